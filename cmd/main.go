@@ -3,8 +3,22 @@ package main
 import (
 	"flag"
 	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+
 	"ludwig/internal/cli"
+	"ludwig/internal/config"
+	"ludwig/internal/kanban"
+	"ludwig/internal/orchestrator"
+	"ludwig/internal/server"
+	"ludwig/internal/storage"
+	"ludwig/internal/types/task"
 	"ludwig/internal/updater"
+	"ludwig/internal/utils"
+
+	"github.com/google/uuid"
+	"golang.org/x/term"
 )
 
 var version = "dev"
@@ -12,6 +26,7 @@ var version = "dev"
 func main() {
 	versionFlag := flag.Bool("version", false, "Print the version and exit")
 	updateFlag := flag.Bool("update", false, "Check for and install updates")
+	rollbackFlag := flag.Bool("rollback", false, "Restore the version replaced by the last update")
 	flag.Parse()
 
 	// Apply any pending updates from previous run
@@ -37,5 +52,220 @@ func main() {
 		return
 	}
 
+	if *rollbackFlag {
+		pending, err := updater.Rollback()
+		if err != nil {
+			fmt.Println("Error: " + err.Error())
+			return
+		}
+		if pending {
+			fmt.Println("Rollback ready! Please restart Ludwig to apply.")
+		} else {
+			fmt.Println("Rolled back to the previous version.")
+		}
+		return
+	}
+
+	if flag.Arg(0) == "serve" {
+		runServe(flag.Args()[1:])
+		return
+	}
+	if flag.Arg(0) == "doctor" {
+		cfg, err := config.LoadConfig()
+		if failed := orchestrator.WriteDoctorReport(os.Stdout, orchestrator.Doctor(cfg, err)); failed > 0 {
+			os.Exit(1)
+		}
+		return
+	}
+	if flag.Arg(0) == "add-file" {
+		runAddFile(flag.Args()[1:])
+		return
+	}
+	if flag.Arg(0) == "run" {
+		runBatch(flag.Args()[1:])
+		return
+	}
+	if flag.Arg(0) == "board" {
+		runBoard()
+		return
+	}
+
 	cli.StartInteractive(version)
 }
+
+// runBoard handles `ludwig board`, printing the kanban once without starting the TUI
+func runBoard() {
+	taskStore, err := storage.NewFileTaskStorage()
+	if err != nil {
+		fmt.Println("Error: " + err.Error())
+		os.Exit(1)
+	}
+	// Oldest first so repeated snapshots of the same tasks are identical
+	tasks, err := taskStore.ListTasksFiltered(storage.ListOptions{SortBy: storage.SortByCreated})
+	if err != nil {
+		fmt.Println("Error: " + err.Error())
+		os.Exit(1)
+	}
+	limit := 0
+	if cfg, err := config.LoadConfig(); err == nil && cfg != nil {
+		limit = cfg.KanbanColumnLimit
+		kanban.SetCustomStatuses(cfg.CustomStatuses)
+	}
+
+	isTerminal := term.IsTerminal(int(os.Stdout.Fd()))
+	width := 0
+	if isTerminal {
+		width = utils.TermWidth()
+	}
+	kanban.WriteSnapshot(os.Stdout, utils.PointerSliceToValueSlice(tasks), limit, width, isTerminal)
+}
+
+// runServe handles `ludwig serve [--addr :8080] [--token TOKEN]`
+func runServe(args []string) {
+	serveFlags := flag.NewFlagSet("serve", flag.ExitOnError)
+	addr := serveFlags.String("addr", server.DEFAULT_ADDR, "Address to serve the task API on")
+	token := serveFlags.String("token", os.Getenv(server.TOKEN_ENV), "Token clients must send as 'Authorization: Bearer <token>' (defaults to $"+server.TOKEN_ENV+")")
+	startOrchestrator := serveFlags.Bool("start", false, "Also run the orchestrator in this process so /events reports its progress")
+	serveFlags.Parse(args)
+
+	taskStore, err := storage.NewFileTaskStorage()
+	if err != nil {
+		fmt.Println("Error: " + err.Error())
+		os.Exit(1)
+	}
+	if *startOrchestrator {
+		cfg, _ := config.LoadConfig()
+		checks, err := orchestrator.Preflight(cfg)
+		for _, check := range checks {
+			if !check.OK {
+				fmt.Println("Warning: " + check.Name + " is not available. " + check.Guidance)
+			}
+		}
+		if err != nil {
+			fmt.Println("Error: " + err.Error())
+			os.Exit(1)
+		}
+		orchestrator.Start()
+		defer orchestrator.Stop()
+		handleSignals(taskStore)
+	}
+	fmt.Println("Serving the ludwig task API on " + *addr)
+	if err := server.ListenAndServe(*addr, *token, taskStore); err != nil {
+		fmt.Println("Error: " + err.Error())
+		os.Exit(1)
+	}
+}
+
+// runAddFile handles `ludwig add-file <path>`, adding a Pending task for each one in
+// the file. With no path or "-", tasks are read from stdin.
+func runAddFile(args []string) {
+	if len(args) > 1 {
+		fmt.Println("Usage: ludwig add-file [path]")
+		os.Exit(2)
+	}
+	input := os.Stdin
+	if len(args) == 1 && args[0] != "-" {
+		file, err := os.Open(args[0])
+		if err != nil {
+			fmt.Println("Error: " + err.Error())
+			os.Exit(1)
+		}
+		defer file.Close()
+		input = file
+	}
+
+	specs, errs := task.ParseTaskList(input)
+	for _, err := range errs {
+		fmt.Println("Skipped: " + err.Error())
+	}
+	taskStore, err := storage.NewFileTaskStorage()
+	if err != nil {
+		fmt.Println("Error: " + err.Error())
+		os.Exit(1)
+	}
+	created := 0
+	for _, spec := range specs {
+		newTask := spec.NewTask(uuid.New().String())
+		if err := taskStore.AddTask(newTask); err != nil {
+			fmt.Println("Error adding " + newTask.Title() + ": " + err.Error())
+			continue
+		}
+		created++
+	}
+	fmt.Printf("Created %d task(s)", created)
+	if skipped := len(specs) - created + len(errs); skipped > 0 {
+		fmt.Printf(", skipped %d", skipped)
+	}
+	fmt.Println()
+	if created < len(specs) || len(errs) > 0 {
+		os.Exit(1)
+	}
+}
+
+// runBatch handles `ludwig run [--concurrency N] [--timeout 30m] [--review fail|first]`,
+// running every queued task to the end and exiting non-zero if any didn't complete
+func runBatch(args []string) {
+	cfg, _ := config.LoadConfig()
+	defaultPolicy := orchestrator.REVIEW_POLICY_FAIL
+	if cfg != nil && cfg.RunReviewPolicy != "" {
+		defaultPolicy = cfg.RunReviewPolicy
+	}
+	runFlags := flag.NewFlagSet("run", flag.ExitOnError)
+	concurrency := runFlags.Int("concurrency", orchestrator.MAX_CONCURRENT_TASKS, "Tasks to run at once")
+	timeout := runFlags.Duration("timeout", 0, "Stop after this long, e.g. 30m, putting running tasks back (default: no limit)")
+	review := runFlags.String("review", defaultPolicy, "What to do when a task asks for review: \"fail\" the task, or answer with its \"first\" option")
+	maxFailures := runFlags.Int("max-failures", orchestrator.DEFAULT_BATCH_MAX_FAILURES, "Failed runs after which a task is marked Failed")
+	runFlags.Parse(args)
+
+	taskStore, err := storage.NewFileTaskStorage()
+	if err != nil {
+		fmt.Println("Error: " + err.Error())
+		os.Exit(1)
+	}
+	checks, err := orchestrator.Preflight(cfg)
+	for _, check := range checks {
+		if !check.OK {
+			fmt.Println("Warning: " + check.Name + " is not available. " + check.Guidance)
+		}
+	}
+	if err != nil {
+		fmt.Println("Error: " + err.Error())
+		os.Exit(1)
+	}
+	handleSignals(taskStore)
+
+	result, err := orchestrator.RunBatch(taskStore, orchestrator.NewClientChain(cfg), orchestrator.BatchOptions{
+		ReviewPolicy: *review,
+		Concurrency:  *concurrency,
+		MaxRuntime:   *timeout,
+		MaxFailures:  *maxFailures,
+	})
+	if result == nil {
+		fmt.Println("Error: " + err.Error())
+		os.Exit(1)
+	}
+	if err != nil {
+		fmt.Println("Error: " + err.Error())
+	}
+	result.WriteSummary(os.Stdout)
+	os.Exit(result.ExitCode())
+}
+
+// handleSignals puts running tasks back and exits on SIGINT or SIGTERM, so a
+// Ctrl+C or a container stop doesn't leave tasks In Progress. The TUI doesn't use
+// it: Bubbletea catches these signals itself and the TUI shuts down once it quits.
+func handleSignals(taskStore storage.TaskStorage) {
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+	go func() {
+		sig := <-signals
+		// A second signal exits straight away if shutting down takes too long
+		signal.Stop(signals)
+		fmt.Println("Received " + sig.String() + ", stopping running tasks...")
+		orchestrator.Shutdown(taskStore, orchestrator.SHUTDOWN_TIMEOUT)
+		if sig == syscall.SIGTERM {
+			os.Exit(143)
+		}
+		os.Exit(130)
+	}()
+}
@@ -1,12 +1,17 @@
 package cli
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"regexp"
+	"strings"
 
 	tea "github.com/charmbracelet/bubbletea"
 
+	"ludwig/internal/config"
+	"ludwig/internal/logger"
+	"ludwig/internal/orchestrator"
 	"ludwig/internal/storage"
 	"ludwig/internal/types/model"
 )
@@ -19,11 +24,26 @@ func StartInteractive(version string) {
 		os.Exit(1)
 	}
 
+	// Keep .ludwig and the worktrees out of the user's commits
+	cfg, _ := config.LoadConfig()
+	if added, err := orchestrator.IgnoreLudwigFiles(cfg); err != nil {
+		logger.Warnf("Could not update .gitignore: %v", err)
+	} else if len(added) > 0 {
+		logger.Infof("Added %s to .gitignore", strings.Join(added, ", "))
+	}
+
 	m := model.NewModel(taskStore, version)
 
+	// Bubbletea handles SIGINT and SIGTERM itself, restoring the terminal and
+	// returning from Run, so running tasks are put back here however it quits
 	p := tea.NewProgram(m, tea.WithAltScreen(), tea.WithMouseCellMotion())
+	_, err = p.Run()
+	orchestrator.Shutdown(taskStore, orchestrator.SHUTDOWN_TIMEOUT)
 
-	if _, err := p.Run(); err != nil {
+	if errors.Is(err, tea.ErrInterrupted) {
+		os.Exit(130)
+	}
+	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error running program: %v\n", err)
 		os.Exit(1)
 	}
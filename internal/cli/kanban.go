@@ -151,8 +151,8 @@ func RenderKanban(tasks []task.Task) string {
 				line.WriteString(KanbanTaskName("", status))
 				continue;
 			}
-			task := taskLists[status][i]
-			displayText := "#" + strconv.Itoa(slices.Index(tasks, task)) + " " + task.Name
+			t := taskLists[status][i]
+			displayText := "#" + strconv.Itoa(slices.IndexFunc(tasks, func(other task.Task) bool { return other.ID == t.ID })) + " " + t.Name
 			index++
 			line.WriteString(KanbanTaskName(displayText, status))
 		}
@@ -0,0 +1,227 @@
+package commandFinder
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+
+	"ludwig/internal/utils"
+)
+
+var BORDER_STYLE = lipgloss.NewStyle().
+	Border(lipgloss.RoundedBorder()).
+	BorderForeground(lipgloss.Color("62")).
+	Padding(0, 1).
+	Margin(1, 1)
+
+var (
+	SELECTED_STYLE    = lipgloss.NewStyle().Foreground(lipgloss.Color("62")).Bold(true)
+	DESCRIPTION_STYLE = lipgloss.NewStyle().Faint(true)
+)
+
+const FINDER_CONTROLS = "(Type to filter, Up/Down to choose, Enter to run, Esc to close)"
+
+// OPEN_KEY opens the finder from the board
+const OPEN_KEY = tea.KeyCtrlP
+
+// MAX_VISIBLE_MATCHES is how many matches are listed at once
+const MAX_VISIBLE_MATCHES = 10
+
+// Entry is a command the finder can pick
+type Entry struct {
+	Name        string
+	Usage       string // Arguments after the name, e.g. "<task ref> [run]"
+	Description string
+}
+
+// SelectMsg is sent when a command is chosen with Enter
+type SelectMsg struct {
+	Entry Entry
+}
+
+// CancelMsg is sent when the finder is closed with Esc
+type CancelMsg struct{}
+
+// Model is an overlay listing commands, fuzzy filtered by what has been typed
+type Model struct {
+	input    textinput.Model
+	entries  []Entry
+	matches  []Entry
+	selected int // Index into matches
+	width    int // Terminal width, updated from tea.WindowSizeMsg
+}
+
+func NewModel(entries []Entry) Model {
+	ti := textinput.New()
+	ti.Prompt = "> "
+	ti.Placeholder = "Search commands"
+	ti.Focus()
+	m := Model{input: ti, entries: entries, width: utils.TermWidth()}
+	m.filter()
+	return m
+}
+
+// Matches returns the commands matching the query, best first
+func (m Model) Matches() []Entry {
+	return m.matches
+}
+
+// Selected returns the highlighted command, false if nothing matches
+func (m Model) Selected() (Entry, bool) {
+	if len(m.matches) == 0 {
+		return Entry{}, false
+	}
+	return m.matches[m.selected], true
+}
+
+func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		return m, nil
+	case tea.KeyMsg:
+		switch msg.Type {
+		case tea.KeyEsc, tea.KeyCtrlC:
+			return m, func() tea.Msg { return CancelMsg{} }
+		case tea.KeyUp, tea.KeyShiftTab:
+			if len(m.matches) > 0 {
+				m.selected = (m.selected + len(m.matches) - 1) % len(m.matches)
+			}
+			return m, nil
+		case tea.KeyDown, tea.KeyTab:
+			if len(m.matches) > 0 {
+				m.selected = (m.selected + 1) % len(m.matches)
+			}
+			return m, nil
+		case tea.KeyEnter:
+			entry, ok := m.Selected()
+			if !ok {
+				return m, nil
+			}
+			return m, func() tea.Msg { return SelectMsg{Entry: entry} }
+		}
+	}
+
+	var cmd tea.Cmd
+	query := m.input.Value()
+	m.input, cmd = m.input.Update(msg)
+	if m.input.Value() != query {
+		m.filter()
+	}
+	return m, cmd
+}
+
+// filter refreshes the matches for the current query and highlights the best one
+func (m *Model) filter() {
+	m.matches = Match(m.input.Value(), m.entries)
+	m.selected = 0
+}
+
+// Match returns the entries whose name contains the letters of query in order, or
+// whose description contains query as written, best first. Name matches rank above
+// description matches; among names, letters at the start of a word and runs of
+// consecutive letters score higher. Ties keep the entries' order.
+func Match(query string, entries []Entry) []Entry {
+	query = strings.ToLower(strings.TrimSpace(query))
+	if query == "" {
+		return append([]Entry(nil), entries...)
+	}
+
+	type scored struct {
+		entry Entry
+		score int
+	}
+	var found []scored
+	for _, entry := range entries {
+		if score, ok := fuzzyScore(query, entry.Name); ok {
+			found = append(found, scored{entry, score + NAME_BONUS})
+		} else if index := strings.Index(strings.ToLower(entry.Description), query); index >= 0 {
+			// Descriptions are long enough that scattered letters match almost anything
+			found = append(found, scored{entry, -index})
+		}
+	}
+	sort.SliceStable(found, func(i, j int) bool { return found[i].score > found[j].score })
+
+	matches := make([]Entry, len(found))
+	for i, f := range found {
+		matches[i] = f.entry
+	}
+	return matches
+}
+
+// Scores for fuzzyScore. NAME_BONUS lifts any name match above every description match.
+const (
+	NAME_BONUS        = 10000
+	WORD_START_BONUS  = 10
+	CONSECUTIVE_BONUS = 5
+)
+
+// fuzzyScore reports whether the letters of query appear in order in text, scoring
+// how closely: each letter at the start of a word or straight after the previous
+// match earns a bonus, and every skipped letter costs a point
+func fuzzyScore(query, text string) (int, bool) {
+	runes := []rune(strings.ToLower(text))
+	score := 0
+	last := -1
+	pos := 0
+	for _, q := range query {
+		for pos < len(runes) && runes[pos] != q {
+			pos++
+		}
+		if pos == len(runes) {
+			return 0, false
+		}
+		if pos == 0 || !unicode.IsLetter(runes[pos-1]) && !unicode.IsDigit(runes[pos-1]) {
+			score += WORD_START_BONUS
+		}
+		if last >= 0 && pos == last+1 {
+			score += CONSECUTIVE_BONUS
+		}
+		score -= pos - last - 1
+		last = pos
+		pos++
+	}
+	return score, true
+}
+
+func (m Model) View() string {
+	width := max(m.width-4, 24)
+	var s strings.Builder
+	s.WriteString(m.input.View() + "\n")
+
+	// Scroll so the highlighted command stays in view
+	start := max(m.selected-MAX_VISIBLE_MATCHES+1, 0)
+	end := min(start+MAX_VISIBLE_MATCHES, len(m.matches))
+	for i := start; i < end; i++ {
+		line := strings.TrimSpace(m.matches[i].Name + " " + m.matches[i].Usage)
+		if m.matches[i].Description != "" {
+			line += "  " + DESCRIPTION_STYLE.Render(m.matches[i].Description)
+		}
+		line = ansi.Truncate(line, width-4, "…")
+		if i == m.selected {
+			s.WriteString("\n" + SELECTED_STYLE.Render("▸ ") + line)
+		} else {
+			s.WriteString("\n  " + line)
+		}
+	}
+	if len(m.matches) == 0 {
+		s.WriteString("\n" + DESCRIPTION_STYLE.Render("  No matching commands"))
+	} else if len(m.matches) > MAX_VISIBLE_MATCHES {
+		s.WriteString("\n" + DESCRIPTION_STYLE.Render(fmt.Sprintf("  %d of %d shown", end-start, len(m.matches))))
+	}
+	s.WriteString("\n\n" + DESCRIPTION_STYLE.Render(FINDER_CONTROLS))
+
+	return BORDER_STYLE.Width(width).Render(s.String()) + "\n"
+}
+
+// TakesArguments reports whether a command's usage lists required arguments
+// such as "<task ref>"
+func TakesArguments(entry Entry) bool {
+	return strings.Contains(entry.Usage, "<")
+}
@@ -17,12 +17,14 @@ var BORDER_STYLE = lipgloss.NewStyle().
 type Model struct {
 	TextInput textarea.Model
 	Height int
+	width int // Terminal width, updated from tea.WindowSizeMsg
 }
 
 func NewModel() Model {
+	width := utils.TermWidth()
 	ti := textarea.New()
 	ti.Placeholder = "...Enter command (e.g., 'add <task>', 'exit', 'help')"
-	ti.SetWidth(utils.TermWidth() - 6) // Account for border padding
+	ti.SetWidth(width - 6) // Account for border padding
 	ti.SetHeight(2)                     // Start with minimum height
 	ti.FocusedStyle.CursorLine = lipgloss.NewStyle()
 	ti.BlurredStyle.CursorLine = lipgloss.NewStyle()
@@ -31,12 +33,42 @@ func NewModel() Model {
 	ti.Focus()
 	return Model{
 		TextInput: ti,
+		width: width,
 	}
 }
 
 func (m *Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 	var cmd tea.Cmd
 	m.TextInput, cmd = m.TextInput.Update(msg)
+	m.fitHeight()
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		inputWidth := max(m.width-6, 20) // Account for border + padding
+		m.TextInput.SetWidth(inputWidth)
+		return *m, nil
+	}
+
+	m.Height = m.TextInput.Height()
+	return *m, cmd
+}
+
+// SetValue replaces the input's contents, e.g. with a recalled command, and resizes to fit
+func (m *Model) SetValue(value string) {
+	m.TextInput.SetValue(value)
+	m.fitHeight()
+	m.Height = m.TextInput.Height()
+}
+
+// InsertNewline adds a line break at the cursor and resizes to fit
+func (m *Model) InsertNewline() {
+	m.TextInput.InsertString("\n")
+	m.fitHeight()
+	m.Height = m.TextInput.Height()
+}
+
+// fitHeight grows or shrinks the textarea to the number of wrapped lines in its contents
+func (m *Model) fitHeight() {
 	content := m.TextInput.Value()
 	if content == "" {
 		m.TextInput.SetHeight(2)
@@ -44,7 +76,7 @@ func (m *Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 		// Calculate wrapped lines based on textarea width
 		width := m.TextInput.Width()
 		if width <= 0 {
-			width = utils.TermWidth() - 6
+			width = m.width - 6
 		}
 		wrappedLines := 1
 		currentLineLength := 0
@@ -67,21 +99,10 @@ func (m *Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 		}
 		m.TextInput.SetHeight(wrappedLines + 1)
 	}
-	switch msg := msg.(type) {
-	case tea.WindowSizeMsg:
-		termWidth := msg.Width
-		inputWidth := max(termWidth-6, 20) // Account for border + padding
-		m.TextInput.SetWidth(inputWidth)
-		return *m, nil
-	}
-
-	m.Height = m.TextInput.Height()
-	return *m, cmd
 }
 
 func (m *Model) View() string {
-	termWidth := utils.TermWidth()
-	inputWidth := max(termWidth - 6, 20) // Account for border (4) + padding (2)
+	inputWidth := max(m.width - 6, 20) // Account for border (4) + padding (2)
 	m.TextInput.SetWidth(inputWidth)
 
 	// Render the middle of the bubble with the input
@@ -2,15 +2,26 @@ package orchestratorIndicator
 
 import (
 	"ludwig/internal/orchestrator"
+	"ludwig/internal/utils"
+	"fmt"
 	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
 )
 
 type animationTickMsg struct{}
 type Model struct {
 	animationFrame int
+	Expanded bool // Show the current task and its runtime after the animation
+	AutoStarted bool // The orchestrator was started on launch by the autoStart option
+	width int     // Terminal width, updated from tea.WindowSizeMsg
+
+	// Where the indicator reads orchestrator state from; replaceable in tests
+	IsRunning func() bool
+	Running func() []orchestrator.RunningTask
+	Now func() time.Time
 }
 
 var frames = [6]string{
@@ -23,11 +34,19 @@ var frames = [6]string{
 }
 const frameInterval = 180 * time.Millisecond
 
+// TOGGLE_KEY switches between the compact and expanded indicator
+const TOGGLE_KEY = tea.KeyCtrlO
+
 var indicatorStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("#99ee99")).Bold(true)
+var detailStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("#99ee99"))
 
 func NewModel() *Model {
 	return &Model{
 		animationFrame: 0,
+		width: utils.TermWidth(),
+		IsRunning: orchestrator.IsRunning,
+		Running: orchestrator.RunningTasks,
+		Now: time.Now,
 	}
 }
 
@@ -38,14 +57,26 @@ func (m *Model) Init() tea.Cmd {
 	})
 }
 
+// Toggle switches between the compact and expanded indicator
+func (m *Model) Toggle() {
+	m.Expanded = !m.Expanded
+}
+
 func (m *Model) Update(msg tea.Msg) (*Model, tea.Cmd) {
-	switch msg.(type) {
+	switch msg := msg.(type) {
 	case animationTickMsg:
 		m.animationFrame++
+		// Once stopped, a later start is the user's own
+		if m.AutoStarted && !m.IsRunning() {
+			m.AutoStarted = false
+		}
 		// Always schedule the next tick, but only show animation when running
 		return m, tea.Tick(frameInterval, func(time.Time) tea.Msg {
 			return animationTickMsg{}
 		})
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		return m, nil
 
 	// You can also handle other messages (keys, window size, etc.)
 	default:
@@ -54,8 +85,30 @@ func (m *Model) Update(msg tea.Msg) (*Model, tea.Cmd) {
 }
 
 func (m *Model) View() string {
-	if !orchestrator.IsRunning() {
+	if !m.IsRunning() {
 		return ""
 	}
-	return indicatorStyle.Render(frames[m.animationFrame%len(frames)])
+	view := indicatorStyle.Render(frames[m.animationFrame%len(frames)])
+	if m.AutoStarted {
+		view += detailStyle.Render(" (auto-started)")
+	}
+	if m.Expanded {
+		view += detailStyle.Render(m.detail())
+	}
+	// Stay on one line so toggling never moves the rest of the screen
+	return ansi.Truncate(view, max(m.width-1, 1), "…")
+}
+
+// detail describes the longest running task, e.g. " · Fix login (2m5s) +1 more"
+func (m *Model) detail() string {
+	running := m.Running()
+	if len(running) == 0 {
+		return " · waiting for tasks"
+	}
+	current := running[0]
+	detail := fmt.Sprintf(" · %s (%s)", current.Task.Title(), m.Now().Sub(current.Since).Round(time.Second))
+	if len(running) > 1 {
+		detail += fmt.Sprintf(" +%d more", len(running)-1)
+	}
+	return detail
 }
@@ -5,38 +5,95 @@ import (
 	"github.com/charmbracelet/bubbles/viewport"
 	"github.com/charmbracelet/bubbles/spinner"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
 
 	"ludwig/internal/components/progressBar"
+	"ludwig/internal/logger"
+	"ludwig/internal/storage"
 	"ludwig/internal/types/task"
 	"ludwig/internal/utils"
 	"ludwig/internal/orchestrator"
 
+	"errors"
+	"fmt"
+	"os"
 	"time"
 	"strings"
 )
 
 var LOADING_STYLE = lipgloss.NewStyle().Foreground(lipgloss.Color("62"))
+// BUBBLE_STYLE is sized per render from the model's stored window size
 var BUBBLE_STYLE = lipgloss.NewStyle().
 	Border(lipgloss.RoundedBorder()).
-	Width(utils.TermWidth() - 5).
-	Height(utils.TermHeight() - 8).
 	BorderForeground(lipgloss.Color("62")).
 	Padding(0, 1).
 	Margin(1, 1)
 
-const VIEWPORT_CONTROLS = "\n(Press Ctrl+S to scroll down, Ctrl+W to scroll up, Esc to exit view)"
+const VIEWPORT_CONTROLS = "\n(Press ↑/↓ to scroll a line, Ctrl+S/Ctrl+W half a page, PgDn/PgUp a page, Home/End to jump to the top/bottom, / to search, Ctrl+F to load full output, Tab to filter, Ctrl+Y to copy, Esc to exit view)"
+
+// TAIL_BYTES is how much of a response file is loaded by default
+const TAIL_BYTES int64 = 256 * 1024
+
+// LIVE_REFRESH_DELAY is how long new output from a running task is left to build up
+// before the view refreshes, so a fast stream isn't read a line at a time
+const LIVE_REFRESH_DELAY = 100 * time.Millisecond
+
+// DEFAULT_MAX_LINES is how many lines of output the view keeps unless the
+// viewportMaxLines option says otherwise
+const DEFAULT_MAX_LINES = 10000
+
+var TRUNCATED_STYLE = lipgloss.NewStyle().Faint(true)
+
+// Checklist styles for the work-in-progress panel shown above a task's output
+var (
+	CHECK_DONE_STYLE    = lipgloss.NewStyle().Foreground(lipgloss.Color("42"))
+	CHECK_PENDING_STYLE = lipgloss.NewStyle().Faint(true)
+)
+
+// MAX_CHECKLIST_LINES caps the panel so the output keeps most of the screen
+const MAX_CHECKLIST_LINES = 8
+
+// MAX_COMMENT_LINES caps the comments panel the same way
+const MAX_COMMENT_LINES = 4
+
+// MAX_SUMMARY_LINES caps the summary panel of a completed task
+const MAX_SUMMARY_LINES = 4
+
+// MAX_DIFFSTAT_LINES caps the files listed in the review panel; the total is always shown
+const MAX_DIFFSTAT_LINES = 6
+
+var REVIEW_STYLE = lipgloss.NewStyle().Foreground(lipgloss.Color("214")).Bold(true)
 
 type Model struct {
 	viewport viewport.Model
 	progressBar progressBar.Model
+	responseFile string // Path relative to .ludwig
 	filePath string
+	fullLoaded bool
 	ViewingTask *task.Task
-	fileChangeInfo *utils.FileChangeInfo
+	stream *utils.OutputStream // Parsing state for incremental reads; nil when not viewing
+	offset int64               // Bytes of the response file consumed so far
+	content strings.Builder    // Rendered output shown in the viewport
+	loopID int                 // Identifies the active update loop so stale loops exit
+	output <-chan string       // Output of the viewed task, if it runs in this process
+	unsubscribe func()         // Stops output, nil when not listening
+	logLines int               // When > 0 the viewport follows the orchestrator log instead of a task
+	logSeq uint64              // Newest log entry currently shown
+	width int                  // Terminal size, updated from tea.WindowSizeMsg
+	height int
 	spinner  spinner.Model
+	clipboard utils.Clipboard
+	notice string               // One-off message shown under the controls, e.g. after copying
+	filter utils.OutputFilter   // Which events of the task's output are shown
+	search *search              // Active search, nil when not searching
+	input *searchInput          // Query being typed after '/', nil otherwise
+	following bool              // Opened by follow, which closes the view when the task finishes
+	maxLines int                // Most lines of output kept; older ones are trimmed. 0 for no limit
+	trimmed int                 // Lines of output trimmed off the top since it was loaded
 }
 
 func NewModel() Model {
-	vp := viewport.New(utils.TermWidth()-6, utils.TermHeight()-6)
+	vp := viewport.New(0, 0)
 	vp.MouseWheelEnabled = true
 	vp.MouseWheelDelta = 3
 	vp.Style.Padding(0, 0)
@@ -48,39 +105,491 @@ func NewModel() Model {
 	sp.Spinner = spinner.Dot
 	sp.Style = LOADING_STYLE
 
-	return Model{
+	m := Model{
 		viewport: vp,
 		progressBar: progressBar.NewModel(&vp),
 		spinner: sp,
+		clipboard: utils.SystemClipboard{},
+		maxLines: DEFAULT_MAX_LINES,
+	}
+	// Query the terminal once; later changes arrive as tea.WindowSizeMsg
+	m.SetSize(utils.TermWidth(), utils.TermHeight())
+	return m
+}
+
+// SetSize resizes the viewport to fit a terminal of the given dimensions
+func (m *Model) SetSize(width, height int) {
+	m.width = width
+	m.height = height
+	m.viewport.Width = width - 14
+	m.progressBar.Width = width
+	m.fitViewport()
+}
+
+// fitViewport gives the viewport whatever height the panels above it leave over
+func (m *Model) fitViewport() {
+	m.viewport.Height = m.height - 6
+	if panels := m.panels(); panels != "" {
+		m.viewport.Height -= strings.Count(panels, "\n") + 2
+	}
+}
+
+// panels renders the follow status, checklist, comments and filter shown above the
+// output, or ""
+func (m *Model) panels() string {
+	var shown []string
+	for _, panel := range []string{m.followLine(), m.trimmedLine(), m.ReviewPanel(), m.SummaryPanel(), m.Checklist(), m.Comments(), m.filterLine()} {
+		if panel != "" {
+			shown = append(shown, panel)
+		}
+	}
+	return strings.Join(shown, "\n\n")
+}
+
+// ReviewPanel renders the question a task is waiting on, its options and the files
+// changed so far, or "" if the task isn't waiting for review
+func (m *Model) ReviewPanel() string {
+	if m.ViewingTask == nil || m.ViewingTask.Status != task.NeedsReview || m.ViewingTask.Review == nil {
+		return ""
+	}
+	review := m.ViewingTask.Review
+	width := max(m.viewport.Width, 1)
+
+	lines := []string{ansi.Truncate(REVIEW_STYLE.Render("Needs review: ")+review.Question, width, "…")}
+	if review.Context != "" {
+		lines = append(lines, ansi.Truncate(TRUNCATED_STYLE.Render(review.Context), width, "…"))
+	}
+	for i, opt := range review.Options {
+		lines = append(lines, ansi.Truncate(fmt.Sprintf("  %d. %s", i+1, opt.Label), width, "…"))
+	}
+
+	if stat := strings.Split(strings.TrimSpace(review.DiffStat), "\n"); review.DiffStat != "" {
+		lines = append(lines, "", TRUNCATED_STYLE.Render("Changes so far:"))
+		files, total := stat[:len(stat)-1], stat[len(stat)-1]
+		if len(files) > MAX_DIFFSTAT_LINES {
+			files = append(files[:MAX_DIFFSTAT_LINES-1], fmt.Sprintf(" (%d more files)", len(files)-(MAX_DIFFSTAT_LINES-1)))
+		}
+		for _, line := range append(files, total) {
+			lines = append(lines, ansi.Truncate(line, width, "…"))
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// Filter returns which events of the task's output are shown
+func (m *Model) Filter() utils.OutputFilter {
+	return m.filter
+}
+
+// SetFilter re-renders the task's output showing only the events filter lets through.
+// The filter stays in place as new output arrives, until another task is viewed.
+func (m *Model) SetFilter(filter utils.OutputFilter) {
+	if m.ViewingTask == nil || m.logLines > 0 || filter == m.filter {
+		return
 	}
+	m.filter = filter
+	m.fitViewport()
+	m.loadContent()
+	m.viewport.GotoBottom()
 }
 
-func (m *Model) SetViewingTask(t *task.Task, filePath string) *Model {
+// filterLine notes the active filter, or "" when everything is shown
+func (m *Model) filterLine() string {
+	if m.ViewingTask == nil || m.logLines > 0 || m.filter == utils.FILTER_ALL {
+		return ""
+	}
+	return TRUNCATED_STYLE.Render("Showing " + m.filter.String() + " only (Tab for " + m.filter.Next().String() + ")")
+}
+
+// Comments renders the viewed task's latest comments, one line each, or "" if it has none
+func (m *Model) Comments() string {
+	if m.ViewingTask == nil || len(m.ViewingTask.Comments) == 0 {
+		return ""
+	}
+	comments := m.ViewingTask.Comments
+	var lines []string
+	if len(comments) > MAX_COMMENT_LINES {
+		hidden := len(comments) - (MAX_COMMENT_LINES - 1)
+		lines = append(lines, TRUNCATED_STYLE.Render(fmt.Sprintf("(%d earlier comments)", hidden)))
+		comments = comments[hidden:]
+	}
+	for _, comment := range comments {
+		text := strings.Join(strings.Fields(comment.Text), " ")
+		line := TRUNCATED_STYLE.Render(comment.At.Format("2006-01-02 15:04")) + " " + text
+		lines = append(lines, ansi.Truncate(line, max(m.viewport.Width, 1), "…"))
+	}
+
+	header := TRUNCATED_STYLE.Render(fmt.Sprintf("Comments: %d", len(m.ViewingTask.Comments)))
+	return header + "\n" + strings.Join(lines, "\n")
+}
+
+// SummaryPanel renders what the viewed task did, or "" if it has no summary
+func (m *Model) SummaryPanel() string {
+	if m.ViewingTask == nil || m.ViewingTask.Summary == "" {
+		return ""
+	}
+	lines := strings.Split(strings.TrimSpace(m.ViewingTask.Summary), "\n")
+	if len(lines) > MAX_SUMMARY_LINES {
+		lines = append(lines[:MAX_SUMMARY_LINES-1], fmt.Sprintf("(%d more lines)", len(lines)-(MAX_SUMMARY_LINES-1)))
+	}
+	for i, line := range lines {
+		lines[i] = ansi.Truncate(line, max(m.viewport.Width, 1), "…")
+	}
+	return CHECK_DONE_STYLE.Render("Summary:") + "\n" + strings.Join(lines, "\n")
+}
+
+// Checklist renders the viewed task's work-in-progress as a checklist, or "" if
+// there is nothing to show. Only the latest items fit when the list is long.
+func (m *Model) Checklist() string {
+	if m.ViewingTask == nil || m.ViewingTask.WorkInProgress == "" {
+		return ""
+	}
+	items := task.ParseProgress(m.ViewingTask.WorkInProgress)
+	if len(items) == 0 {
+		return ""
+	}
+	done, total := task.CountProgress(items)
+
+	var lines []string
+	if len(items) > MAX_CHECKLIST_LINES {
+		hidden := len(items) - (MAX_CHECKLIST_LINES - 1)
+		lines = append(lines, TRUNCATED_STYLE.Render(fmt.Sprintf("(%d earlier items)", hidden)))
+		items = items[hidden:]
+	}
+	for _, item := range items {
+		var line string
+		switch {
+		case item.Note:
+			line = item.Text
+		case item.Done:
+			line = CHECK_DONE_STYLE.Render("✓ " + item.Text)
+		default:
+			line = CHECK_PENDING_STYLE.Render("○ " + item.Text)
+		}
+		lines = append(lines, ansi.Truncate(line, max(m.viewport.Width, 1), "…"))
+	}
+
+	header := TRUNCATED_STYLE.Render(fmt.Sprintf("Progress: %d/%d done", done, total))
+	return header + "\n" + strings.Join(lines, "\n")
+}
+
+// SetViewingTask points the viewport at a task's response file (relative to .ludwig)
+// Only the tail of large files is loaded until the user asks for the full output
+func (m *Model) SetViewingTask(t *task.Task, responseFile string) *Model {
+	m.logLines = 0
+	m.following = false
 	m.ViewingTask = t
-	m.filePath = filePath
-	content := utils.OutputLines(strings.Split(utils.ReadFileAsString(filePath), "\n"))
-	m.viewport.SetContent(content)
+	m.filter = utils.FILTER_ALL
+	m.search, m.input = nil, nil
+	m.fitViewport()
+	m.responseFile = responseFile
+	m.filePath, _ = storage.ResponsePath(responseFile) // A failure shows when the file is read
+	m.fullLoaded = false
+	m.loadContent()
 	m.viewport.GotoBottom()
-	m.fileChangeInfo, _ = utils.InitFileChangeInfo(filePath)
 	return m
 }
 
+// UpdateViewingTask swaps in a fresh copy of the task being viewed, keeping its
+// output and scroll position, and refits the viewport to panels that may have
+// grown or shrunk with it, e.g. once a review or summary is added
+func (m *Model) UpdateViewingTask(t *task.Task) {
+	m.ViewingTask = t
+	m.fitViewport()
+}
+
+// SetViewingLogs points the viewport at the last n orchestrator log lines
+// Refresh keeps it following new entries as they're logged
+func (m *Model) SetViewingLogs(n int) *Model {
+	m.ViewingTask = nil
+	m.following = false
+	m.search, m.input = nil, nil
+	m.fitViewport()
+	m.stream = nil
+	m.logLines = n
+	m.logSeq = 0
+	m.refreshLogs()
+	m.viewport.GotoBottom()
+	return m
+}
+
+// refreshLogs re-renders the log view if anything was logged since the last render
+func (m *Model) refreshLogs() bool {
+	seq := logger.LastSeq()
+	if seq == m.logSeq && m.content.Len() > 0 {
+		return false
+	}
+	m.logSeq = seq
+
+	m.content.Reset()
+	entries := logger.Recent(m.logLines)
+	if len(entries) == 0 {
+		m.content.WriteString(TRUNCATED_STYLE.Render("(No orchestrator activity yet. Run 'start' to begin processing tasks.)"))
+	}
+	for _, entry := range entries {
+		m.content.WriteString(entry.String() + "\n")
+	}
+
+	atBottom := m.viewport.AtBottom() || m.viewport.ScrollPercent() > 0.95
+	m.setContent()
+	if atBottom {
+		m.viewport.GotoBottom()
+	}
+	return true
+}
+
+// Follow marks the task being viewed as followed and jumps to the newest output.
+// New output keeps the view at the bottom unless the user scrolls up; End resumes.
+func (m *Model) Follow() {
+	m.following = true
+	m.fitViewport()
+	m.viewport.GotoBottom()
+}
+
+// Following reports whether the viewed task was opened by follow
+func (m *Model) Following() bool {
+	return m.following && m.ViewingTask != nil && m.stream != nil
+}
+
+// Close clears the view and stops following its output
+func (m *Model) Close() {
+	m.viewport.SetContent("")
+	m.content.Reset()
+	m.search = nil
+	m.stream = nil // Stops the update loop
+	m.logLines = 0
+	m.following = false
+	m.trimmed = 0
+}
+
+// ResponseFile returns the response file being viewed, relative to .ludwig
+func (m *Model) ResponseFile() string {
+	return m.responseFile
+}
+
+// followLine names the followed task and says whether new output is being kept in view
+func (m *Model) followLine() string {
+	if !m.Following() {
+		return ""
+	}
+	if m.viewport.AtBottom() {
+		return LOADING_STYLE.Render("Following " + m.ViewingTask.Title() + " until it finishes")
+	}
+	return LOADING_STYLE.Render("Paused following " + m.ViewingTask.Title() + " (End to resume)")
+}
+
+// trimmedLine says that earlier output was trimmed and where to find it
+func (m *Model) trimmedLine() string {
+	if m.trimmed == 0 || m.logLines > 0 {
+		return ""
+	}
+	return TRUNCATED_STYLE.Render(fmt.Sprintf("(%d earlier lines trimmed to keep the view responsive. They're in %s, or press Ctrl+F to load the full output.)", m.trimmed, m.filePath))
+}
+
+// SetMaxLines sets how many lines of output are kept before the oldest are
+// trimmed: 0 for DEFAULT_MAX_LINES, negative for no limit
+func (m *Model) SetMaxLines(n int) {
+	switch {
+	case n == 0:
+		m.maxLines = DEFAULT_MAX_LINES
+	case n < 0:
+		m.maxLines = 0
+	default:
+		m.maxLines = n
+	}
+}
+
+// trimContent drops the oldest lines of output past maxLines, returning how many
+// it dropped. Output loaded in full with Ctrl+F is kept whole, since the user asked
+// for all of it.
+func (m *Model) trimContent() int {
+	if m.maxLines == 0 || m.fullLoaded {
+		return 0
+	}
+	content := m.content.String()
+	excess := strings.Count(content, "\n") - m.maxLines
+	if excess <= 0 {
+		return 0
+	}
+	cut := 0
+	for i := 0; i < excess; i++ {
+		cut += strings.IndexByte(content[cut:], '\n') + 1
+	}
+	m.content.Reset()
+	m.content.WriteString(content[cut:])
+	if m.trimmed == 0 {
+		defer m.fitViewport() // The trimmed panel now takes up room
+	}
+	m.trimmed += excess
+	return excess
+}
+
+// LoadFull replaces the tail view with the entire response file
+func (m *Model) LoadFull() {
+	m.fullLoaded = true
+	m.loadContent()
+}
+
+// SetClipboard replaces where Ctrl+Y copies to
+func (m *Model) SetClipboard(c utils.Clipboard) {
+	m.clipboard = c
+}
+
+// Notice returns the message shown under the controls, if any
+func (m *Model) Notice() string {
+	return m.notice
+}
+
+// CopyContent copies the output being viewed, without styling, to the clipboard
+// and sets a notice saying whether it worked
+func (m *Model) CopyContent() {
+	text := ansi.Strip(m.content.String())
+	if strings.TrimSpace(text) == "" {
+		m.notice = "Nothing to copy yet."
+		return
+	}
+	err := m.clipboard.WriteAll(text)
+	switch {
+	case errors.Is(err, utils.ErrNoClipboard):
+		m.notice = "Couldn't copy: " + utils.ErrNoClipboard.Error() + "."
+	case err != nil:
+		m.notice = "Couldn't copy: " + err.Error()
+	default:
+		m.notice = fmt.Sprintf("Copied %d lines to the clipboard.", strings.Count(strings.TrimRight(text, "\n"), "\n")+1)
+	}
+}
+
+// setContent puts the latest output in the viewport, re-running any active search over it
+func (m *Model) setContent() {
+	if m.search != nil {
+		m.findMatches()
+	}
+	m.showContent()
+}
+
+// ScrollOffset returns how many lines of output are scrolled past the top of the viewport
+func (m *Model) ScrollOffset() int {
+	return m.viewport.YOffset
+}
+
+// Content returns the rendered output currently held by the viewport
+func (m *Model) Content() string {
+	return m.content.String()
+}
+
+// loadContent (re)reads the response file from scratch, either fully or just its tail
+func (m *Model) loadContent() {
+	m.content.Reset()
+	m.offset = 0
+	if m.trimmed > 0 {
+		m.trimmed = 0
+		defer m.fitViewport() // The trimmed panel is gone
+	}
+	m.stream = utils.NewOutputStream()
+
+	if !m.fullLoaded && m.isLarge() {
+		stat, _ := os.Stat(m.filePath)
+		m.offset = stat.Size() - TAIL_BYTES
+		m.stream = utils.NewTailOutputStream()
+		notice := TRUNCATED_STYLE.Render(fmt.Sprintf("(Showing the last %d KB. Press Ctrl+F to load the full output.)", TAIL_BYTES/1024))
+		m.content.WriteString(notice + "\n")
+	}
+	m.stream.Filter = m.filter
+
+	chunk, offset, err := storage.ReadResponseFrom(m.responseFile, m.offset)
+	if err != nil {
+		m.content.WriteString("Error reading file: " + err.Error() + "\npath: " + m.filePath)
+		m.setContent()
+		return
+	}
+	// A tail read starts mid-line; drop the partial first line
+	if m.offset > 0 {
+		if newline := strings.IndexByte(chunk, '\n'); newline != -1 {
+			chunk = chunk[newline+1:]
+		}
+	}
+	m.offset = offset
+	m.content.WriteString(m.stream.Feed(chunk))
+	m.trimContent()
+	m.setContent()
+}
+
+// Refresh appends anything written to the response file since the last read
+// Returns true if new output was added
+func (m *Model) Refresh() bool {
+	if m.logLines > 0 {
+		return m.refreshLogs()
+	}
+	if m.stream == nil {
+		return false
+	}
+
+	stat, err := os.Stat(m.filePath)
+	if err != nil {
+		return false
+	}
+	if stat.Size() < m.offset {
+		// File was replaced or truncated; start over
+		m.loadContent()
+		return true
+	}
+	if stat.Size() == m.offset {
+		return false
+	}
+
+	chunk, offset, err := storage.ReadResponseFrom(m.responseFile, m.offset)
+	if err != nil {
+		return false
+	}
+	m.offset = offset
+	rendered := m.stream.Feed(chunk)
+	if rendered == "" {
+		return false
+	}
+
+	atBottom := m.viewport.AtBottom() || m.viewport.ScrollPercent() > 0.95
+	m.content.WriteString(rendered)
+	trimmed := m.trimContent()
+	m.setContent()
+	if atBottom {
+		m.viewport.GotoBottom()
+	} else if trimmed > 0 {
+		// Keep the lines being read in place as the ones above them go
+		m.viewport.SetYOffset(max(m.viewport.YOffset-trimmed, 0))
+	}
+	return true
+}
+
+// isLarge reports whether the response file exceeds the tail window
+func (m *Model) isLarge() bool {
+	stat, err := os.Stat(m.filePath)
+	return err == nil && stat.Size() > TAIL_BYTES
+}
+
 func (m *Model) View() string {
 	var s strings.Builder
 
 	s.WriteString(m.progressBar.View())
 	// Render full screen output view
 
-	spinnerOn := m.ViewingTask.Status == task.InProgress && orchestrator.IsRunning()
+	spinnerOn := m.ViewingTask != nil && m.ViewingTask.Status == task.InProgress && orchestrator.IsRunning()
 
 	insideBubble := strings.Builder{}
+	if panels := m.panels(); panels != "" {
+		insideBubble.WriteString(panels + "\n\n")
+	}
 	insideBubble.WriteString(m.viewport.View())
 	if spinnerOn {
 		insideBubble.WriteString("\n" + m.spinner.View() + LOADING_STYLE.Render(" Working on it"))
 	}
 
-	s.WriteString(BUBBLE_STYLE.Render(insideBubble.String()))
+	s.WriteString(BUBBLE_STYLE.Width(m.width - 5).Height(m.height - 8).Render(insideBubble.String()))
 	s.WriteString(VIEWPORT_CONTROLS)
+	if m.input != nil {
+		s.WriteString("\n" + m.searchPrompt())
+	} else if m.notice != "" {
+		s.WriteString("\n" + TRUNCATED_STYLE.Render(m.notice))
+	}
 	return s.String()
 }
 
@@ -92,24 +601,81 @@ func (m *Model) Update(msg tea.Msg) (*Model, tea.Cmd) {
 	
 	m.progressBar.Update(msg)
 	switch msg := msg.(type) {
+	case liveRefreshMsg:
+		return m, m.updateLoop(msg)
 	case tea.WindowSizeMsg:
-		m.viewport.Width = msg.Width - 14
-		m.viewport.Height = msg.Height - 6
+		m.SetSize(msg.Width, msg.Height)
 		viewportUpdated = true
 	case tea.KeyMsg:
+		if m.input != nil {
+			m.updateSearchInput(msg)
+			return m, nil
+		}
+		m.notice = ""
 		switch msg.Type {
+		case tea.KeyRunes:
+			if m.stream == nil && m.logLines == 0 {
+				break // Not viewing anything; the keys are for the command input
+			}
+			switch string(msg.Runes) {
+			case "/":
+				m.input = &searchInput{}
+			case "n":
+				m.NextMatch()
+			case "N":
+				m.PrevMatch()
+			}
+		case tea.KeyCtrlY:
+			if m.stream != nil || m.logLines > 0 {
+				m.CopyContent()
+			}
 		case tea.KeyCtrlS:
-			m.viewport.ScrollDown((utils.TermHeight() - 6)/2)
+			m.viewport.ScrollDown(m.viewport.Height/2)
 			m.progressBar.Progress = m.viewport.ScrollPercent()
 			viewportUpdated = true
 		case tea.KeyCtrlW:
-			m.viewport.ScrollUp((utils.TermHeight() - 6)/2)
+			m.viewport.ScrollUp(m.viewport.Height/2)
+			m.progressBar.Progress = m.viewport.ScrollPercent()
+			viewportUpdated = true
+		case tea.KeyDown:
+			m.viewport.ScrollDown(1)
 			m.progressBar.Progress = m.viewport.ScrollPercent()
 			viewportUpdated = true
+		case tea.KeyUp:
+			m.viewport.ScrollUp(1)
+			m.progressBar.Progress = m.viewport.ScrollPercent()
+			viewportUpdated = true
+		case tea.KeyPgDown:
+			m.viewport.PageDown()
+			m.progressBar.Progress = m.viewport.ScrollPercent()
+			viewportUpdated = true
+		case tea.KeyPgUp:
+			m.viewport.PageUp()
+			m.progressBar.Progress = m.viewport.ScrollPercent()
+			viewportUpdated = true
+		case tea.KeyHome:
+			m.viewport.GotoTop()
+			m.progressBar.Progress = m.viewport.ScrollPercent()
+			viewportUpdated = true
+		case tea.KeyEnd:
+			m.viewport.GotoBottom()
+			m.progressBar.Progress = m.viewport.ScrollPercent()
+			viewportUpdated = true
+		case tea.KeyTab:
+			if m.stream != nil && m.logLines == 0 {
+				m.SetFilter(m.filter.Next())
+				m.progressBar.Progress = m.viewport.ScrollPercent()
+				viewportUpdated = true
+			}
+		case tea.KeyCtrlF:
+			if m.ViewingTask != nil && m.logLines == 0 && !m.fullLoaded {
+				m.LoadFull()
+				m.progressBar.Progress = m.viewport.ScrollPercent()
+				viewportUpdated = true
+			}
 		case tea.KeyCtrlC, tea.KeyEsc:
 			//m.viewport = &viewport.Model{}
-			m.viewport.SetContent("")
-			m.fileChangeInfo = nil  // Clean up file change detection
+			m.Close()
 			return m, nil
 		}
 	case tea.MouseMsg:
@@ -149,37 +715,67 @@ func (m *Model) Init() tea.Cmd {
 }
 
 func UpdateViewportWidth(m *Model) {
-	termWidth := utils.TermWidth()
-	termHeight := utils.TermHeight()
-	m.viewport.Width = termWidth - 14
-	m.viewport.Height = termHeight - 6
+	m.SetSize(utils.TermWidth(), utils.TermHeight())
 }
 
-func (m *Model) ViewportUpdateLoop()  {
-	time.AfterFunc(2*time.Second, func() {
-		if m.viewport.Height == 0 || m.fileChangeInfo == nil {
-			return
-		}
+// LIVE_POLL_INTERVAL is how often the view checks for new output when no task in
+// this process tells it some was written, e.g. for a task run by another Ludwig
+const LIVE_POLL_INTERVAL = 2 * time.Second
 
-		changed, fileContent, err := utils.HasFileChangedHybrid(m.filePath, m.fileChangeInfo)
-		if err != nil {
-			// Handle error, maybe retry or log
-			m.ViewportUpdateLoop()
-			return
-		}
+// liveRefreshMsg asks Update to show what was written since the last refresh
+type liveRefreshMsg struct {
+	loopID int // The loop that sent it; messages of a retired loop are dropped
+}
 
-		if !changed {
-			m.ViewportUpdateLoop()
-			return
-		}
+// ViewportUpdateLoop starts keeping the view up to date with the response file or
+// log being viewed, straight away when the viewed task is running in this process.
+// It returns the command that waits for the first refresh; Update reads the new
+// output and waits again until the view closes. Starting a new loop (e.g. viewing
+// another task) retires any previous one.
+func (m *Model) ViewportUpdateLoop() tea.Cmd {
+	m.stopLoop()
+	m.loopID++
+	if m.ViewingTask != nil {
+		m.output, m.unsubscribe = orchestrator.SubscribeOutput(m.ViewingTask.ID)
+	}
+	return waitForOutput(m.loopID, m.output)
+}
 
-		scrollPrcnt := m.viewport.ScrollPercent()
-		atBottom := scrollPrcnt > 0.95
-		content := utils.OutputLines(strings.Split(fileContent, "\n"))
-		m.viewport.SetContent(content)
-		if atBottom {
-			m.viewport.GotoBottom()
+// stopLoop stops listening for the viewed task's output
+func (m *Model) stopLoop() {
+	if m.unsubscribe != nil {
+		m.unsubscribe()
+	}
+	m.output, m.unsubscribe = nil, nil
+}
+
+// waitForOutput returns a command that waits for output on the channel, or for
+// LIVE_POLL_INTERVAL, before asking for a refresh. It runs off the UI thread, so it
+// leaves the Model alone and Update does the reading.
+func waitForOutput(loopID int, output <-chan string) tea.Cmd {
+	return func() tea.Msg {
+		select {
+		case <-output:
+			time.Sleep(LIVE_REFRESH_DELAY) // Let the rest of a burst land first
+			for len(output) > 0 {
+				<-output
+			}
+		case <-time.After(LIVE_POLL_INTERVAL):
 		}
-		m.ViewportUpdateLoop()
-	})
+		return liveRefreshMsg{loopID: loopID}
+	}
+}
+
+// updateLoop refreshes the view for the running loop and waits for the next
+// refresh, or ends the loop once the view is closed
+func (m *Model) updateLoop(msg liveRefreshMsg) tea.Cmd {
+	if msg.loopID != m.loopID {
+		return nil
+	}
+	if m.viewport.Height == 0 || (m.stream == nil && m.logLines == 0) {
+		m.stopLoop()
+		return nil
+	}
+	m.Refresh()
+	return waitForOutput(m.loopID, m.output)
 }
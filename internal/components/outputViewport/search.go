@@ -0,0 +1,211 @@
+package outputViewport
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// Search highlight styles; the current match stands out from the rest
+var (
+	MATCH_STYLE         = lipgloss.NewStyle().Background(lipgloss.Color("58")).Foreground(lipgloss.Color("230"))
+	CURRENT_MATCH_STYLE = lipgloss.NewStyle().Background(lipgloss.Color("214")).Foreground(lipgloss.Color("16"))
+)
+
+// searchMatch is one occurrence of the search pattern, as byte offsets into the
+// unstyled text of a content line
+type searchMatch struct {
+	line, start, end int
+}
+
+// search holds the active search over the viewport's content
+type search struct {
+	query   string
+	regex   bool
+	pattern *regexp.Regexp
+	matches []searchMatch
+	current int
+}
+
+// searchInput is the query being typed after '/'
+type searchInput struct {
+	text  string
+	regex bool // Toggled with Ctrl+R; otherwise the query is a case-insensitive literal
+}
+
+// Searching reports whether a search query is being typed
+func (m *Model) Searching() bool {
+	return m.input != nil
+}
+
+// Search finds every occurrence of query in the output and jumps to the first one
+// at or below the top of the view. Literal queries ignore case; regex queries are
+// used as written. An empty query clears the search.
+func (m *Model) Search(query string, regex bool) error {
+	if query == "" {
+		m.ClearSearch()
+		return nil
+	}
+	expr := "(?i)" + regexp.QuoteMeta(query)
+	if regex {
+		expr = query
+	}
+	pattern, err := regexp.Compile(expr)
+	if err != nil {
+		return fmt.Errorf("invalid pattern: %w", err)
+	}
+
+	m.search = &search{query: query, regex: regex, pattern: pattern}
+	m.findMatches()
+	if len(m.search.matches) == 0 {
+		m.notice = fmt.Sprintf("No matches for %q.", query)
+		m.showContent()
+		return nil
+	}
+	top := m.viewport.YOffset
+	for i, match := range m.search.matches {
+		if match.line >= top {
+			m.search.current = i
+			break
+		}
+	}
+	m.jumpToMatch()
+	return nil
+}
+
+// ClearSearch removes the search and its highlighting
+func (m *Model) ClearSearch() {
+	m.input = nil
+	if m.search != nil {
+		m.search = nil
+		m.showContent()
+	}
+}
+
+// MatchCount returns how many matches the active search has
+func (m *Model) MatchCount() int {
+	if m.search == nil {
+		return 0
+	}
+	return len(m.search.matches)
+}
+
+// CurrentMatchLine returns the content line of the current match, or -1 without one
+func (m *Model) CurrentMatchLine() int {
+	if m.MatchCount() == 0 {
+		return -1
+	}
+	return m.search.matches[m.search.current].line
+}
+
+// NextMatch moves to the next match, wrapping round to the first
+func (m *Model) NextMatch() {
+	if m.MatchCount() == 0 {
+		return
+	}
+	m.search.current = (m.search.current + 1) % len(m.search.matches)
+	m.jumpToMatch()
+}
+
+// PrevMatch moves to the previous match, wrapping round to the last
+func (m *Model) PrevMatch() {
+	if m.MatchCount() == 0 {
+		return
+	}
+	m.search.current = (m.search.current + len(m.search.matches) - 1) % len(m.search.matches)
+	m.jumpToMatch()
+}
+
+// updateSearchInput handles a key while a query is being typed
+func (m *Model) updateSearchInput(msg tea.KeyMsg) {
+	switch msg.Type {
+	case tea.KeyEsc, tea.KeyCtrlC:
+		m.input = nil
+	case tea.KeyEnter:
+		input := *m.input
+		m.input = nil
+		if err := m.Search(input.text, input.regex); err != nil {
+			m.notice = err.Error()
+		}
+	case tea.KeyCtrlR:
+		m.input.regex = !m.input.regex
+	case tea.KeyBackspace:
+		if runes := []rune(m.input.text); len(runes) > 0 {
+			m.input.text = string(runes[:len(runes)-1])
+		}
+	case tea.KeyRunes, tea.KeySpace:
+		m.input.text += string(msg.Runes)
+	}
+}
+
+// searchPrompt renders the query being typed, shown in place of the notice
+func (m *Model) searchPrompt() string {
+	mode := "Search"
+	if m.input.regex {
+		mode = "Regex search"
+	}
+	return mode + ": /" + m.input.text + "█  (Enter to search, Ctrl+R for regex, Esc to cancel)"
+}
+
+// findMatches scans the unstyled content for the active pattern
+func (m *Model) findMatches() {
+	m.search.matches = nil
+	for i, line := range strings.Split(m.content.String(), "\n") {
+		for _, loc := range m.search.pattern.FindAllStringIndex(ansi.Strip(line), -1) {
+			if loc[0] == loc[1] {
+				continue // Patterns like "a*" match everywhere; only count real text
+			}
+			m.search.matches = append(m.search.matches, searchMatch{line: i, start: loc[0], end: loc[1]})
+		}
+	}
+	if m.search.current >= len(m.search.matches) {
+		m.search.current = max(len(m.search.matches)-1, 0)
+	}
+}
+
+// jumpToMatch scrolls the current match into view and reports its position
+func (m *Model) jumpToMatch() {
+	m.showContent()
+	line := m.search.matches[m.search.current].line
+	if line < m.viewport.YOffset || line >= m.viewport.YOffset+m.viewport.Height {
+		m.viewport.SetYOffset(max(line-m.viewport.Height/3, 0))
+	}
+	m.progressBar.Progress = m.viewport.ScrollPercent()
+	m.notice = fmt.Sprintf("Match %d/%d for %q (n/N for next/previous)", m.search.current+1, len(m.search.matches), m.search.query)
+}
+
+// showContent puts the output in the viewport, highlighting search matches. Lines
+// with a match lose their own styling so the highlight can be placed exactly.
+func (m *Model) showContent() {
+	if m.search == nil || len(m.search.matches) == 0 {
+		m.viewport.SetContent(m.content.String())
+		return
+	}
+	lines := strings.Split(m.content.String(), "\n")
+	byLine := map[int][]int{} // Line → indexes into matches
+	for i, match := range m.search.matches {
+		byLine[match.line] = append(byLine[match.line], i)
+	}
+	for line, indexes := range byLine {
+		plain := ansi.Strip(lines[line])
+		var b strings.Builder
+		last := 0
+		for _, i := range indexes {
+			match := m.search.matches[i]
+			style := MATCH_STYLE
+			if i == m.search.current {
+				style = CURRENT_MATCH_STYLE
+			}
+			b.WriteString(plain[last:match.start])
+			b.WriteString(style.Render(plain[match.start:match.end]))
+			last = match.end
+		}
+		b.WriteString(plain[last:])
+		lines[line] = b.String()
+	}
+	m.viewport.SetContent(strings.Join(lines, "\n"))
+}
@@ -0,0 +1,259 @@
+package taskForm
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/textarea"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/google/uuid"
+
+	"ludwig/internal/types/task"
+	"ludwig/internal/utils"
+)
+
+var BORDER_STYLE = lipgloss.NewStyle().
+	Border(lipgloss.RoundedBorder()).
+	BorderForeground(lipgloss.Color("62")).
+	Padding(0, 1).
+	Margin(1, 1)
+
+var (
+	LABEL_STYLE   = lipgloss.NewStyle().Bold(true)
+	FOCUSED_STYLE = lipgloss.NewStyle().Foreground(lipgloss.Color("62")).Bold(true)
+	ERROR_STYLE   = lipgloss.NewStyle().Foreground(lipgloss.Color("196"))
+	HINT_STYLE    = lipgloss.NewStyle().Faint(true)
+)
+
+const FORM_CONTROLS = "(Tab/Shift+Tab to move between fields, Enter for the next field or to create the task, Alt+Enter for a new line, Esc to cancel)"
+
+// Fields of the form, in the order they are filled in
+const (
+	FIELD_NAME = iota
+	FIELD_TAGS
+	FIELD_PRIORITY
+	FIELD_INSTRUCTIONS
+	fieldCount
+)
+
+var fieldLabels = [fieldCount]string{"Name", "Tags (optional, comma separated)", "Priority (optional, higher is more urgent)", "Instructions (optional)"}
+
+// SubmitMsg is sent when the form is submitted with valid input
+type SubmitMsg struct {
+	Task *task.Task
+}
+
+// CancelMsg is sent when the form is closed with Esc
+type CancelMsg struct{}
+
+// Model is a multi-step form for creating a task
+type Model struct {
+	inputs       [FIELD_INSTRUCTIONS]textinput.Model // Single-line fields
+	instructions textarea.Model
+	focus        int
+	err          error // Last validation error, shown under the fields
+	width        int   // Terminal width, updated from tea.WindowSizeMsg
+}
+
+func NewModel() Model {
+	width := utils.TermWidth()
+	m := Model{width: width}
+	for i := range m.inputs {
+		ti := textinput.New()
+		ti.Prompt = ""
+		m.inputs[i] = ti
+	}
+	m.inputs[FIELD_NAME].Placeholder = "Short summary of the task"
+	m.inputs[FIELD_TAGS].Placeholder = "e.g. backend, refactor"
+	m.inputs[FIELD_PRIORITY].Placeholder = "0"
+
+	ta := textarea.New()
+	ta.Placeholder = "Details, constraints, acceptance criteria..."
+	ta.ShowLineNumbers = false
+	ta.Prompt = ""
+	ta.CharLimit = 0
+	ta.FocusedStyle.CursorLine = lipgloss.NewStyle()
+	ta.BlurredStyle.CursorLine = lipgloss.NewStyle()
+	ta.SetHeight(5)
+	m.instructions = ta
+
+	m.setWidth(width)
+	m.setFocus(FIELD_NAME)
+	return m
+}
+
+// Focused returns the field currently being edited
+func (m Model) Focused() int {
+	return m.focus
+}
+
+// Err returns the last validation error, or nil
+func (m Model) Err() error {
+	return m.err
+}
+
+func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.setWidth(msg.Width)
+		return m, nil
+	case tea.KeyMsg:
+		switch msg.Type {
+		case tea.KeyEsc, tea.KeyCtrlC:
+			return m, func() tea.Msg { return CancelMsg{} }
+		case tea.KeyTab, tea.KeyDown:
+			if msg.Type == tea.KeyDown && m.focus == FIELD_INSTRUCTIONS {
+				break // Let the textarea move between lines
+			}
+			m.setFocus((m.focus + 1) % fieldCount)
+			return m, nil
+		case tea.KeyShiftTab, tea.KeyUp:
+			if msg.Type == tea.KeyUp && m.focus == FIELD_INSTRUCTIONS && m.instructions.Line() > 0 {
+				break
+			}
+			m.setFocus((m.focus + fieldCount - 1) % fieldCount)
+			return m, nil
+		case tea.KeyEnter:
+			if msg.Alt && m.focus == FIELD_INSTRUCTIONS {
+				m.instructions.InsertString("\n")
+				return m, nil
+			}
+			if m.focus < FIELD_INSTRUCTIONS {
+				m.setFocus(m.focus + 1)
+				return m, nil
+			}
+			return m.submit()
+		}
+	}
+
+	var cmd tea.Cmd
+	if m.focus == FIELD_INSTRUCTIONS {
+		m.instructions, cmd = m.instructions.Update(msg)
+	} else {
+		m.inputs[m.focus], cmd = m.inputs[m.focus].Update(msg)
+	}
+	return m, cmd
+}
+
+// submit validates the form and sends a SubmitMsg, or moves to the first bad field
+func (m Model) submit() (Model, tea.Cmd) {
+	newTask, field, err := m.build()
+	if err != nil {
+		m.err = err
+		m.setFocus(field)
+		return m, nil
+	}
+	m.err = nil
+	return m, func() tea.Msg { return SubmitMsg{Task: newTask} }
+}
+
+// Task validates the form and returns the task it describes
+func (m Model) Task() (*task.Task, error) {
+	newTask, _, err := m.build()
+	return newTask, err
+}
+
+// build turns the fields into a Pending task, returning the field at fault on error.
+// Instructions are kept below the name, which stays the first line and so the task's title.
+func (m Model) build() (*task.Task, int, error) {
+	name := strings.TrimSpace(m.inputs[FIELD_NAME].Value())
+	if name == "" {
+		return nil, FIELD_NAME, errors.New("a name is required")
+	}
+	if strings.Contains(name, "\n") {
+		return nil, FIELD_NAME, errors.New("the name must be a single line")
+	}
+
+	priority := 0
+	if value := strings.TrimSpace(m.inputs[FIELD_PRIORITY].Value()); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil {
+			return nil, FIELD_PRIORITY, fmt.Errorf("priority must be a whole number, got %q", value)
+		}
+		priority = parsed
+	}
+
+	if instructions := strings.TrimSpace(m.instructions.Value()); instructions != "" {
+		name += "\n\n" + instructions
+	}
+
+	return &task.Task{
+		ID:        uuid.New().String(),
+		Name:      name,
+		Status:    task.Pending,
+		CreatedAt: time.Now(),
+		Priority:  priority,
+		Tags:      parseTags(m.inputs[FIELD_TAGS].Value()),
+	}, 0, nil
+}
+
+// parseTags splits a comma or space separated list, dropping blanks and repeats (ignoring case)
+func parseTags(value string) []string {
+	var tags []string
+	for _, tag := range strings.FieldsFunc(value, func(r rune) bool { return r == ',' || r == ' ' }) {
+		duplicate := false
+		for _, existing := range tags {
+			if strings.EqualFold(existing, tag) {
+				duplicate = true
+				break
+			}
+		}
+		if !duplicate {
+			tags = append(tags, tag)
+		}
+	}
+	return tags
+}
+
+func (m *Model) setFocus(field int) {
+	m.focus = field
+	for i := range m.inputs {
+		if i == field {
+			m.inputs[i].Focus()
+		} else {
+			m.inputs[i].Blur()
+		}
+	}
+	if field == FIELD_INSTRUCTIONS {
+		m.instructions.Focus()
+	} else {
+		m.instructions.Blur()
+	}
+}
+
+func (m *Model) setWidth(width int) {
+	m.width = width
+	inputWidth := max(width-8, 20) // Account for border, padding and margin
+	for i := range m.inputs {
+		m.inputs[i].Width = inputWidth
+	}
+	m.instructions.SetWidth(inputWidth)
+}
+
+func (m Model) View() string {
+	var s strings.Builder
+	s.WriteString(LABEL_STYLE.Render("New task") + "\n")
+	for field := 0; field < fieldCount; field++ {
+		label := "  " + fieldLabels[field]
+		if field == m.focus {
+			label = FOCUSED_STYLE.Render("> " + fieldLabels[field])
+		}
+		s.WriteString("\n" + label + "\n")
+		if field == FIELD_INSTRUCTIONS {
+			s.WriteString(m.instructions.View() + "\n")
+		} else {
+			s.WriteString(m.inputs[field].View() + "\n")
+		}
+	}
+	if m.err != nil {
+		s.WriteString("\n" + ERROR_STYLE.Render("Error: "+m.err.Error()) + "\n")
+	}
+	s.WriteString("\n" + HINT_STYLE.Render(FORM_CONTROLS))
+
+	return BORDER_STYLE.Width(max(m.width-4, 24)).Render(s.String()) + "\n"
+}
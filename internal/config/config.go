@@ -10,13 +10,77 @@ import (
 
 // Config represents the user's configuration
 type Config struct {
-	DelayMs    int    `json:"delayMs"`    // Minimum delay in milliseconds between requests
-	AIProvider string `json:"aiProvider"` // "gemini" (default), "ollama", or "copilot"
+	DelayMs           int      `json:"delayMs"`           // Minimum delay in milliseconds between requests
+	RequestsPerMinute int      `json:"requestsPerMinute"` // Max AI requests started per minute across all workers (default: 0, unlimited)
+	SchedulingPolicy  string   `json:"schedulingPolicy"`  // Which runnable task goes next: "review-first" (default), "pending-first", "fifo" or "priority"
+	AutoStart         bool     `json:"autoStart"`         // Start the orchestrator when the TUI launches, if git and the AI provider are available (default: false)
+	IdleTimeout       string   `json:"idleTimeout"`       // Stop the orchestrator after this long with nothing to run, e.g. "15m" (default: never)
+	MaxRetries        int      `json:"maxRetries"`        // Times a rate-limited prompt is retried before its run fails (default: 3, negative for none)
+	RetryDelay        string   `json:"retryDelay"`        // Wait before the first rate limit retry, doubling for each after it, e.g. "10s" (default: "30s")
+	AIProvider        string   `json:"aiProvider"`        // "gemini" (default), "ollama", or "copilot"
+	FallbackProviders []string `json:"fallbackProviders"` // Providers tried in order when aiProvider can't be reached or crashes, e.g. ["ollama"] (default: none)
+	RunReviewPolicy   string   `json:"runReviewPolicy"`   // What "ludwig run" does when a task asks for review: "fail" (default) or "first" to choose its first option
+	// Routing settings
+	PowerfulModel     string `json:"powerfulModel"`     // Model of aiProvider for complex tasks: those tagged "complex" or longer than complexTaskLength (default: none, every task uses the provider's model)
+	ComplexTaskLength int    `json:"complexTaskLength"` // Tasks whose description has more characters than this count as complex (default: 0, only the tag counts)
+	// Environment settings
+	Env map[string]string `json:"env"` // Variables added to the AI CLI's environment for every task; a value of "$NAME" is read from Ludwig's own environment and masked like a secret (default: none)
 	// Ollama-specific settings
 	OllamaBaseURL string `json:"ollamaBaseURL"` // Base URL for Ollama (default: http://localhost:11434)
 	OllamaModel   string `json:"ollamaModel"`   // Model name for Ollama (default: mistral)
 	// Copilot-specific settings
 	CopilotModel string `json:"copilotModel"` // Model name for Copilot (default: gpt-5)
+	// Storage settings
+	StorageDir string `json:"storageDir"` // Where tasks.json and responses are kept, absolute or relative to the project; set by migrate-storage (default: .ludwig)
+	// Response file settings
+	SyncResponses    bool  `json:"syncResponses"`    // Fsync every streamed chunk instead of buffering (default: false)
+	MaxResponseBytes int64 `json:"maxResponseBytes"` // Largest response a single run may write before the task is stopped (default: 50 MB, negative for no limit)
+	// Git settings
+	WorktreeDir           string `json:"worktreeDir"`           // Where task worktrees are created, absolute or relative to the repo root (default: .worktrees)
+	SquashCommits         bool   `json:"squashCommits"`         // Squash a task's commits into one when it completes (default: false)
+	PathGuard             bool   `json:"pathGuard"`             // After each run, check the main repo and idle worktrees for changes and put the task in review if the AI made any outside its worktree (default: false)
+	DiscardFailedWork     bool   `json:"discardFailedWork"`     // Throw away the uncommitted changes of killed or errored runs instead of keeping them (default: false)
+	CommitMessageTemplate string `json:"commitMessageTemplate"` // text/template for the commit of leftover changes (default: "Task completed: {{.TaskID}}...")
+	SquashMessageTemplate string `json:"squashMessageTemplate"` // text/template for the squashed commit (default: title, details, work summary and task ID)
+	// Summary settings
+	AISummaries bool `json:"aiSummaries"` // Ask the AI for a short summary of each completed task instead of collecting its "✓ Completed:" lines (default: false)
+	// Audit settings
+	AuditLog bool `json:"auditLog"` // Append metadata for every AI request to ~/.ai-orchestrator/audit.jsonl (default: false)
+	// Redaction settings
+	DisableRedaction bool     `json:"disableRedaction"` // Write responses and audit lines without masking secrets (default: false)
+	RedactPatterns   []string `json:"redactPatterns"`   // Extra regular expressions to mask on top of the built-in secret patterns
+	// Update settings
+	UpdateChannel  string `json:"updateChannel"`  // "stable" (default) for full releases, or "beta" to include pre-releases
+	UpdateOwner    string `json:"updateOwner"`    // GitHub owner whose releases are installed (default: AlexanderHeffernan)
+	UpdateRepo     string `json:"updateRepo"`     // GitHub repository whose releases are installed (default: Ludwig-AI)
+	UpdateAPIURL   string `json:"updateAPIURL"`   // GitHub API base URL, e.g. https://github.example.com/api/v3 for GitHub Enterprise (default: https://api.github.com)
+	MaxUpdateBytes int64  `json:"maxUpdateBytes"` // Largest release archive an update may download (default: 200 MB, negative for no limit)
+	// Template settings
+	TaskTemplates map[string]TaskTemplate `json:"taskTemplates"` // Named starting points for new tasks, used with "template use" (default: none)
+	// Kanban settings
+	KanbanColumnLimit int            `json:"kanbanColumnLimit"` // Max tasks shown per column (default: 10, negative for no limit)
+	CustomStatuses    []StatusColumn `json:"customStatuses"`    // Extra workflow states, each shown as its own kanban column (default: none)
+	// Output view settings
+	ViewportMaxLines int `json:"viewportMaxLines"` // Most lines of a task's output kept in view; older ones are trimmed (default: 10000, negative for no limit)
+	// Command input settings
+	SaveCommandHistory bool `json:"saveCommandHistory"` // Keep Up/Down command history in .ludwig/history between sessions (default: false)
+}
+
+// StatusColumn is a custom workflow state, such as "Blocked" or "Testing". Tasks
+// are moved into it by hand and the orchestrator leaves them there.
+type StatusColumn struct {
+	Name  string `json:"name"`
+	Color string `json:"color"` // ANSI color code of the column's border, e.g. "36" for cyan (default: "37")
+	Order int    `json:"order"` // Position among the columns: To Do is 10, In Progress 20, In Review 30, Completed 40 and Failed 50
+}
+
+// TaskTemplate is a reusable task. Its description is a text/template where
+// {{.Input}} is replaced by the text given to "template use"; without it the
+// text is added below the description.
+type TaskTemplate struct {
+	Description string   `json:"description"`
+	Tags        []string `json:"tags"`
+	Priority    int      `json:"priority"`
 }
 
 // LoadConfig loads configuration from .ludwig/config.json in the current project
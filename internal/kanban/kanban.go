@@ -3,10 +3,14 @@ package kanban
 import (
 	"fmt"
 	"strings"
+	"ludwig/internal/config"
+	"ludwig/internal/logger"
 	"ludwig/internal/types/task"
 	"ludwig/internal/utils"
 	"strconv"
 	"slices"
+	"sort"
+	"time"
 )
 
 var borderColors map[task.Status]string = map[task.Status]string {
@@ -14,6 +18,7 @@ var borderColors map[task.Status]string = map[task.Status]string {
 	task.InProgress:  "33", // Yellow
 	task.NeedsReview: "35", // Magenta
 	task.Completed:   "32", // Green
+	task.Failed:      "31", // Red
 }
 
 func seperateTaskByStatus(tasks []task.Task) map[task.Status][]task.Task {
@@ -22,38 +27,128 @@ func seperateTaskByStatus(tasks []task.Task) map[task.Status][]task.Task {
 		task.InProgress:  {},
 		task.NeedsReview: {},
 		task.Completed:   {},
+		task.Failed:      {},
 	}
 	for _, task := range tasks {
+		// Archived tasks stay in storage but never appear on the board
+		if task.Archived {
+			continue
+		}
 		taskLists[task.Status] = append(taskLists[task.Status], task)
 	}
 	return taskLists
 }
 
 const TASK_NAME_LENGTH = 40
-func printKanbanHeader() {
+func printKanbanHeader(taskLists map[task.Status][]task.Task) {
 	//fmt.Print(" " + strings.Repeat("╭" + strings.Repeat("─", TASK_NAME_LENGTH - 3) + "╮ ", 4) + "\r\n")
 	//fmt.Print(KanbanTaskName("Pending") + KanbanTaskName("In Progress") + KanbanTaskName("In Review") + KanbanTaskName("Completed") + "\r\n")
 	//fmt.Print(" " + strings.Repeat("├" + strings.Repeat("─", TASK_NAME_LENGTH - 3) + "┤ ", 4) + "\r\n")
-	fmt.Print(genKanbanHeader())
+	fmt.Print(genKanbanHeader(taskLists))
+}
+
+func genKanbanHeader(taskLists map[task.Status][]task.Task) string {
+	return genColumnsHeader(statusOrder, taskLists, TASK_NAME_LENGTH)
+}
+
+// statusOrder is the left-to-right (or top-to-bottom when stacked) column order
+var statusOrder = []task.Status{task.Pending, task.InProgress, task.NeedsReview, task.Completed}
+
+// columnOrder positions each column; custom statuses slot in between by their order
+var columnOrder = map[task.Status]int{
+	task.Pending:     10,
+	task.InProgress:  20,
+	task.NeedsReview: 30,
+	task.Completed:   40,
+	task.Failed:      50,
+}
+
+// customStatuses are the configured custom columns, see SetCustomStatuses
+var customStatuses []task.Status
+
+// DEFAULT_CUSTOM_COLOR is the border color of a custom column that doesn't set one
+const DEFAULT_CUSTOM_COLOR = "37" // White
+
+// SetCustomStatuses adds a column for each configured custom status, replacing
+// those set before. Names that are already a built-in status are skipped.
+func SetCustomStatuses(columns []config.StatusColumn) {
+	for _, status := range customStatuses {
+		delete(columnOrder, status)
+		delete(columnTitles, status)
+		delete(borderColors, status)
+	}
+	customStatuses = nil
+
+	for _, column := range columns {
+		name := strings.TrimSpace(column.Name)
+		if name == "" {
+			continue
+		}
+		if existing, err := task.ParseStatus(name); err == nil && !existing.IsCustom() {
+			logger.Warnf("Ignoring custom status %q, it is already a built-in status", name)
+			continue
+		}
+		status := task.CustomStatus(name)
+		if slices.Contains(customStatuses, status) {
+			continue
+		}
+		color := column.Color
+		if color == "" {
+			color = DEFAULT_CUSTOM_COLOR
+		}
+		customStatuses = append(customStatuses, status)
+		columnOrder[status] = column.Order
+		columnTitles[status] = name
+		borderColors[status] = color
+	}
 }
 
-func genKanbanHeader() string {
+// visibleStatuses returns the columns to draw in order: the standard four and any
+// custom ones, plus Failed only while some task on the board has failed
+func visibleStatuses(tasks []task.Task) []task.Status {
+	statuses := append(slices.Clone(statusOrder), customStatuses...)
+	for _, t := range tasks {
+		if t.Status == task.Failed && !t.Archived {
+			statuses = append(statuses, task.Failed)
+			break
+		}
+	}
+	slices.SortStableFunc(statuses, func(a, b task.Status) int {
+		return columnOrder[a] - columnOrder[b]
+	})
+	return statuses
+}
+
+var columnTitles = map[task.Status]string{
+	task.Pending:     "To Do",
+	task.InProgress:  "In Progress",
+	task.NeedsReview: "In Review",
+	task.Completed:   "Completed",
+	task.Failed:      "Failed",
+}
+
+// columnTitle is a column's title followed by how many tasks it holds, e.g. "To Do (5)"
+func columnTitle(status task.Status, count int) string {
+	return columnTitles[status] + " (" + strconv.Itoa(count) + ")"
+}
+
+// genColumnsHeader renders the top border and titles for the given columns side by
+// side, counting every task in taskLists including those a column limit hides
+func genColumnsHeader(statuses []task.Status, taskLists map[task.Status][]task.Task, width int) string {
 	var header strings.Builder
 	// top bars in each color
-	header.WriteString(utils.ColoredString(" ╭" + strings.Repeat("─", TASK_NAME_LENGTH - 3) + "╮", borderColors[task.Pending]))
-	header.WriteString(utils.ColoredString(" ╭" + strings.Repeat("─", TASK_NAME_LENGTH - 3) + "╮", borderColors[task.InProgress]))
-	header.WriteString(utils.ColoredString(" ╭" + strings.Repeat("─", TASK_NAME_LENGTH - 3) + "╮", borderColors[task.NeedsReview]))
-	header.WriteString(utils.ColoredString(" ╭" + strings.Repeat("─", TASK_NAME_LENGTH - 3) + "╮ \n", borderColors[task.Completed]))
-
-	//header.WriteString(" " + strings.Repeat("╭" + strings.Repeat("─", TASK_NAME_LENGTH - 3) + "╮ ", 4) + "\n")
-	header.WriteString(KanbanTaskName("To Do", task.Pending) + KanbanTaskName("In Progress", task.InProgress) + KanbanTaskName("In Review", task.NeedsReview) + KanbanTaskName("Completed", task.Completed) + "\n")
-
-	header.WriteString(utils.ColoredString(" ├" + strings.Repeat("─", TASK_NAME_LENGTH - 3) + "┤", borderColors[task.Pending]))
-	header.WriteString(utils.ColoredString(" ├" + strings.Repeat("─", TASK_NAME_LENGTH - 3) + "┤", borderColors[task.InProgress]))
-	header.WriteString(utils.ColoredString(" ├" + strings.Repeat("─", TASK_NAME_LENGTH - 3) + "┤", borderColors[task.NeedsReview]))
-	header.WriteString(utils.ColoredString(" ├" + strings.Repeat("─", TASK_NAME_LENGTH - 3) + "┤ \n", borderColors[task.Completed]))
-	//
-	//header.WriteString(" " + strings.Repeat("├" + strings.Repeat("─", TASK_NAME_LENGTH - 3) + "┤ ", 4) + "\n")
+	for _, status := range statuses {
+		header.WriteString(utils.ColoredString(" ╭" + strings.Repeat("─", width - 3) + "╮", borderColors[status]))
+	}
+	header.WriteString(" \n")
+	for _, status := range statuses {
+		header.WriteString(kanbanCell(columnTitle(status, len(taskLists[status])), status, width))
+	}
+	header.WriteString("\n")
+	for _, status := range statuses {
+		header.WriteString(utils.ColoredString(" ├" + strings.Repeat("─", width - 3) + "┤", borderColors[status]))
+	}
+	header.WriteString(" \n")
 	return header.String()
 }
 
@@ -62,10 +157,15 @@ func printKanbanFooter() {
 }
 
 func genKanbanFooter() string {
+	return genColumnsFooter(statusOrder, TASK_NAME_LENGTH)
+}
+
+// genColumnsFooter renders the bottom border for the given columns side by side
+func genColumnsFooter(statuses []task.Status, width int) string {
 	builder := strings.Builder{}
 	// bottom bars in each color
-	for status := task.Pending; status <= task.Completed; status++ {
-		builder.WriteString(utils.ColoredString(" ╰" + strings.Repeat("─", TASK_NAME_LENGTH - 3) + "╯", borderColors[status]))
+	for _, status := range statuses {
+		builder.WriteString(utils.ColoredString(" ╰" + strings.Repeat("─", width - 3) + "╯", borderColors[status]))
 	}
 	return builder.String()
 }
@@ -80,19 +180,26 @@ func BorderColorFromString(status string) string {
 		return borderColors[task.NeedsReview]
 	case "Completed":
 		return borderColors[task.Completed]
+	case "Failed":
+		return borderColors[task.Failed]
 	default:
 		return "34" // Default to blue
 	}
 }
 
 func KanbanTaskName(name string, status task.Status ) string {
-	return utils.LeftRightBorderedString(name, TASK_NAME_LENGTH, len(name), true, borderColors[status])
+	return kanbanCell(name, status, TASK_NAME_LENGTH)
+}
+
+// kanbanCell renders one bordered cell of a column that is width characters wide
+func kanbanCell(name string, status task.Status, width int) string {
+	return utils.LeftRightBorderedString(name, width, len(name), true, borderColors[status])
 }
 
 func DisplayKanban(tasks []task.Task) {
 	utils.ClearScreen()
-	printKanbanHeader()
 	taskLists := seperateTaskByStatus(tasks)
+	printKanbanHeader(taskLists)
 
 	listLengths := []int{
 		len(taskLists[task.Pending]),
@@ -124,41 +231,127 @@ func DisplayKanban(tasks []task.Task) {
 	printKanbanFooter()
 }
 
+// DEFAULT_COLUMN_LIMIT is the number of tasks shown per column when no limit is configured
+const DEFAULT_COLUMN_LIMIT = 10
+
+// limitColumn keeps the limit most recently created tasks of a column, preserving their
+// order, and returns how many were hidden. A negative limit disables the cap.
+func limitColumn(column []task.Task, limit int) ([]task.Task, int) {
+	if limit < 0 || len(column) <= limit {
+		return column, 0
+	}
+	newest := slices.Clone(column)
+	sort.SliceStable(newest, func(i, j int) bool {
+		return newest[i].CreatedAt.After(newest[j].CreatedAt)
+	})
+	keep := make(map[string]bool, limit)
+	for _, t := range newest[:limit] {
+		keep[t.ID] = true
+	}
+
+	kept := make([]task.Task, 0, limit)
+	for _, t := range column {
+		if keep[t.ID] {
+			kept = append(kept, t)
+		}
+	}
+	return kept, len(column) - len(kept)
+}
+
+// MIN_COLUMN_WIDTH is the narrowest a column can get before the board stacks its columns
+const MIN_COLUMN_WIDTH = 24
+
 func RenderKanban(tasks []task.Task) string {
+	return RenderKanbanWithLimit(tasks, DEFAULT_COLUMN_LIMIT)
+}
+
+// RenderKanbanWithLimit renders the board at full column width showing at most limit
+// tasks per column, followed by a "+ N more" line for any that were hidden. A limit
+// of 0 uses DEFAULT_COLUMN_LIMIT and a negative limit shows every task.
+func RenderKanbanWithLimit(tasks []task.Task, limit int) string {
+	return renderColumns(tasks, limit, visibleStatuses(tasks), TASK_NAME_LENGTH)
+}
+
+// RenderKanbanToFit renders the board so no line is wider than termWidth.
+// Columns shrink down to MIN_COLUMN_WIDTH; below that they are stacked vertically.
+func RenderKanbanToFit(tasks []task.Task, limit int, termWidth int) string {
+	statuses := visibleStatuses(tasks)
+	// Each line is the columns plus a trailing space
+	columnWidth := min(TASK_NAME_LENGTH, (termWidth - 1) / len(statuses))
+	if columnWidth >= MIN_COLUMN_WIDTH {
+		return renderColumns(tasks, limit, statuses, columnWidth)
+	}
+
+	stackedWidth := max(min(termWidth - 1, TASK_NAME_LENGTH * 2), MIN_COLUMN_WIDTH)
+	stacked := make([]string, 0, len(statuses))
+	for _, status := range statuses {
+		stacked = append(stacked, renderColumns(tasks, limit, []task.Status{status}, stackedWidth))
+	}
+	return strings.Join(stacked, "\n")
+}
+
+// renderColumns renders the given status columns side by side, each width characters wide
+func renderColumns(tasks []task.Task, limit int, statuses []task.Status, width int) string {
+	if limit == 0 {
+		limit = DEFAULT_COLUMN_LIMIT
+	}
 	var builder strings.Builder
-	//printKanbanHeader()
-	builder.WriteString(genKanbanHeader())
 	taskLists := seperateTaskByStatus(tasks)
+	builder.WriteString(genColumnsHeader(statuses, taskLists, width))
 
-	listLengths := []int{
-		len(taskLists[task.Pending]),
-		len(taskLists[task.InProgress]),
-		len(taskLists[task.NeedsReview]),
-		len(taskLists[task.Completed]),
+	hidden := map[task.Status]int{}
+	for _, status := range statuses {
+		taskLists[status], hidden[status] = limitColumn(taskLists[status], limit)
 	}
+
 	maxListLength := 0
-	for _, length := range listLengths {
+	for _, status := range statuses {
+		length := len(taskLists[status])
+		if hidden[status] > 0 {
+			length++
+		}
 		if length > maxListLength {
 			maxListLength = length
 		}
 	}
 
-	index := 1
 	for i := 0; i < maxListLength; i++ {
 		var line strings.Builder
-		for status := task.Pending; status <= task.Completed; status++ {
+		for _, status := range statuses {
+			if i == len(taskLists[status]) && hidden[status] > 0 {
+				line.WriteString(kanbanCell("+ " + strconv.Itoa(hidden[status]) + " more", status, width))
+				continue
+			}
 			if i >= len(taskLists[status]) {
-				line.WriteString(KanbanTaskName("", status))
+				line.WriteString(kanbanCell("", status, width))
 				continue;
 			}
-			task := taskLists[status][i]
-			displayText := "#" + strconv.Itoa(slices.Index(tasks, task)) + " " + task.Name
-			index++
-			line.WriteString(KanbanTaskName(displayText, status))
+			line.WriteString(kanbanCell(taskLabel(taskLists[status][i], width), status, width))
 		}
 		builder.WriteString(line.String() + " \n")
 
 	}
-	builder.WriteString(genKanbanFooter())
+	builder.WriteString(genColumnsFooter(statuses, width))
 	return builder.String()
 }
+
+// FormatElapsed renders how long a task has been running, to the second, e.g. "2m14s"
+func FormatElapsed(elapsed time.Duration) string {
+	return elapsed.Round(time.Second).String()
+}
+
+// taskLabel is a task's line in a column width characters wide. In Progress tasks
+// end with how long they have been running, and the title is shortened to keep it
+// in view rather than letting the cell truncate it away.
+func taskLabel(t task.Task, width int) string {
+	label := t.ShortID() + " " + t.Title()
+	if t.Status != task.InProgress || t.StartedAt.IsZero() {
+		return label
+	}
+	suffix := " (" + FormatElapsed(time.Since(t.StartedAt)) + ")"
+	// kanbanCell truncates anything longer than width - 5
+	if room := width - 5 - len(suffix); len(label) > room {
+		label = label[:max(room - 3, 0)] + "..."
+	}
+	return label + suffix
+}
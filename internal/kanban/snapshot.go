@@ -0,0 +1,30 @@
+package kanban
+
+import (
+	"io"
+	"strings"
+
+	"github.com/charmbracelet/x/ansi"
+
+	"ludwig/internal/types/task"
+)
+
+// WriteSnapshot prints the board once for `ludwig board`, outside the TUI.
+// A termWidth of 0 renders full-width columns, as when output is piped to a file.
+// Without color, all escape codes are stripped so the output is plain text.
+func WriteSnapshot(w io.Writer, tasks []task.Task, limit int, termWidth int, color bool) error {
+	var board string
+	if termWidth > 0 {
+		board = RenderKanbanToFit(tasks, limit, termWidth)
+	} else {
+		board = RenderKanbanWithLimit(tasks, limit)
+	}
+	if !color {
+		board = ansi.Strip(board)
+	}
+	if !strings.HasSuffix(board, "\n") {
+		board += "\n"
+	}
+	_, err := io.WriteString(w, board)
+	return err
+}
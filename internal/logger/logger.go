@@ -0,0 +1,121 @@
+package logger
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"ludwig/internal/utils"
+)
+
+// Level is the severity of a log entry
+type Level int
+
+const (
+	LevelDebug Level = iota
+	LevelInfo
+	LevelWarn
+	LevelError
+)
+
+func (l Level) String() string {
+	switch l {
+	case LevelDebug:
+		return "DEBUG"
+	case LevelInfo:
+		return "INFO"
+	case LevelWarn:
+		return "WARN"
+	case LevelError:
+		return "ERROR"
+	default:
+		return "UNKNOWN"
+	}
+}
+
+// Entry is a single captured log line
+type Entry struct {
+	Seq     uint64 // Increases by one for every entry ever logged
+	Time    time.Time
+	Level   Level
+	Message string
+}
+
+func (e Entry) String() string {
+	return fmt.Sprintf("%s %-5s %s", e.Time.Format("15:04:05"), e.Level, e.Message)
+}
+
+// BUFFER_SIZE is how many entries are kept; older entries are dropped
+const BUFFER_SIZE = 1000
+
+var (
+	mu       sync.Mutex
+	entries  [BUFFER_SIZE]Entry // Ring buffer, entries[seq % BUFFER_SIZE]
+	lastSeq  uint64
+	minLevel = LevelInfo
+)
+
+// SetLevel sets the lowest level that is recorded
+func SetLevel(level Level) {
+	mu.Lock()
+	defer mu.Unlock()
+	minLevel = level
+}
+
+func Debugf(format string, args ...any) { logf(LevelDebug, format, args...) }
+func Infof(format string, args ...any)  { logf(LevelInfo, format, args...) }
+func Warnf(format string, args ...any)  { logf(LevelWarn, format, args...) }
+func Errorf(format string, args ...any) { logf(LevelError, format, args...) }
+
+func logf(level Level, format string, args ...any) {
+	mu.Lock()
+	if level < minLevel {
+		mu.Unlock()
+		return
+	}
+	lastSeq++
+	entry := Entry{
+		Seq:     lastSeq,
+		Time:    time.Now(),
+		Level:   level,
+		Message: fmt.Sprintf(format, args...),
+	}
+	entries[lastSeq%BUFFER_SIZE] = entry
+	mu.Unlock()
+
+	// Mirror to debug.log when DEBUG is set
+	utils.DebugLog(level.String() + " " + entry.Message)
+}
+
+// Recent returns up to n of the most recent entries, oldest first.
+// n <= 0 returns everything still in the buffer.
+func Recent(n int) []Entry {
+	mu.Lock()
+	defer mu.Unlock()
+
+	available := min(lastSeq, BUFFER_SIZE)
+	if n <= 0 || uint64(n) > available {
+		n = int(available)
+	}
+	result := make([]Entry, 0, n)
+	for seq := lastSeq - uint64(n) + 1; seq <= lastSeq; seq++ {
+		result = append(result, entries[seq%BUFFER_SIZE])
+	}
+	return result
+}
+
+// LastSeq returns the sequence number of the newest entry, 0 if nothing was logged
+func LastSeq() uint64 {
+	mu.Lock()
+	defer mu.Unlock()
+	return lastSeq
+}
+
+// Reset drops all entries and restores the default level
+func Reset() {
+	mu.Lock()
+	defer mu.Unlock()
+	entries = [BUFFER_SIZE]Entry{}
+	lastSeq = 0
+	minLevel = LevelInfo
+}
@@ -0,0 +1,88 @@
+package notify
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"ludwig/internal/logger"
+)
+
+// Notice is a message from a background component meant for the user, such as a
+// failed update check or a task that failed. The TUI shows it in its message area.
+type Notice struct {
+	Level logger.Level // LevelWarn or LevelError; more severe notices are shown first
+	Text  string
+	At    time.Time
+}
+
+// BUFFER_SIZE is how many notices a subscriber can fall behind by before new
+// notices are dropped for it
+const BUFFER_SIZE = 16
+
+// DEDUP_WINDOW is how long a notice is held back after the same text was sent, so
+// an error hit on every poll doesn't flood the message area
+const DEDUP_WINDOW = time.Minute
+
+var (
+	mu          sync.Mutex
+	subscribers = map[chan Notice]struct{}{}
+	lastSent    = map[string]time.Time{} // When each text was last sent, for dedup
+)
+
+// Subscribe returns a channel of notices and a func to stop receiving them. Senders
+// never wait on a subscriber; if the channel is full the notice is dropped.
+func Subscribe() (<-chan Notice, func()) {
+	ch := make(chan Notice, BUFFER_SIZE)
+	mu.Lock()
+	subscribers[ch] = struct{}{}
+	mu.Unlock()
+
+	var once sync.Once
+	return ch, func() {
+		once.Do(func() {
+			mu.Lock()
+			delete(subscribers, ch)
+			mu.Unlock()
+			close(ch)
+		})
+	}
+}
+
+// Warnf logs a warning and sends it to every subscriber
+func Warnf(format string, args ...any) {
+	logger.Warnf(format, args...)
+	send(logger.LevelWarn, fmt.Sprintf(format, args...))
+}
+
+// Errorf logs an error and sends it to every subscriber
+func Errorf(format string, args ...any) {
+	logger.Errorf(format, args...)
+	send(logger.LevelError, fmt.Sprintf(format, args...))
+}
+
+// send delivers a notice to every subscriber without blocking, unless the same
+// text was sent within DEDUP_WINDOW
+func send(level logger.Level, text string) {
+	mu.Lock()
+	defer mu.Unlock()
+	at := time.Now()
+	if last, ok := lastSent[text]; ok && at.Sub(last) < DEDUP_WINDOW {
+		return
+	}
+	lastSent[text] = at
+	for key, sent := range lastSent {
+		if at.Sub(sent) >= DEDUP_WINDOW {
+			delete(lastSent, key)
+		}
+	}
+
+	notice := Notice{Level: level, Text: text, At: at}
+	for ch := range subscribers {
+		select {
+		case ch <- notice:
+		default:
+			// Slow subscriber; drop rather than hold up the sender
+		}
+	}
+}
@@ -0,0 +1,49 @@
+package orchestrator
+
+import (
+	"math/rand"
+	"time"
+)
+
+// POLL_INTERVAL is how long the loop first waits before looking again when there is
+// no work; each further empty poll doubles the wait up to MAX_POLL_INTERVAL
+const (
+	POLL_INTERVAL     = 2 * time.Second
+	MAX_POLL_INTERVAL = 30 * time.Second
+	POLL_JITTER       = 0.1 // Fraction of each wait added or taken away at random
+)
+
+// PollBackoff spaces out polls of an empty task list so an idle orchestrator wakes
+// less and less often. Finding a task resets it. Waits are jittered so orchestrators
+// sharing a project (the TUI and the server, say) don't poll in step.
+type PollBackoff struct {
+	Base   time.Duration
+	Max    time.Duration
+	Jitter float64
+	Rand   func() float64 // Defaults to rand.Float64; tests replace it
+
+	next time.Duration
+}
+
+// NewPollBackoff returns a backoff from POLL_INTERVAL to MAX_POLL_INTERVAL
+func NewPollBackoff() *PollBackoff {
+	return &PollBackoff{Base: POLL_INTERVAL, Max: MAX_POLL_INTERVAL, Jitter: POLL_JITTER, Rand: rand.Float64}
+}
+
+// Next returns how long to wait after an empty poll, doubling the wait for next time
+func (b *PollBackoff) Next() time.Duration {
+	if b.next == 0 {
+		b.next = b.Base
+	}
+	wait := b.next
+	b.next = min(b.next*2, b.Max)
+	if b.Jitter > 0 && b.Rand != nil {
+		wait += time.Duration((b.Rand()*2 - 1) * b.Jitter * float64(wait))
+	}
+	return wait
+}
+
+// Reset goes back to waiting Base, for when a task has turned up
+func (b *PollBackoff) Reset() {
+	b.next = 0
+}
@@ -0,0 +1,220 @@
+package orchestrator
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"ludwig/internal/logger"
+	"ludwig/internal/orchestrator/clients"
+	"ludwig/internal/storage"
+	"ludwig/internal/types/task"
+)
+
+// Review policies accepted by RunBatch and the runReviewPolicy config option
+const (
+	REVIEW_POLICY_FAIL  = "fail"  // Fail a task that asks for review (default)
+	REVIEW_POLICY_FIRST = "first" // Answer a review with its first option
+)
+
+// DEFAULT_BATCH_MAX_FAILURES is how many failed runs RunBatch allows a task before
+// marking it Failed, when BatchOptions doesn't say
+const DEFAULT_BATCH_MAX_FAILURES = 3
+
+// BATCH_REVIEW_NOTE is left on reviews RunBatch answers, so the AI and anyone
+// reading the task later know no one chose the option
+const BATCH_REVIEW_NOTE = "Answered automatically by 'ludwig run'"
+
+// BatchOptions controls RunBatch. The zero value runs one task at a time with no
+// time limit and fails tasks that ask for review.
+type BatchOptions struct {
+	ReviewPolicy string        // REVIEW_POLICY_FAIL or REVIEW_POLICY_FIRST; "" for fail
+	Concurrency  int           // Tasks run at once (default: 1)
+	MaxRuntime   time.Duration // After this long, running tasks are interrupted and no more started (default: no limit)
+	MaxFailures  int           // Failed runs after which a task is marked Failed instead of retried (default: DEFAULT_BATCH_MAX_FAILURES)
+}
+
+// BatchResult is where each task RunBatch set out to run ended up
+type BatchResult struct {
+	Completed []*task.Task
+	Failed    []*task.Task
+	Remaining []*task.Task // Still to run, e.g. interrupted by MaxRuntime
+	TimedOut  bool         // MaxRuntime was reached
+}
+
+// ExitCode is 0 when every task completed and 1 otherwise, for CI
+func (r *BatchResult) ExitCode() int {
+	if len(r.Failed) > 0 || len(r.Remaining) > 0 {
+		return 1
+	}
+	return 0
+}
+
+// WriteSummary prints a line per task and the totals
+func (r *BatchResult) WriteSummary(w io.Writer) {
+	for _, t := range r.Completed {
+		fmt.Fprintf(w, "Completed  %s  %s\n", t.ShortID(), t.Title())
+	}
+	for _, t := range r.Failed {
+		fmt.Fprintf(w, "Failed     %s  %s: %s\n", t.ShortID(), t.Title(), t.FailureReason)
+	}
+	for _, t := range r.Remaining {
+		fmt.Fprintf(w, "Remaining  %s  %s (%s)\n", t.ShortID(), t.Title(), task.StatusString(*t))
+	}
+	total := len(r.Completed) + len(r.Failed) + len(r.Remaining)
+	fmt.Fprintf(w, "%d task(s): %d completed, %d failed, %d remaining\n", total, len(r.Completed), len(r.Failed), len(r.Remaining))
+	if r.TimedOut {
+		fmt.Fprintln(w, "Stopped at the time limit; remaining tasks will run again next time.")
+	}
+}
+
+// RunBatch runs every task that is runnable when it's called until each has
+// completed or failed, RunOnce at a time on each of opts.Concurrency workers. A task
+// that asks for review is answered or failed as opts.ReviewPolicy says, and one that
+// keeps erroring is failed after opts.MaxFailures runs, so the queue always drains.
+func RunBatch(taskStore storage.TaskStorage, aiClient clients.AIClient, opts BatchOptions) (*BatchResult, error) {
+	switch opts.ReviewPolicy {
+	case "":
+		opts.ReviewPolicy = REVIEW_POLICY_FAIL
+	case REVIEW_POLICY_FAIL, REVIEW_POLICY_FIRST:
+	default:
+		return nil, fmt.Errorf("unknown review policy %q (use %s or %s)", opts.ReviewPolicy, REVIEW_POLICY_FAIL, REVIEW_POLICY_FIRST)
+	}
+	if opts.MaxFailures <= 0 {
+		opts.MaxFailures = DEFAULT_BATCH_MAX_FAILURES
+	}
+
+	tasks, err := taskStore.ListTasks()
+	if err != nil {
+		return nil, err
+	}
+	var batch []string
+	for _, t := range tasks {
+		if !t.Archived && (isStartable(t) || isResumable(t)) {
+			batch = append(batch, t.ID)
+		}
+	}
+	logger.Infof("Running %d task(s) until done", len(batch))
+
+	var timedOut atomic.Bool
+	finished := make(chan struct{})
+	if opts.MaxRuntime > 0 {
+		timer := time.NewTimer(opts.MaxRuntime)
+		defer timer.Stop()
+		go func() {
+			select {
+			case <-timer.C:
+				logger.Warnf("Batch reached its time limit of %s, interrupting running tasks", opts.MaxRuntime)
+				timedOut.Store(true)
+				interruptActive(errShutdown)
+			case <-finished:
+			}
+		}()
+	}
+
+	var (
+		wg       sync.WaitGroup
+		settleMu sync.Mutex // One worker settles the batch at a time
+		errMu    sync.Mutex
+		runErr   error
+	)
+	for i := 0; i < max(opts.Concurrency, 1); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			// A worker that finds nothing to run can stop: only a worker's own run
+			// (and the settling after it) makes a task runnable again, and that
+			// worker goes round once more to pick it up
+			for !timedOut.Load() {
+				processed, err := RunOnce(taskStore, aiClient)
+				if err != nil {
+					errMu.Lock()
+					runErr = errors.Join(runErr, err)
+					errMu.Unlock()
+					return
+				}
+				if !processed {
+					return
+				}
+				settleMu.Lock()
+				settleBatch(taskStore, batch, opts)
+				settleMu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+	close(finished)
+
+	result := &BatchResult{TimedOut: timedOut.Load()}
+	for _, id := range batch {
+		t, err := taskStore.GetTask(id)
+		if err != nil {
+			continue // Deleted while the batch ran
+		}
+		switch t.Status {
+		case task.Completed:
+			result.Completed = append(result.Completed, t)
+		case task.Failed:
+			result.Failed = append(result.Failed, t)
+		default:
+			result.Remaining = append(result.Remaining, t)
+		}
+	}
+	return result, runErr
+}
+
+// settleBatch deals with batch tasks no one is there to deal with: a review is
+// answered or failed by policy, and a task that has errored opts.MaxFailures times
+// is failed. Tasks being run are left alone.
+func settleBatch(taskStore storage.TaskStorage, batch []string, opts BatchOptions) {
+	for _, id := range batch {
+		t, err := taskStore.GetTask(id)
+		if err != nil {
+			continue
+		}
+		waiting := t.Status == task.NeedsReview && t.ReviewResponse == nil
+		givenUp := t.Status == task.Pending && t.Failures >= opts.MaxFailures
+		if !waiting && !givenUp {
+			continue
+		}
+
+		release, ok := claimActive(t)
+		if !ok {
+			continue
+		}
+		// Re-read now it's ours, in case a worker moved it on since
+		if t, err = taskStore.GetTask(id); err == nil {
+			switch {
+			case t.Status == task.NeedsReview && t.ReviewResponse == nil:
+				answerReview(taskStore, t, opts.ReviewPolicy)
+			case t.Status == task.Pending && t.Failures >= opts.MaxFailures:
+				failTask(taskStore, t, fmt.Sprintf("gave up after %d failed runs", t.Failures))
+			}
+		}
+		release()
+	}
+}
+
+// answerReview answers t's review by policy, failing t if the policy can't
+func answerReview(taskStore storage.TaskStorage, t *task.Task, policy string) {
+	if policy != REVIEW_POLICY_FIRST || t.Review == nil || len(t.Review.Options) == 0 {
+		question := ""
+		if t.Review != nil {
+			question = t.Review.Question
+		}
+		failTask(taskStore, t, "asked for review: "+question)
+		return
+	}
+	chosen := t.Review.Options[0]
+	logger.Infof("Answering the review of task %s with %q", t.ShortID(), chosen.Label)
+	t.ReviewResponse = &task.ReviewResponse{
+		ChosenOptionID: chosen.ID,
+		ChosenLabel:    chosen.Label,
+		UserNotes:      BATCH_REVIEW_NOTE,
+		RespondedAt:    time.Now(),
+	}
+	_ = taskStore.UpdateTask(t)
+}
@@ -1,8 +1,51 @@
 package clients
 
-import "io"
+import (
+	"context"
+	"io"
+)
 
 type AIClient interface {
 	SendPrompt(prompt string, writer io.Writer) (string, error)
 	SendPromptWithDir(prompt string, writer io.Writer, workDir string) (string, error)
 }
+
+// AvailabilityChecker is implemented by clients that can cheaply tell whether
+// their backend (CLI binary or server) is usable before sending a prompt
+type AvailabilityChecker interface {
+	Available() bool
+}
+
+// ContextClient is implemented by clients whose prompts can be cancelled part way,
+// stopping the CLI process or HTTP request behind them
+type ContextClient interface {
+	SendPromptWithContext(ctx context.Context, prompt string, writer io.Writer, workDir string) (string, error)
+}
+
+// SendPromptWithContext sends prompt through c, giving up when ctx is cancelled.
+// Clients that don't implement ContextClient are left to finish in the background;
+// their result is dropped and ctx's error returned instead.
+func SendPromptWithContext(ctx context.Context, c AIClient, prompt string, writer io.Writer, workDir string) (string, error) {
+	if err := ctx.Err(); err != nil {
+		return "", err
+	}
+	if cc, ok := c.(ContextClient); ok {
+		return cc.SendPromptWithContext(ctx, prompt, writer, workDir)
+	}
+
+	type result struct {
+		response string
+		err      error
+	}
+	done := make(chan result, 1)
+	go func() {
+		response, err := c.SendPromptWithDir(prompt, writer, workDir)
+		done <- result{response, err}
+	}()
+	select {
+	case r := <-done:
+		return r.response, r.err
+	case <-ctx.Done():
+		return "", ctx.Err()
+	}
+}
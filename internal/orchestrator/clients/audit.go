@@ -0,0 +1,140 @@
+package clients
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// AUDIT_FILE is the audit log's file name inside ~/.ai-orchestrator
+const AUDIT_FILE = "audit.jsonl"
+
+// AuditEntry is one line of the audit log, recorded per AI call. Only metadata
+// is kept, never the prompt or response text.
+type AuditEntry struct {
+	Timestamp      time.Time `json:"timestamp"`
+	TaskID         string    `json:"taskId"`
+	Provider       string    `json:"provider"`
+	Model          string    `json:"model"`
+	PromptLength   int       `json:"promptLength"`
+	ResponseLength int       `json:"responseLength"`
+	DurationMs     int64     `json:"durationMs"`
+	Error          string    `json:"error,omitempty"`
+}
+
+// DefaultAuditPath returns ~/.ai-orchestrator/audit.jsonl
+func DefaultAuditPath() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".ai-orchestrator", AUDIT_FILE), nil
+}
+
+// auditFileMu serialises appends so concurrent workers never interleave lines
+var auditFileMu sync.Mutex
+
+// AuditFile is an audit log on disk. Each Write appends to the file, creating it
+// (and its directory) if needed, so it can be shared by short-lived AuditedClients.
+type AuditFile string
+
+// Write appends p to the file
+func (f AuditFile) Write(p []byte) (int, error) {
+	auditFileMu.Lock()
+	defer auditFileMu.Unlock()
+
+	path := string(f)
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return 0, fmt.Errorf("failed to create audit log directory: %w", err)
+	}
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return 0, err
+	}
+	defer file.Close()
+	return file.Write(p)
+}
+
+// AuditedClient wraps an AIClient and writes an AuditEntry line to Out for every prompt sent
+type AuditedClient struct {
+	Client AIClient
+	Out    io.Writer
+	TaskID string              // Recorded on every entry; empty when not running a task
+	Redact func(string) string // Optional hook to mask secrets in recorded error messages
+}
+
+// WithAudit wraps c so every prompt is recorded as a JSON line on w
+func WithAudit(c AIClient, w io.Writer) *AuditedClient {
+	return &AuditedClient{Client: c, Out: w}
+}
+
+// SendPrompt sends the prompt through the wrapped client and records the call
+func (a *AuditedClient) SendPrompt(prompt string, writer io.Writer) (string, error) {
+	return a.SendPromptWithDir(prompt, writer, "")
+}
+
+// SendPromptWithDir sends the prompt through the wrapped client and records the call
+func (a *AuditedClient) SendPromptWithDir(prompt string, writer io.Writer, workDir string) (string, error) {
+	return a.SendPromptWithContext(context.Background(), prompt, writer, workDir)
+}
+
+// SendPromptWithContext sends the prompt through the wrapped client, cancelling it
+// with ctx, and records the call
+func (a *AuditedClient) SendPromptWithContext(ctx context.Context, prompt string, writer io.Writer, workDir string) (string, error) {
+	start := time.Now()
+	response, err := SendPromptWithContext(ctx, a.Client, prompt, writer, workDir)
+
+	provider, model := Describe(a.Client)
+	model = modelOr(ctx, model)
+	entry := AuditEntry{
+		Timestamp:      start,
+		TaskID:         a.TaskID,
+		Provider:       provider,
+		Model:          model,
+		PromptLength:   len(prompt),
+		ResponseLength: len(response),
+		DurationMs:     time.Since(start).Milliseconds(),
+	}
+	if err != nil {
+		entry.Error = err.Error()
+		if a.Redact != nil {
+			entry.Error = a.Redact(entry.Error)
+		}
+	}
+	// A broken audit log must not fail the task; the caller only sees the AI result
+	if line, marshalErr := json.Marshal(entry); marshalErr == nil {
+		a.Out.Write(append(line, '\n'))
+	}
+
+	return response, err
+}
+
+// Unwrap returns the wrapped client
+func (a *AuditedClient) Unwrap() AIClient {
+	return a.Client
+}
+
+// Describe returns the provider and model name of a client for reporting.
+// Gemini picks its model from a fallback chain, so it reports "auto".
+func Describe(client AIClient) (provider, model string) {
+	switch c := client.(type) {
+	case *GeminiClient:
+		return "gemini", "auto"
+	case *OllamaClient:
+		return "ollama", c.Model
+	case *CopilotClient:
+		return "copilot", c.Model
+	case *MockClient:
+		return "mock", ""
+	case interface{ Unwrap() AIClient }:
+		// Middleware reports the client it wraps
+		return Describe(c.Unwrap())
+	default:
+		return fmt.Sprintf("%T", client), ""
+	}
+}
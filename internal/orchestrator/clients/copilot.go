@@ -2,6 +2,7 @@ package clients
 
 import (
 	"bytes"
+	"context"
 	"fmt"
 	"io"
 	"os/exec"
@@ -22,6 +23,12 @@ func NewCopilotClient(model string) *CopilotClient {
 	}
 }
 
+// Available reports whether the copilot CLI is installed
+func (c *CopilotClient) Available() bool {
+	_, err := exec.LookPath("copilot")
+	return err == nil
+}
+
 // SendPrompt sends a prompt to GitHub Copilot CLI with streaming
 // - Streams output in real-time to the provided writer
 // - Returns the complete response text once done
@@ -35,16 +42,25 @@ func (c *CopilotClient) SendPrompt(prompt string, writer io.Writer) (string, err
 // - If workDir is empty, uses current working directory
 // - GitHub Copilot CLI runs with context awareness of the current directory
 func (c *CopilotClient) SendPromptWithDir(prompt string, writer io.Writer, workDir string) (string, error) {
-	return c.executeStreamInDir(prompt, writer, workDir)
+	return c.SendPromptWithContext(context.Background(), prompt, writer, workDir)
+}
+
+// SendPromptWithContext is SendPromptWithDir, killing the copilot process when ctx is cancelled
+func (c *CopilotClient) SendPromptWithContext(ctx context.Context, prompt string, writer io.Writer, workDir string) (string, error) {
+	return c.executeStreamInDir(ctx, prompt, writer, workDir)
 }
 
 // executeStreamInDir executes a single streaming request to Copilot in a specific working directory
 // - Uses "copilot -p" for non-interactive mode with --allow-all-tools for automation
+// - Uses the model set on ctx with WithModel, if any, instead of c.Model
+// - Adds the variables set on ctx with WithEnv to the CLI's environment
 // - If workDir is empty, uses current working directory
-func (c *CopilotClient) executeStreamInDir(prompt string, writer io.Writer, workDir string) (string, error) {
+// - The process is killed if ctx is cancelled
+func (c *CopilotClient) executeStreamInDir(ctx context.Context, prompt string, writer io.Writer, workDir string) (string, error) {
 	// GitHub Copilot CLI command: copilot --model <model> -p <prompt> --allow-all-tools
 	// --allow-all-tools is required for non-interactive/automated use
-	cmd := exec.Command("copilot", "--model", c.Model, "-p", prompt, "--allow-all-tools")
+	cmd := exec.CommandContext(ctx, "copilot", "--model", modelOr(ctx, c.Model), "-p", prompt, "--allow-all-tools")
+	cmd.Env = commandEnv(ctx)
 	
 	// Set working directory for the command if provided
 	if workDir != "" {
@@ -0,0 +1,31 @@
+package clients
+
+import (
+	"context"
+	"os"
+)
+
+type envKey struct{}
+
+// WithEnv returns a context asking the client to run its CLI with env, "KEY=value"
+// pairs, added to Ludwig's own environment. Clients that don't start a process, like
+// Ollama, ignore it.
+func WithEnv(ctx context.Context, env []string) context.Context {
+	return context.WithValue(ctx, envKey{}, env)
+}
+
+// EnvFrom returns the variables set by WithEnv, or nil if there are none
+func EnvFrom(ctx context.Context) []string {
+	env, _ := ctx.Value(envKey{}).([]string)
+	return env
+}
+
+// commandEnv returns the environment for a CLI started with ctx: nil to inherit
+// Ludwig's, or Ludwig's with the WithEnv variables on top
+func commandEnv(ctx context.Context) []string {
+	env := EnvFrom(ctx)
+	if len(env) == 0 {
+		return nil
+	}
+	return append(os.Environ(), env...)
+}
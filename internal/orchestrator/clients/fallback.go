@@ -0,0 +1,131 @@
+package clients
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"os/exec"
+	"strings"
+
+	"ludwig/internal/logger"
+)
+
+// ErrProviderUnavailable marks an error as the provider's fault rather than the
+// task's, so a FallbackClient moves on to the next provider
+var ErrProviderUnavailable = errors.New("provider unavailable")
+
+// ErrAllProvidersFailed is returned by a FallbackClient when no provider could answer
+var ErrAllProvidersFailed = errors.New("all providers failed")
+
+// IsProviderError reports whether err means the provider couldn't answer at all,
+// e.g. its CLI is missing or crashed or its server is down, so another provider
+// may succeed. Errors from the task itself, such as a cancelled run or a response
+// that couldn't be written, aren't, nor are rate limits: WithRetry waits those out.
+func IsProviderError(response string, err error) bool {
+	if err == nil || errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+	if errors.Is(err, ErrProviderUnavailable) {
+		return true
+	}
+	if isRateLimitError(response, err) {
+		return false
+	}
+	var execErr *exec.Error
+	var exitErr *exec.ExitError
+	var netErr net.Error
+	if errors.As(err, &execErr) || errors.As(err, &exitErr) || errors.As(err, &netErr) {
+		return true
+	}
+	// Servers that answered, but with an error of their own
+	message := err.Error()
+	for code := 500; code < 600; code++ {
+		if strings.Contains(message, fmt.Sprintf("status %d", code)) {
+			return true
+		}
+	}
+	return false
+}
+
+// Provider is a client in a FallbackClient's chain with the name it is reported by
+type Provider struct {
+	Name   string
+	Client AIClient
+}
+
+// FallbackClient sends each prompt to its providers in order until one answers.
+// It only moves on when IsProviderError says the provider failed; any other
+// error is returned straight away since the next provider would fail the same way.
+type FallbackClient struct {
+	Providers []Provider
+}
+
+// WithFallback returns a client that tries each provider in turn
+func WithFallback(providers ...Provider) *FallbackClient {
+	return &FallbackClient{Providers: providers}
+}
+
+// SendPrompt sends the prompt to the first provider that answers
+func (f *FallbackClient) SendPrompt(prompt string, writer io.Writer) (string, error) {
+	return f.SendPromptWithDir(prompt, writer, "")
+}
+
+// SendPromptWithDir sends the prompt to the first provider that answers
+func (f *FallbackClient) SendPromptWithDir(prompt string, writer io.Writer, workDir string) (string, error) {
+	return f.SendPromptWithContext(context.Background(), prompt, writer, workDir)
+}
+
+// SendPromptWithContext is SendPromptWithDir, not trying any more providers once
+// ctx is cancelled. A model set with WithModel names one of the primary provider's
+// models, so the fallbacks use their own.
+func (f *FallbackClient) SendPromptWithContext(ctx context.Context, prompt string, writer io.Writer, workDir string) (string, error) {
+	var failures []string
+	for i, provider := range f.Providers {
+		providerCtx := ctx
+		if i > 0 {
+			providerCtx = WithModel(ctx, "")
+		}
+		response, err := SendPromptWithContext(providerCtx, provider.Client, prompt, writer, workDir)
+		if err == nil {
+			if i > 0 {
+				logger.Infof("Prompt answered by fallback provider %s", provider.Name)
+			}
+			return response, nil
+		}
+		if ctx.Err() != nil || !IsProviderError(response, err) {
+			return response, err
+		}
+
+		failures = append(failures, provider.Name+": "+err.Error())
+		if i+1 < len(f.Providers) {
+			next := f.Providers[i+1].Name
+			logger.Warnf("Provider %s failed, falling back to %s: %v", provider.Name, next, err)
+			if writer != nil {
+				fmt.Fprintf(writer, "\n\n⚠️  Provider %s failed: %v. Falling back to %s...\n\n", provider.Name, err, next)
+			}
+		}
+	}
+	return "", fmt.Errorf("%w: %s", ErrAllProvidersFailed, strings.Join(failures, "; "))
+}
+
+// Available reports whether any provider is available. Providers that can't tell
+// are assumed to be.
+func (f *FallbackClient) Available() bool {
+	for _, provider := range f.Providers {
+		checker, ok := provider.Client.(AvailabilityChecker)
+		if !ok || checker.Available() {
+			return true
+		}
+	}
+	return false
+}
+
+// Unwrap returns the primary provider's client
+func (f *FallbackClient) Unwrap() AIClient {
+	if len(f.Providers) == 0 {
+		return nil
+	}
+	return f.Providers[0].Client
+}
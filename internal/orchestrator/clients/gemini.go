@@ -2,11 +2,10 @@ package clients
 
 import (
 	"bytes"
+	"context"
 	"fmt"
 	"io"
 	"os/exec"
-	"strings"
-	"time"
 )
 
 type GeminiClient struct{}
@@ -19,9 +18,15 @@ var modelFallbackChain = []string{
 	"gemini-2.5-flash-lite",
 }
 
-// SendPrompt sends a prompt to Gemini with streaming, retries on rate limits, and model fallback.
+// Available reports whether the gemini CLI is installed
+func (g *GeminiClient) Available() bool {
+	_, err := exec.LookPath("gemini")
+	return err == nil
+}
+
+// SendPrompt sends a prompt to Gemini with streaming and model fallback.
 // - Tries models in order: auto-gemini-3, gemini-2.5-pro, gemini-2.5-flash, gemini-2.5-flash-lite
-// - For each model, retries up to 3 times on rate limit (429) errors with exponential backoff
+// - Rate limit (429) errors are returned without falling back; WithRetry retries them
 // - Streams output in real-time to the provided writer
 // - On failure (non-rate-limit), falls back to the next weaker model
 // - Returns the complete response text once done
@@ -34,8 +39,19 @@ func (g *GeminiClient) SendPrompt(prompt string, writer io.Writer) (string, erro
 // - Same behavior as SendPrompt but executes in the provided workDir
 // - If workDir is empty, uses current working directory
 func (g *GeminiClient) SendPromptWithDir(prompt string, writer io.Writer, workDir string) (string, error) {
-	for _, model := range modelFallbackChain {
-		response, err := g.SendPromptWithModelAndDir(prompt, writer, model, workDir)
+	return g.SendPromptWithContext(context.Background(), prompt, writer, workDir)
+}
+
+// SendPromptWithContext is SendPromptWithDir, killing the gemini process when ctx is cancelled.
+// A model set on ctx with WithModel is tried first, before the usual chain.
+func (g *GeminiClient) SendPromptWithContext(ctx context.Context, prompt string, writer io.Writer, workDir string) (string, error) {
+	for _, model := range modelChain(ModelFrom(ctx)) {
+		response, err := g.executeStreamInDir(ctx, prompt, writer, model, workDir)
+		
+		// A cancelled prompt is not the model's fault; don't try the next one
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return response, ctxErr
+		}
 		
 		// If successful, return
 		if err == nil {
@@ -54,12 +70,26 @@ func (g *GeminiClient) SendPromptWithDir(prompt string, writer io.Writer, workDi
 		}
 	}
 	
-	return "", fmt.Errorf("all models exhausted")
+	return "", fmt.Errorf("%w: all models exhausted", ErrProviderUnavailable)
 }
 
-// SendPromptWithModel sends a prompt to Gemini using a specific model with rate limit retries
-// - Retries up to 3 times on rate limit (429) errors with exponential backoff
-// - Includes partial work from previous attempt so AI can catch up and continue
+// modelChain returns the models to try in order: first, if it isn't "", then the
+// rest of modelFallbackChain
+func modelChain(first string) []string {
+	if first == "" {
+		return modelFallbackChain
+	}
+	chain := []string{first}
+	for _, model := range modelFallbackChain {
+		if model != first {
+			chain = append(chain, model)
+		}
+	}
+	return chain
+}
+
+// SendPromptWithModel sends a prompt to Gemini using a specific model
+// - Makes a single attempt; wrap the client with WithRetry to retry rate limits
 // - Returns the complete response text once done
 // - Runs in the current working directory (main repo)
 func (g *GeminiClient) SendPromptWithModel(prompt string, writer io.Writer, model string) (string, error) {
@@ -70,53 +100,23 @@ func (g *GeminiClient) SendPromptWithModel(prompt string, writer io.Writer, mode
 // - Same behavior as SendPromptWithModel but executes in the provided workDir
 // - If workDir is empty, uses current working directory
 func (g *GeminiClient) SendPromptWithModelAndDir(prompt string, writer io.Writer, model string, workDir string) (string, error) {
-	maxRetries := 3
-	baseDelay := 30 * time.Second
-	var lastPartialResponse string
-
-	for attempt := 0; attempt <= maxRetries; attempt++ {
-		// On retry, include previous partial work as context
-		promptToUse := prompt
-		if attempt > 0 && lastPartialResponse != "" {
-			promptToUse = buildRetryPrompt(prompt, lastPartialResponse)
-		}
-
-		response, err := g.executeStreamInDir(promptToUse, writer, model, workDir)
-
-		// Check for rate limit error (429)
-		if isRateLimitError(response, err) {
-			if attempt < maxRetries {
-				lastPartialResponse = response // Save partial work for next attempt
-				delay := baseDelay * time.Duration(1<<uint(attempt)) // 30s, 60s, 120s
-				msg := fmt.Sprintf("\n\n⚠️  Rate limited. Retrying in %v... (attempt %d/%d)\n\n", delay, attempt+1, maxRetries)
-				if writer != nil {
-					writer.Write([]byte(msg))
-				}
-				time.Sleep(delay)
-				continue
-			}
-			// Out of retries
-			return response, fmt.Errorf("rate limit exceeded after %d retries: %w", maxRetries, err)
-		}
-
-		// Non-rate-limit error or success
-		return response, err
-	}
-
-	return "", fmt.Errorf("max retries exceeded")
+	return g.executeStreamInDir(context.Background(), prompt, writer, model, workDir)
 }
 
 // executeStream executes a single streaming request to Gemini using a specific model
 // - Runs in the current working directory (main repo)
 func (g *GeminiClient) executeStream(prompt string, writer io.Writer, model string) (string, error) {
-	return g.executeStreamInDir(prompt, writer, model, "")
+	return g.executeStreamInDir(context.Background(), prompt, writer, model, "")
 }
 
 // executeStreamInDir executes a single streaming request to Gemini in a specific working directory
 // - If workDir is empty, uses current working directory
-func (g *GeminiClient) executeStreamInDir(prompt string, writer io.Writer, model string, workDir string) (string, error) {
+// - Adds the variables set on ctx with WithEnv to the CLI's environment
+// - The process is killed if ctx is cancelled
+func (g *GeminiClient) executeStreamInDir(ctx context.Context, prompt string, writer io.Writer, model string, workDir string) (string, error) {
 	// Use --output-format stream-json for real-time event streaming
-	cmd := exec.Command("gemini", "--yolo", "--model", model, "--output-format", "stream-json", prompt)
+	cmd := exec.CommandContext(ctx, "gemini", "--yolo", "--model", model, "--output-format", "stream-json", prompt)
+	cmd.Env = commandEnv(ctx)
 	
 	// Set working directory for the command
 	if workDir != "" {
@@ -180,49 +180,3 @@ func (g *GeminiClient) executeStreamInDir(prompt string, writer io.Writer, model
 
 	return fullResponse.String(), nil
 }
-
-// buildRetryPrompt creates a new prompt that includes the partial work from the previous attempt
-// This allows the AI to catch up on what was already done and continue from where it left off
-func buildRetryPrompt(originalPrompt string, partialResponse string) string {
-	if partialResponse == "" {
-		return originalPrompt
-	}
-
-	return fmt.Sprintf(`%s
-
----
-
-[PREVIOUS WORK COMPLETED ON RETRY]:
-%s
-[END PREVIOUS WORK]
-
-Please review the above work. If it appears complete, confirm that and provide a summary. If it's incomplete, continue from where it left off to finish the task.`,
-		originalPrompt, partialResponse)
-}
-
-// isRateLimitError checks if the error is a 429 rate limit error
-func isRateLimitError(response string, err error) bool {
-	// Check response for rate limit indicators
-	if response != "" {
-		lowerResponse := strings.ToLower(response)
-		if strings.Contains(lowerResponse, "resource has been exhausted") ||
-			strings.Contains(lowerResponse, "429") ||
-			strings.Contains(lowerResponse, "rate limit") ||
-			strings.Contains(lowerResponse, "too many requests") {
-			return true
-		}
-	}
-
-	// Check error message
-	if err != nil {
-		lowerErr := strings.ToLower(err.Error())
-		if strings.Contains(lowerErr, "resource has been exhausted") ||
-			strings.Contains(lowerErr, "429") ||
-			strings.Contains(lowerErr, "rate limit") ||
-			strings.Contains(lowerErr, "too many requests") {
-			return true
-		}
-	}
-
-	return false
-}
@@ -0,0 +1,138 @@
+package clients
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"ludwig/internal/types/task"
+)
+
+// ErrMockExhausted is returned once a MockClient has used up its scripted responses
+var ErrMockExhausted = errors.New("mock client has no scripted responses left")
+
+// MockResponse is one scripted reply from a MockClient
+type MockResponse struct {
+	Text   string              // Streamed to the writer and returned
+	Review *task.ReviewRequest // Appended to Text as a ---NEEDS_REVIEW--- block
+	Files  map[string]string   // Written into the work dir first, as if the AI edited code
+	Err    error               // Returned after the text is streamed
+	Hang   bool                // After streaming the text, block until the prompt is cancelled, like a stuck AI
+}
+
+// MockClient replays scripted responses in order so tests can drive whole task
+// lifecycles without a real AI. Every prompt and work dir it receives is recorded.
+type MockClient struct {
+	mu        sync.Mutex
+	responses []MockResponse
+	prompts   []string
+	workDirs  []string
+	models    []string
+}
+
+// NewMockClient returns a client that answers with responses, one per prompt
+func NewMockClient(responses ...MockResponse) *MockClient {
+	return &MockClient{responses: responses}
+}
+
+// SendPrompt replies with the next scripted response
+func (m *MockClient) SendPrompt(prompt string, writer io.Writer) (string, error) {
+	return m.SendPromptWithDir(prompt, writer, "")
+}
+
+// SendPromptWithDir writes the next response's files into workDir, streams its text
+// to writer and returns it along with its scripted error
+func (m *MockClient) SendPromptWithDir(prompt string, writer io.Writer, workDir string) (string, error) {
+	return m.SendPromptWithContext(context.Background(), prompt, writer, workDir)
+}
+
+// SendPromptWithContext is SendPromptWithDir; a Hang response returns ctx's error
+// once it is cancelled
+func (m *MockClient) SendPromptWithContext(ctx context.Context, prompt string, writer io.Writer, workDir string) (string, error) {
+	m.mu.Lock()
+	m.prompts = append(m.prompts, prompt)
+	m.workDirs = append(m.workDirs, workDir)
+	m.models = append(m.models, ModelFrom(ctx))
+	if len(m.responses) == 0 {
+		m.mu.Unlock()
+		return "", ErrMockExhausted
+	}
+	resp := m.responses[0]
+	m.responses = m.responses[1:]
+	m.mu.Unlock()
+
+	for name, contents := range resp.Files {
+		path := filepath.Join(workDir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			return "", err
+		}
+		if err := os.WriteFile(path, []byte(contents), 0644); err != nil {
+			return "", err
+		}
+	}
+
+	text := resp.Text
+	if resp.Review != nil {
+		text += "\n\n" + FormatReviewBlock(resp.Review)
+	}
+	if writer != nil {
+		if _, err := io.WriteString(writer, text); err != nil {
+			return "", err
+		}
+	}
+	if resp.Hang {
+		<-ctx.Done()
+		return text, ctx.Err()
+	}
+	return text, resp.Err
+}
+
+// Prompts returns every prompt the client has received, in order
+func (m *MockClient) Prompts() []string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]string(nil), m.prompts...)
+}
+
+// WorkDirs returns the work dir of every prompt, in order
+func (m *MockClient) WorkDirs() []string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]string(nil), m.workDirs...)
+}
+
+// Models returns the model set with WithModel for every prompt, in order; "" when
+// none was
+func (m *MockClient) Models() []string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]string(nil), m.models...)
+}
+
+// Remaining returns how many scripted responses have not been used yet
+func (m *MockClient) Remaining() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return len(m.responses)
+}
+
+// FormatReviewBlock renders a review request in the ---NEEDS_REVIEW--- format the
+// task prompt asks the AI to use
+func FormatReviewBlock(review *task.ReviewRequest) string {
+	var b strings.Builder
+	b.WriteString("---NEEDS_REVIEW---\n")
+	fmt.Fprintf(&b, "Question: %s\n", review.Question)
+	if review.Context != "" {
+		fmt.Fprintf(&b, "Context: %s\n", review.Context)
+	}
+	for _, opt := range review.Options {
+		fmt.Fprintf(&b, "- id: %s | label: %s\n", opt.ID, opt.Label)
+	}
+	b.WriteString("---END_REVIEW---\n")
+	return b.String()
+}
@@ -0,0 +1,25 @@
+package clients
+
+import "context"
+
+type modelKey struct{}
+
+// WithModel returns a context asking the client that sends the prompt to use model
+// instead of its own. An empty model leaves the client's default in place.
+func WithModel(ctx context.Context, model string) context.Context {
+	return context.WithValue(ctx, modelKey{}, model)
+}
+
+// ModelFrom returns the model set by WithModel, or "" if the client should use its own
+func ModelFrom(ctx context.Context) string {
+	model, _ := ctx.Value(modelKey{}).(string)
+	return model
+}
+
+// modelOr returns the model set on ctx, or fallback if there is none
+func modelOr(ctx context.Context, fallback string) string {
+	if model := ModelFrom(ctx); model != "" {
+		return model
+	}
+	return fallback
+}
@@ -2,10 +2,12 @@ package clients
 
 import (
 	"bytes"
+	"context"
 	"fmt"
 	"io"
 	"net/http"
 	"strings"
+	"time"
 )
 
 type OllamaClient struct {
@@ -29,6 +31,17 @@ func NewOllamaClient(baseURL, model string) *OllamaClient {
 	}
 }
 
+// Available reports whether the Ollama server answers within a second
+func (o *OllamaClient) Available() bool {
+	client := &http.Client{Timeout: time.Second}
+	resp, err := client.Get(o.BaseURL + "/api/tags")
+	if err != nil {
+		return false
+	}
+	resp.Body.Close()
+	return resp.StatusCode == http.StatusOK
+}
+
 // SendPrompt sends a prompt to Ollama without a specific working directory
 func (o *OllamaClient) SendPrompt(prompt string, writer io.Writer) (string, error) {
 	return o.SendPromptWithDir(prompt, writer, "")
@@ -38,23 +51,29 @@ func (o *OllamaClient) SendPrompt(prompt string, writer io.Writer) (string, erro
 // Ollama doesn't support working directory context like the gemini CLI does,
 // but we include it in the interface for compatibility
 func (o *OllamaClient) SendPromptWithDir(prompt string, writer io.Writer, workDir string) (string, error) {
+	return o.SendPromptWithContext(context.Background(), prompt, writer, workDir)
+}
+
+// SendPromptWithContext is SendPromptWithDir, aborting the request when ctx is cancelled
+func (o *OllamaClient) SendPromptWithContext(ctx context.Context, prompt string, writer io.Writer, workDir string) (string, error) {
 	if workDir != "" {
 		// Include workdir context in the prompt for Ollama
 		prompt = fmt.Sprintf("Current working directory: %s\n\n%s", workDir, prompt)
 	}
 
-	return o.sendToOllama(prompt, writer)
+	return o.sendToOllama(ctx, prompt, writer)
 }
 
-// sendToOllama makes the actual HTTP request to Ollama's /api/generate endpoint
-func (o *OllamaClient) sendToOllama(prompt string, writer io.Writer) (string, error) {
+// sendToOllama makes the actual HTTP request to Ollama's /api/generate endpoint,
+// using the model set on ctx with WithModel, if any, instead of o.Model
+func (o *OllamaClient) sendToOllama(ctx context.Context, prompt string, writer io.Writer) (string, error) {
 	// Prepare request body
 	reqBody := fmt.Sprintf(`{"model":"%s","prompt":"%s","stream":true,"raw":true}`,
-		o.Model, escapeJSON(prompt))
+		escapeJSON(modelOr(ctx, o.Model)), escapeJSON(prompt))
 
 	// Create HTTP request
 	url := fmt.Sprintf("%s/api/generate", strings.TrimSuffix(o.BaseURL, "/"))
-	req, err := http.NewRequest("POST", url, bytes.NewBufferString(reqBody))
+	req, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewBufferString(reqBody))
 	if err != nil {
 		return "", fmt.Errorf("failed to create request: %w", err)
 	}
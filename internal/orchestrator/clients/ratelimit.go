@@ -0,0 +1,90 @@
+package clients
+
+import (
+	"context"
+	"io"
+	"sync"
+	"time"
+)
+
+// TokenBucket limits how many requests start per minute. It is safe for use by
+// many goroutines: each waiter reserves its token up front, so queued callers are
+// spaced out evenly instead of all waking at once.
+type TokenBucket struct {
+	mu       sync.Mutex
+	capacity float64
+	tokens   float64
+	perSec   float64
+	last     time.Time
+
+	// Now and Sleep default to the real clock; tests replace them
+	Now   func() time.Time
+	Sleep func(time.Duration)
+}
+
+// NewTokenBucket allows perMinute requests per minute, with bursts of up to perMinute
+func NewTokenBucket(perMinute int) *TokenBucket {
+	return &TokenBucket{
+		capacity: float64(perMinute),
+		tokens:   float64(perMinute),
+		perSec:   float64(perMinute) / 60,
+		Now:      time.Now,
+		Sleep:    time.Sleep,
+	}
+}
+
+// Wait blocks until a request may start and returns how long it waited
+func (b *TokenBucket) Wait() time.Duration {
+	b.mu.Lock()
+	now := b.Now()
+	if !b.last.IsZero() {
+		b.tokens = min(b.capacity, b.tokens+now.Sub(b.last).Seconds()*b.perSec)
+	}
+	b.last = now
+
+	// Take the token now, going into debt if needed, then sleep off the debt
+	b.tokens--
+	var wait time.Duration
+	if b.tokens < 0 {
+		wait = time.Duration(-b.tokens / b.perSec * float64(time.Second))
+	}
+	b.mu.Unlock()
+
+	if wait > 0 {
+		b.Sleep(wait)
+	}
+	return wait
+}
+
+// RateLimitedClient waits on a shared TokenBucket before every prompt
+type RateLimitedClient struct {
+	Client  AIClient
+	Limiter *TokenBucket
+}
+
+// WithRateLimit wraps c so at most perMinute prompts start each minute
+func WithRateLimit(c AIClient, perMinute int) *RateLimitedClient {
+	return &RateLimitedClient{Client: c, Limiter: NewTokenBucket(perMinute)}
+}
+
+// SendPrompt waits for the rate limiter, then sends the prompt through the wrapped client
+func (r *RateLimitedClient) SendPrompt(prompt string, writer io.Writer) (string, error) {
+	return r.SendPromptWithDir(prompt, writer, "")
+}
+
+// SendPromptWithDir waits for the rate limiter, then sends the prompt through the wrapped client
+func (r *RateLimitedClient) SendPromptWithDir(prompt string, writer io.Writer, workDir string) (string, error) {
+	return r.SendPromptWithContext(context.Background(), prompt, writer, workDir)
+}
+
+// SendPromptWithContext waits for the rate limiter, then sends the prompt through the
+// wrapped client unless ctx was cancelled while waiting
+func (r *RateLimitedClient) SendPromptWithContext(ctx context.Context, prompt string, writer io.Writer, workDir string) (string, error) {
+	r.Limiter.Wait()
+	return SendPromptWithContext(ctx, r.Client, prompt, writer, workDir)
+}
+
+// Unwrap returns the wrapped client
+func (r *RateLimitedClient) Unwrap() AIClient {
+	return r.Client
+}
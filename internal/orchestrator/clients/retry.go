@@ -0,0 +1,129 @@
+package clients
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// DEFAULT_MAX_RETRIES and DEFAULT_RETRY_DELAY set how WithRetry backs off from
+// rate limits: 30s, 60s, then 120s before giving up
+const (
+	DEFAULT_MAX_RETRIES = 3
+	DEFAULT_RETRY_DELAY = 30 * time.Second
+)
+
+// RetryClient retries rate-limited (429) prompts with exponential backoff, passing the
+// partial work from the failed attempt along so the AI can pick up where it stopped
+type RetryClient struct {
+	Client     AIClient
+	MaxRetries int
+	BaseDelay  time.Duration
+	Sleep      func(time.Duration) // Defaults to time.Sleep; tests replace it
+}
+
+// WithRetry wraps c so rate-limited prompts are retried with the default backoff
+func WithRetry(c AIClient) *RetryClient {
+	return &RetryClient{
+		Client:     c,
+		MaxRetries: DEFAULT_MAX_RETRIES,
+		BaseDelay:  DEFAULT_RETRY_DELAY,
+		Sleep:      time.Sleep,
+	}
+}
+
+// SendPrompt sends the prompt through the wrapped client, retrying rate limits
+func (r *RetryClient) SendPrompt(prompt string, writer io.Writer) (string, error) {
+	return r.SendPromptWithDir(prompt, writer, "")
+}
+
+// SendPromptWithDir sends the prompt through the wrapped client, retrying rate limits
+func (r *RetryClient) SendPromptWithDir(prompt string, writer io.Writer, workDir string) (string, error) {
+	return r.SendPromptWithContext(context.Background(), prompt, writer, workDir)
+}
+
+// SendPromptWithContext is SendPromptWithDir, giving up between attempts once ctx is cancelled
+func (r *RetryClient) SendPromptWithContext(ctx context.Context, prompt string, writer io.Writer, workDir string) (string, error) {
+	var lastPartialResponse string
+
+	for attempt := 0; ; attempt++ {
+		// On retry, include previous partial work as context
+		promptToUse := prompt
+		if attempt > 0 && lastPartialResponse != "" {
+			promptToUse = buildRetryPrompt(prompt, lastPartialResponse)
+		}
+
+		response, err := SendPromptWithContext(ctx, r.Client, promptToUse, writer, workDir)
+		if err == nil || ctx.Err() != nil || !isRateLimitError(response, err) {
+			// Success or an error retrying won't fix
+			return response, err
+		}
+		if attempt >= r.MaxRetries {
+			return response, fmt.Errorf("rate limit exceeded after %d retries: %w", r.MaxRetries, err)
+		}
+
+		lastPartialResponse = response // Save partial work for next attempt
+		delay := r.BaseDelay * time.Duration(1<<uint(attempt))
+		msg := fmt.Sprintf("\n\n⚠️  Rate limited. Retrying in %v... (attempt %d/%d)\n\n", delay, attempt+1, r.MaxRetries)
+		if writer != nil {
+			writer.Write([]byte(msg))
+		}
+		r.Sleep(delay)
+		if err := ctx.Err(); err != nil {
+			return response, err
+		}
+	}
+}
+
+// Unwrap returns the wrapped client
+func (r *RetryClient) Unwrap() AIClient {
+	return r.Client
+}
+
+// buildRetryPrompt creates a new prompt that includes the partial work from the previous attempt
+// This allows the AI to catch up on what was already done and continue from where it left off
+func buildRetryPrompt(originalPrompt string, partialResponse string) string {
+	if partialResponse == "" {
+		return originalPrompt
+	}
+
+	return fmt.Sprintf(`%s
+
+---
+
+[PREVIOUS WORK COMPLETED ON RETRY]:
+%s
+[END PREVIOUS WORK]
+
+Please review the above work. If it appears complete, confirm that and provide a summary. If it's incomplete, continue from where it left off to finish the task.`,
+		originalPrompt, partialResponse)
+}
+
+// isRateLimitError checks if the error is a 429 rate limit error
+func isRateLimitError(response string, err error) bool {
+	// Check response for rate limit indicators
+	if response != "" {
+		lowerResponse := strings.ToLower(response)
+		if strings.Contains(lowerResponse, "resource has been exhausted") ||
+			strings.Contains(lowerResponse, "429") ||
+			strings.Contains(lowerResponse, "rate limit") ||
+			strings.Contains(lowerResponse, "too many requests") {
+			return true
+		}
+	}
+
+	// Check error message
+	if err != nil {
+		lowerErr := strings.ToLower(err.Error())
+		if strings.Contains(lowerErr, "resource has been exhausted") ||
+			strings.Contains(lowerErr, "429") ||
+			strings.Contains(lowerErr, "rate limit") ||
+			strings.Contains(lowerErr, "too many requests") {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,94 @@
+package orchestrator
+
+import (
+	"errors"
+	"strings"
+	"text/template"
+	"time"
+
+	"ludwig/internal/config"
+	"ludwig/internal/logger"
+	"ludwig/internal/types/task"
+)
+
+// DEFAULT_COMMIT_TEMPLATE is the message for the commit of changes the AI left uncommitted
+const DEFAULT_COMMIT_TEMPLATE = "Task completed: {{.TaskID}}\n\nAuto-committed any uncommitted changes to preserve work."
+
+// DEFAULT_SQUASH_TEMPLATE is the message for a task's squashed commit
+const DEFAULT_SQUASH_TEMPLATE = "{{.Title}}{{with .Details}}\n\n{{.}}{{end}}{{with .Summary}}\n\nWork summary:\n{{.}}{{end}}\n\nTask: {{.TaskID}}"
+
+// CommitMessageData is what commit message templates can refer to
+type CommitMessageData struct {
+	TaskName string // The full task description
+	TaskID   string
+	Branch   string
+	Date     string // Today, as 2006-01-02
+	Title    string // First line of TaskName
+	Details  string // The rest of TaskName
+	Summary  string // The task's work-in-progress report
+}
+
+// RenderCommitMessage fills in a commit message template for a task
+func RenderCommitMessage(text string, t *task.Task) (string, error) {
+	tmpl, err := template.New("commit").Option("missingkey=error").Parse(text)
+	if err != nil {
+		return "", err
+	}
+	_, details, _ := strings.Cut(t.Name, "\n")
+	data := CommitMessageData{
+		TaskName: t.Name,
+		TaskID:   t.ID,
+		Branch:   t.BranchName,
+		Date:     time.Now().Format("2006-01-02"),
+		Title:    t.Title(),
+		Details:  strings.TrimSpace(details),
+		Summary:  strings.TrimSpace(t.WorkInProgress),
+	}
+	var b strings.Builder
+	if err := tmpl.Execute(&b, data); err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(b.String()), nil
+}
+
+// SquashMessage builds the default squashed commit message: the task's title as the
+// subject, then the rest of its description and its work-in-progress summary
+func SquashMessage(t *task.Task) string {
+	message, _ := RenderCommitMessage(DEFAULT_SQUASH_TEMPLATE, t)
+	return message
+}
+
+// commitMessage renders the configured auto-commit template
+func commitMessage(cfg *config.Config, t *task.Task) string {
+	custom := ""
+	if cfg != nil {
+		custom = cfg.CommitMessageTemplate
+	}
+	return renderOrDefault(custom, DEFAULT_COMMIT_TEMPLATE, t)
+}
+
+// squashMessage renders the configured squash template
+func squashMessage(cfg *config.Config, t *task.Task) string {
+	custom := ""
+	if cfg != nil {
+		custom = cfg.SquashMessageTemplate
+	}
+	return renderOrDefault(custom, DEFAULT_SQUASH_TEMPLATE, t)
+}
+
+// renderOrDefault renders custom, falling back to the default template when custom
+// is empty, broken or renders to nothing, so a bad template never loses work
+func renderOrDefault(custom, fallback string, t *task.Task) string {
+	if strings.TrimSpace(custom) != "" {
+		message, err := RenderCommitMessage(custom, t)
+		if err == nil && message != "" {
+			return message
+		}
+		if err == nil {
+			err = errors.New("it renders to an empty message")
+		}
+		logger.Warnf("Commit message template for task %s is unusable, using the default: %v", t.ShortID(), err)
+	}
+	message, _ := RenderCommitMessage(fallback, t)
+	return message
+}
@@ -0,0 +1,110 @@
+package orchestrator
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+
+	"ludwig/internal/config"
+	"ludwig/internal/storage"
+)
+
+// Doctor runs Preflight's checks plus everything else Ludwig needs to work: a git
+// repo to run in, a readable config, writable .ludwig and storage directories and a
+// readable tasks.json. cfgErr is the error config.LoadConfig gave, if any.
+func Doctor(cfg *config.Config, cfgErr error) []PreflightCheck {
+	git := checkGit()
+	checks := []PreflightCheck{git, checkRepo(git.OK), checkConfig(cfg, cfgErr), checkProvider(cfg)}
+
+	cwd, _ := os.Getwd()
+	ludwigDir := filepath.Join(cwd, ".ludwig")
+	checks = append(checks, checkWritable(".ludwig directory", ludwigDir))
+	if dataDir, err := storage.DataDir(); err == nil && filepath.Clean(dataDir) != ludwigDir {
+		checks = append(checks, checkWritable("storage directory", dataDir))
+	}
+	return append(checks, checkTaskFile())
+}
+
+// WriteDoctorReport prints a PASS or FAIL line per check, with how to fix each
+// failure, and returns how many failed
+func WriteDoctorReport(w io.Writer, checks []PreflightCheck) int {
+	failed := 0
+	for _, check := range checks {
+		if check.OK {
+			fmt.Fprintf(w, "PASS  %s\n", check.Name)
+			continue
+		}
+		failed++
+		fmt.Fprintf(w, "FAIL  %s\n      %s\n", check.Name, check.Guidance)
+	}
+	if failed == 0 {
+		fmt.Fprintln(w, "Everything Ludwig needs is in place.")
+	} else {
+		fmt.Fprintf(w, "%d of %d checks failed.\n", failed, len(checks))
+	}
+	return failed
+}
+
+// checkRepo checks that Ludwig is running inside a git repo, where task worktrees
+// are made
+func checkRepo(gitOK bool) PreflightCheck {
+	check := PreflightCheck{Name: "git repository", Required: true}
+	if !gitOK {
+		check.Guidance = "Install git first, then run Ludwig from inside your project's repo"
+		return check
+	}
+	cmd := exec.Command("git", "rev-parse", "--is-inside-work-tree")
+	cmd.Dir = getRepoRoot()
+	check.OK = cmd.Run() == nil
+	check.Guidance = "Run Ludwig from inside your project's git repo, or create one with 'git init' and make a first commit"
+	return check
+}
+
+// checkConfig checks that .ludwig/config.json could be read and names a known
+// scheduling policy
+func checkConfig(cfg *config.Config, cfgErr error) PreflightCheck {
+	check := PreflightCheck{Name: "config (.ludwig/config.json)", OK: true}
+	if cfgErr != nil {
+		check.OK = false
+		check.Guidance = "Fix or remove .ludwig/config.json, which can't be read: " + cfgErr.Error()
+		return check
+	}
+	if cfg != nil {
+		if _, err := SchedulerFor(cfg.SchedulingPolicy); err != nil {
+			check.OK = false
+			check.Guidance = "Fix schedulingPolicy in .ludwig/config.json: " + err.Error()
+		}
+	}
+	return check
+}
+
+// checkWritable checks that files can be created in dir, creating it if needed as
+// Ludwig would
+func checkWritable(name, dir string) PreflightCheck {
+	check := PreflightCheck{Name: name + " (" + dir + ")", Required: true}
+	err := os.MkdirAll(dir, 0755)
+	if err == nil {
+		var probe *os.File
+		if probe, err = os.CreateTemp(dir, ".doctor-*"); err == nil {
+			probe.Close()
+			os.Remove(probe.Name())
+		}
+	}
+	check.OK = err == nil
+	if err != nil {
+		check.Guidance = "Make " + dir + " a directory you can write to: " + err.Error()
+	}
+	return check
+}
+
+// checkTaskFile checks that tasks.json, if there is one, can be read
+func checkTaskFile() PreflightCheck {
+	path, err := storage.CheckTaskFile()
+	check := PreflightCheck{Name: "tasks file (" + path + ")", OK: err == nil, Required: true}
+	if err != nil {
+		check.Guidance = "Run 'restore-backup' to go back to the copy taken before the last save, or move the file aside to start afresh: " + err.Error()
+	}
+	return check
+}
@@ -0,0 +1,64 @@
+package orchestrator
+
+import (
+	"os"
+	"sort"
+	"strings"
+
+	"ludwig/internal/config"
+	"ludwig/internal/logger"
+	"ludwig/internal/storage"
+)
+
+// TaskEnv returns the env config option as "KEY=value" pairs for the AI CLI, sorted
+// by key, along with the values read from Ludwig's environment, which are secrets to
+// mask. A "$NAME" value that isn't set is left out with a warning rather than
+// passed on empty.
+func TaskEnv(cfg *config.Config) (env []string, secrets []string) {
+	if cfg == nil || len(cfg.Env) == 0 {
+		return nil, nil
+	}
+	keys := make([]string, 0, len(cfg.Env))
+	for key := range cfg.Env {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		value := cfg.Env[key]
+		if name, ok := strings.CutPrefix(value, "$"); ok && name != "" {
+			hostValue, set := os.LookupEnv(name)
+			if !set {
+				logger.Warnf("Not setting %s for the AI: $%s is not set", key, name)
+				continue
+			}
+			value = hostValue
+			secrets = append(secrets, value)
+		}
+		env = append(env, key+"="+value)
+	}
+	return env, secrets
+}
+
+// redactedError is an error whose message has had secrets masked, so it can be
+// logged and shown, while errors.Is and errors.As still see the original
+type redactedError struct {
+	message string
+	err     error
+}
+
+func (e *redactedError) Error() string { return e.message }
+func (e *redactedError) Unwrap() error { return e.err }
+
+// redactError masks what redactor matches in err's message, such as a CLI echoing
+// a token from the env config option, returning err as it is when nothing matches
+func redactError(redactor *storage.Redactor, err error) error {
+	if err == nil {
+		return nil
+	}
+	message := redactor.Redact(err.Error())
+	if message == err.Error() {
+		return err
+	}
+	return &redactedError{message: message, err: err}
+}
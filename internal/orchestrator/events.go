@@ -0,0 +1,85 @@
+package orchestrator
+
+import (
+	"sync"
+	"time"
+
+	"ludwig/internal/types/task"
+)
+
+// EventType names a task transition made by the orchestrator
+type EventType string
+
+const (
+	TaskStarted     EventType = "started"
+	TaskNeedsReview EventType = "needs-review"
+	TaskCompleted   EventType = "completed"
+	TaskFailed      EventType = "failed"
+
+	// OrchestratorIdleStop is sent when the orchestrator stops itself after idleTimeout.
+	// It isn't about a task, so only Type, Reason and At are set.
+	OrchestratorIdleStop EventType = "idle-stop"
+)
+
+// EVENT_BUFFER_SIZE is how many events a subscriber can fall behind by before
+// new events are dropped for it
+const EVENT_BUFFER_SIZE = 64
+
+// Event describes a task transition, sent to every subscriber
+type Event struct {
+	Type     EventType `json:"type"`
+	TaskID   string    `json:"taskId"`
+	TaskName string    `json:"taskName"`
+	Status   string    `json:"status"`
+	Reason   string    `json:"reason,omitempty"`
+	At       time.Time `json:"at"`
+}
+
+var (
+	subscribersMu sync.Mutex
+	subscribers   = map[chan Event]struct{}{}
+)
+
+// Subscribe returns a channel of orchestrator events and a func to stop receiving them.
+// The orchestrator never waits on a subscriber; if the channel is full the event is dropped.
+func Subscribe() (<-chan Event, func()) {
+	ch := make(chan Event, EVENT_BUFFER_SIZE)
+	subscribersMu.Lock()
+	subscribers[ch] = struct{}{}
+	subscribersMu.Unlock()
+
+	var once sync.Once
+	return ch, func() {
+		once.Do(func() {
+			subscribersMu.Lock()
+			delete(subscribers, ch)
+			subscribersMu.Unlock()
+			close(ch)
+		})
+	}
+}
+
+// publish sends an event for t's current state to every subscriber without blocking
+func publish(eventType EventType, t *task.Task) {
+	publishEvent(Event{
+		Type:     eventType,
+		TaskID:   t.ID,
+		TaskName: t.Name,
+		Status:   task.StatusString(*t),
+		Reason:   t.FailureReason,
+		At:       time.Now(),
+	})
+}
+
+// publishEvent sends event to every subscriber without blocking
+func publishEvent(event Event) {
+	subscribersMu.Lock()
+	defer subscribersMu.Unlock()
+	for ch := range subscribers {
+		select {
+		case ch <- event:
+		default:
+			// Slow subscriber; drop rather than hold up the orchestrator
+		}
+	}
+}
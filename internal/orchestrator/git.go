@@ -1,23 +1,64 @@
 package orchestrator
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"os/exec"
 	"path/filepath"
 	"regexp"
 	"strings"
+
+	"ludwig/internal/config"
 )
 
-// CreateWorktree creates a new git worktree for a given branch
-// Returns the path to the worktree directory
-func CreateWorktree(branchName, taskID string) (string, error) {
+// ErrBaseBranchNotFound is returned when a task's base branch doesn't exist in the repo
+var ErrBaseBranchNotFound = errors.New("base branch not found")
+
+// DEFAULT_WORKTREE_DIR is where worktrees are created, relative to the repo root,
+// unless the worktreeDir option says otherwise
+const DEFAULT_WORKTREE_DIR = ".worktrees"
+
+// WorktreeBase returns the directory task worktrees are created in: worktreeDir
+// from cfg, absolute or relative to the repo root, or DEFAULT_WORKTREE_DIR
+func WorktreeBase(cfg *config.Config) string {
+	dir := DEFAULT_WORKTREE_DIR
+	if cfg != nil && cfg.WorktreeDir != "" {
+		dir = cfg.WorktreeDir
+	}
+	if filepath.IsAbs(dir) {
+		return filepath.Clean(dir)
+	}
+	return filepath.Join(getRepoRoot(), dir)
+}
+
+// CreateWorktree creates a new git worktree for a given branch, starting from baseBranch.
+// An empty baseBranch means main, or the current branch when there is no main.
+// The worktree is created under WorktreeBase. Returns the path to the worktree directory
+func CreateWorktree(branchName, taskID, baseBranch string) (string, error) {
 	repoRoot := getRepoRoot()
-	worktreeDir := filepath.Join(repoRoot, ".worktrees", taskID)
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		return "", fmt.Errorf("failed to load config: %w", err)
+	}
+	base := WorktreeBase(cfg)
+	worktreeDir := filepath.Join(base, taskID)
 	
-	// Ensure .worktrees directory exists
-	if err := os.MkdirAll(filepath.Join(repoRoot, ".worktrees"), 0755); err != nil {
-		return "", fmt.Errorf("failed to create .worktrees directory: %w", err)
+	// Ensure the worktree directory exists
+	if err := os.MkdirAll(base, 0755); err != nil {
+		return "", fmt.Errorf("failed to create worktree directory %s: %w", base, err)
+	}
+
+	if baseBranch != "" {
+		if exists, _ := BranchExists(baseBranch); !exists {
+			return "", fmt.Errorf("%w: %s", ErrBaseBranchNotFound, baseBranch)
+		}
+		cmd := exec.Command("git", "worktree", "add", "-b", branchName, worktreeDir, baseBranch)
+		cmd.Dir = repoRoot
+		if out, err := cmd.CombinedOutput(); err != nil {
+			return "", fmt.Errorf("failed to create worktree from %s: %w: %s", baseBranch, err, strings.TrimSpace(string(out)))
+		}
+		return worktreeDir, nil
 	}
 	
 	// Try to create worktree based on "main" branch first
@@ -74,8 +115,8 @@ func RemoveWorktree(worktreePath string) error {
 
 // CommitAnyChanges stages and commits any uncommitted changes in the worktree
 // This ensures that AI work is preserved even if the AI didn't explicitly commit
-// Uses the task ID to create a descriptive commit message
-func CommitAnyChanges(worktreePath string, taskID string) error {
+// The message usually comes from CommitMessage
+func CommitAnyChanges(worktreePath string, commitMsg string) error {
 	// Check if there are any changes
 	cmd := exec.Command("git", "status", "--porcelain")
 	cmd.Dir = worktreePath
@@ -97,7 +138,6 @@ func CommitAnyChanges(worktreePath string, taskID string) error {
 	}
 	
 	// Commit the changes
-	commitMsg := fmt.Sprintf("Task completed: %s\n\nAuto-committed any uncommitted changes to preserve work.", taskID)
 	commitCmd := exec.Command("git", "commit", "-m", commitMsg)
 	commitCmd.Dir = worktreePath
 	if err := commitCmd.Run(); err != nil {
@@ -108,6 +148,120 @@ func CommitAnyChanges(worktreePath string, taskID string) error {
 	return nil
 }
 
+// WorktreeDiff returns everything the worktree's branch has changed since it branched
+// off baseBranch ("" for main): commits made by the AI plus any uncommitted and
+// untracked files. Untracked files are staged so they show up in the diff.
+func WorktreeDiff(worktreePath, baseBranch string) (string, error) {
+	return diffAgainstBase(worktreePath, baseBranch)
+}
+
+// WorktreeDiffStat summarises the same changes as WorktreeDiff as a `git diff --stat`:
+// one line per file with its added and removed lines, then a total
+func WorktreeDiffStat(worktreePath, baseBranch string) (string, error) {
+	stat, err := diffAgainstBase(worktreePath, baseBranch, "--stat")
+	return strings.TrimRight(stat, "\n"), err
+}
+
+func diffAgainstBase(worktreePath, baseBranch string, args ...string) (string, error) {
+	addCmd := exec.Command("git", "add", "-A")
+	addCmd.Dir = worktreePath
+	if err := addCmd.Run(); err != nil {
+		return "", fmt.Errorf("failed to stage changes: %w", err)
+	}
+
+	args = append(append([]string{"diff", "--cached"}, args...), branchBase(worktreePath, baseBranch))
+	cmd := exec.Command("git", args...)
+	cmd.Dir = worktreePath
+	output, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("failed to diff worktree: %w", err)
+	}
+	return string(output), nil
+}
+
+// branchBase returns the commit the worktree's branch forked from baseBranch ("" for
+// main), or HEAD when there is no such branch to compare against
+func branchBase(worktreePath, baseBranch string) string {
+	if baseBranch == "" {
+		baseBranch = "main"
+	}
+	cmd := exec.Command("git", "merge-base", "HEAD", baseBranch)
+	cmd.Dir = worktreePath
+	output, err := cmd.Output()
+	if err != nil {
+		return "HEAD"
+	}
+	return strings.TrimSpace(string(output))
+}
+
+// BranchHasCommits reports whether the worktree's branch has any commits of its own,
+// i.e. commits that aren't already on baseBranch ("" for main)
+func BranchHasCommits(worktreePath, baseBranch string) (bool, error) {
+	cmd := exec.Command("git", "rev-list", "--count", branchBase(worktreePath, baseBranch)+"..HEAD")
+	cmd.Dir = worktreePath
+	output, err := cmd.Output()
+	if err != nil {
+		return false, fmt.Errorf("failed to count branch commits: %w", err)
+	}
+	return strings.TrimSpace(string(output)) != "0", nil
+}
+
+// SquashCommits replaces every commit the worktree's branch made since it forked
+// from baseBranch ("" for main) with a single commit carrying message. A branch
+// with no commits of its own is left alone.
+func SquashCommits(worktreePath, baseBranch, message string) error {
+	hasCommits, err := BranchHasCommits(worktreePath, baseBranch)
+	if err != nil || !hasCommits {
+		return err
+	}
+	base := branchBase(worktreePath, baseBranch)
+	if base == "HEAD" {
+		return fmt.Errorf("failed to squash commits: no common ancestor with %s", baseBranch)
+	}
+
+	resetCmd := exec.Command("git", "reset", "--soft", base)
+	resetCmd.Dir = worktreePath
+	if out, err := resetCmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("failed to reset to %s: %w: %s", base, err, strings.TrimSpace(string(out)))
+	}
+
+	// Commits that cancel each other out leave nothing to commit; the branch is then
+	// back at its base, which is what it effectively was
+	diffCmd := exec.Command("git", "diff", "--cached", "--quiet")
+	diffCmd.Dir = worktreePath
+	if diffCmd.Run() == nil {
+		return nil
+	}
+
+	commitCmd := exec.Command("git", "commit", "-q", "-m", message)
+	commitCmd.Dir = worktreePath
+	if out, err := commitCmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("failed to commit squashed changes: %w: %s", err, strings.TrimSpace(string(out)))
+	}
+	return nil
+}
+
+// DiscardChanges throws away a worktree's uncommitted changes, untracked files included
+func DiscardChanges(worktreePath string) error {
+	for _, args := range [][]string{{"reset", "-q", "--hard", "HEAD"}, {"clean", "-q", "-fd"}} {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = worktreePath
+		if out, err := cmd.CombinedOutput(); err != nil {
+			return fmt.Errorf("failed to discard changes: %w: %s", err, strings.TrimSpace(string(out)))
+		}
+	}
+	return nil
+}
+
+// worktreeExists reports whether a task's worktree is still on disk
+func worktreeExists(worktreePath string) bool {
+	if worktreePath == "" {
+		return false
+	}
+	_, err := os.Stat(worktreePath)
+	return err == nil
+}
+
 // CreateBranch creates a new branch and checks it out (deprecated: use CreateWorktree instead)
 func CreateBranch(branchName string) error {
 	cmd := exec.Command("git", "checkout", "-b", branchName)
@@ -115,6 +269,21 @@ func CreateBranch(branchName string) error {
 	return cmd.Run()
 }
 
+// DeleteBranch force deletes a branch, merged or not. A branch that doesn't exist
+// is already gone, so that is not an error.
+func DeleteBranch(branchName string) error {
+	exists, err := BranchExists(branchName)
+	if err != nil || !exists {
+		return err
+	}
+	cmd := exec.Command("git", "branch", "-D", branchName)
+	cmd.Dir = getRepoRoot()
+	if out, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("failed to delete branch %s: %w: %s", branchName, err, strings.TrimSpace(string(out)))
+	}
+	return nil
+}
+
 // CheckoutBranch switches to an existing branch (deprecated: no longer needed with worktrees)
 func CheckoutBranch(branchName string) error {
 	cmd := exec.Command("git", "checkout", branchName)
@@ -135,6 +304,9 @@ func BranchExists(branchName string) (bool, error) {
 	return false, nil
 }
 
+// BRANCH_PREFIX starts the name of every branch Ludwig creates for a task
+const BRANCH_PREFIX = "ludwig/"
+
 // GenerateBranchName creates a unique branch name from a task name
 // Extracts first 2-3 words, converts to kebab-case, ensures uniqueness
 func GenerateBranchName(taskName string) (string, error) {
@@ -158,7 +330,7 @@ func GenerateBranchName(taskName string) (string, error) {
 	}
 
 	// Add ludwig/ prefix
-	baseName = "ludwig/" + baseName
+	baseName = BRANCH_PREFIX + baseName
 
 	// Check for duplicates and append counter if needed
 	branchName := baseName
@@ -0,0 +1,83 @@
+package orchestrator
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"ludwig/internal/config"
+)
+
+// IgnoreLudwigFiles adds .ludwig/ and the worktree directory to the .gitignore of
+// the repo in the current directory, so they aren't committed by accident. Entries
+// already ignored are left alone, as is a worktree directory outside the repo. It
+// returns the entries added, and does nothing outside a git repo.
+func IgnoreLudwigFiles(cfg *config.Config) ([]string, error) {
+	repoRoot := getRepoRoot()
+	if _, err := os.Stat(filepath.Join(repoRoot, ".git")); err != nil {
+		return nil, nil
+	}
+	entries := []string{".ludwig/"}
+	if rel, err := filepath.Rel(repoRoot, WorktreeBase(cfg)); err == nil && rel != "." && !strings.HasPrefix(rel, "..") {
+		entries = append(entries, filepath.ToSlash(rel)+"/")
+	}
+	return EnsureGitignored(filepath.Join(repoRoot, ".gitignore"), entries...)
+}
+
+// EnsureGitignored appends each entry missing from the gitignore file at path,
+// creating it if needed, and returns the entries added. Existing lines are never
+// rewritten. An entry counts as present with or without its leading or trailing
+// slash, e.g. ".worktrees" covers ".worktrees/".
+func EnsureGitignored(path string, entries ...string) ([]string, error) {
+	existing, err := os.ReadFile(path)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	present := map[string]bool{}
+	scanner := bufio.NewScanner(strings.NewReader(string(existing)))
+	for scanner.Scan() {
+		present[gitignoreKey(scanner.Text())] = true
+	}
+	var missing []string
+	for _, entry := range entries {
+		if key := gitignoreKey(entry); !present[key] {
+			present[key] = true
+			missing = append(missing, entry)
+		}
+	}
+	if len(missing) == 0 {
+		return nil, nil
+	}
+
+	var b strings.Builder
+	if len(existing) > 0 && !strings.HasSuffix(string(existing), "\n") {
+		b.WriteString("\n")
+	}
+	if len(existing) > 0 {
+		b.WriteString("\n")
+	}
+	b.WriteString("# Ludwig\n")
+	for _, entry := range missing {
+		b.WriteString(entry + "\n")
+	}
+
+	// Append rather than rewrite, so a concurrent edit to the file is never lost
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open %s: %w", path, err)
+	}
+	if _, err := file.WriteString(b.String()); err != nil {
+		file.Close()
+		return nil, fmt.Errorf("failed to update %s: %w", path, err)
+	}
+	return missing, file.Close()
+}
+
+// gitignoreKey normalizes a gitignore line for comparing entries
+func gitignoreKey(line string) string {
+	return strings.Trim(strings.TrimSpace(line), "/")
+}
@@ -0,0 +1,49 @@
+package orchestrator
+
+import (
+	"io"
+	"time"
+
+	"ludwig/internal/config"
+	"ludwig/internal/logger"
+	"ludwig/internal/metrics"
+	"ludwig/internal/orchestrator/clients"
+	"ludwig/internal/types/task"
+)
+
+var (
+	tasksCompleted = metrics.NewCounter("ludwig_tasks_completed_total", "Tasks the orchestrator has completed.")
+	taskFailures   = metrics.NewCounter("ludwig_task_failures_total", "AI runs that errored plus tasks that ended Failed.")
+	aiRequestTime  = metrics.NewHistogram("ludwig_ai_request_duration_seconds", "Time taken by each AI request.",
+		[]float64{1, 5, 15, 30, 60, 120, 300, 600, 1800})
+)
+
+// sendPrompt sends a task's prompt to the AI client in its work dir with the model
+// SelectModel picks and the env config option, recording how long it took and, when enabled, an audit log line.
+// Secrets are masked in the error it returns, which ends up in ludwig.log and toasts.
+func sendPrompt(aiClient clients.AIClient, cfg *config.Config, t *task.Task, prompt string, writer io.Writer) (string, error) {
+	if cfg != nil && cfg.AuditLog {
+		if path, err := clients.DefaultAuditPath(); err == nil {
+			audited := clients.WithAudit(aiClient, clients.AuditFile(path))
+			audited.TaskID = t.ID
+			audited.Redact = newRedactor(cfg).Redact
+			aiClient = audited
+		} else {
+			logger.Warnf("Audit log disabled, could not find home directory: %v", err)
+		}
+	}
+
+	ctx := taskContext(t.ID)
+	if model := SelectModel(cfg, t); model != "" {
+		logger.Infof("Task %s runs with model %s", t.ShortID(), model)
+		ctx = clients.WithModel(ctx, model)
+	}
+	if env, _ := TaskEnv(cfg); len(env) > 0 {
+		ctx = clients.WithEnv(ctx, env)
+	}
+
+	start := time.Now()
+	defer func() { aiRequestTime.Observe(time.Since(start).Seconds()) }()
+	response, err := clients.SendPromptWithContext(ctx, aiClient, prompt, writer, t.WorkDir())
+	return response, redactError(newRedactor(cfg), err)
+}
@@ -1,54 +1,121 @@
 package orchestrator
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
 	"sync"
 	"time"
 
 	"ludwig/internal/config"
+	"ludwig/internal/logger"
+	"ludwig/internal/notify"
 	"ludwig/internal/orchestrator/clients"
 	"ludwig/internal/storage"
 	"ludwig/internal/types/task"
+	"ludwig/internal/utils"
 )
 
 var (
 	mu                sync.Mutex
 	running           bool
+	activeTasks       = map[string]task.Task{} // Snapshots of tasks currently being worked on, by ID
+	activeSince       = map[string]time.Time{} // When each active task was picked up, by ID
+	activeContexts    = map[string]context.Context{}         // Cancelled to abort an active task's AI call
+	activeCancels     = map[string]context.CancelCauseFunc{} // Cancels the matching activeContexts entry
 	stopCh            chan struct{}
 	wg                sync.WaitGroup
 	rateLimitMu       sync.Mutex
 	lastRequestTime   time.Time
-	semaphore         chan struct{} // Limits concurrent tasks to 3
+	semaphore         chan struct{} // Limits concurrent tasks to MAX_CONCURRENT_TASKS
 )
 
+// MAX_CONCURRENT_TASKS is how many tasks the orchestrator runs at once
+const MAX_CONCURRENT_TASKS = 3
+
 // Start launches the orchestrator loop in a goroutine.
-func Start() {
+// It refuses to start, returning ErrGitMissing, when git is not installed.
+func Start() error {
+	if !checkGit().OK {
+		logger.Errorf("Orchestrator not started: %v", ErrGitMissing)
+		return ErrGitMissing
+	}
+
 	mu.Lock()
 	defer mu.Unlock()
 	if running {
-		return
+		return nil
 	}
 	running = true
 	stopCh = make(chan struct{})
-	semaphore = make(chan struct{}, 3) // Max 3 parallel tasks
+	semaphore = make(chan struct{}, MAX_CONCURRENT_TASKS)
 	wg.Add(1)
 	go orchestratorLoop()
+	return nil
 }
 
-// Stop signals the orchestrator to stop and waits for it to finish.
+// Stop signals the orchestrator to stop and waits for it to finish. Running tasks
+// don't hold it up: their AI calls are cancelled, their uncommitted changes
+// committed and the tasks put back to run again on the next start. It is safe to
+// call again while an earlier Stop is still waiting on running tasks.
 func Stop() {
 	mu.Lock()
 	if !running {
 		mu.Unlock()
 		return
 	}
-	close(stopCh)
+	select {
+	case <-stopCh: // Already stopping
+	default:
+		close(stopCh)
+	}
 	mu.Unlock()
+	interruptActive(errStopped)
 	wg.Wait()
 	mu.Lock()
 	running = false
 	mu.Unlock()
 }
 
+// stopIdle stops the orchestrator from inside its loop once it has had nothing to
+// do for idleTimeout, unless Stop is already under way
+func stopIdle(idleTimeout time.Duration) {
+	mu.Lock()
+	select {
+	case <-stopCh:
+		mu.Unlock()
+		return
+	default:
+	}
+	close(stopCh)
+	running = false
+	mu.Unlock()
+
+	logger.Infof("Orchestrator stopped after being idle for %v", idleTimeout)
+	publishEvent(Event{
+		Type:   OrchestratorIdleStop,
+		Reason: fmt.Sprintf("idle for %v", idleTimeout),
+		At:     time.Now(),
+	})
+}
+
+// idleTimeoutFor parses the idleTimeout option, returning 0 (never stop) when it
+// is unset or invalid
+func idleTimeoutFor(cfg *config.Config) time.Duration {
+	if cfg == nil || cfg.IdleTimeout == "" {
+		return 0
+	}
+	timeout, err := time.ParseDuration(cfg.IdleTimeout)
+	if err != nil || timeout < 0 {
+		logger.Warnf("Ignoring idleTimeout %q, expected a duration such as \"15m\"", cfg.IdleTimeout)
+		return 0
+	}
+	return timeout
+}
+
 // IsRunning returns true if the orchestrator is running.
 func IsRunning() bool {
 	mu.Lock()
@@ -56,12 +123,13 @@ func IsRunning() bool {
 	return running
 }
 
-// orchestratorLoop polls for tasks and dispatches them to a worker pool.
+// orchestratorLoop claims runnable tasks and runs each in a worker slot.
+// It makes the same steps as RunOnce, but keeps up to MAX_CONCURRENT_TASKS going at once.
 func orchestratorLoop() {
 	defer wg.Done()
 	taskStore, err := storage.NewFileTaskStorage()
 	if err != nil {
-		// Silent failure - orchestrator runs in background
+		notify.Errorf("Orchestrator could not open task storage: %v", err)
 		return
 	}
 	
@@ -69,103 +137,166 @@ func orchestratorLoop() {
 	cfg, err := config.LoadConfig()
 	if err != nil {
 		// Config load failure is non-critical, continue without it
+		logger.Warnf("Could not load config, using defaults: %v", err)
 	}
 
-	// Initialize AI client based on configuration
-	var aiClient clients.AIClient
-	if cfg != nil {
-		switch cfg.AIProvider {
-		case "ollama":
-			aiClient = clients.NewOllamaClient(cfg.OllamaBaseURL, cfg.OllamaModel)
-		case "copilot":
-			aiClient = clients.NewCopilotClient(cfg.CopilotModel)
-		default:
-			// Default to Gemini
-			aiClient = &clients.GeminiClient{}
-		}
-	} else {
-		// Default to Gemini if no config
-		aiClient = &clients.GeminiClient{}
-	}
+	aiClient := NewClientChain(cfg)
+	schedule := schedulerFor(cfg)
+	idleTimeout := idleTimeoutFor(cfg)
+	idleSince := time.Now()
+	backoff := NewPollBackoff()
+
+	logger.Infof("Orchestrator started")
 
 	for {
+		// Wait for a free worker slot
 		select {
 		case <-stopCh:
+			logger.Infof("Orchestrator stopped")
 			return
-		default:
-			// Get all tasks and dispatch available ones
-			tasks, err := taskStore.ListTasks()
+		case semaphore <- struct{}{}:
+		}
+
+		t, release, err := claimNext(taskStore, schedule)
+		if err != nil || t == nil {
+			<-semaphore
+			busy := len(ActiveTasks()) > 0
+			if busy {
+				// Only back off when idle; a task finishing may make another runnable
+				backoff.Reset()
+			}
+			wait := backoff.Next() // No tasks available, wait before polling again
 			if err != nil {
-				time.Sleep(2 * time.Second)
-				continue
+				notify.Errorf("Orchestrator could not list tasks: %v", err)
+			} else {
+				logger.Debugf("No pending tasks found, polling again in %v", wait.Round(time.Millisecond))
 			}
-
-			foundWork := false
-
-			// First pass: process NeedsReview tasks with responses
-			for _, t := range tasks {
-				if t.Status == task.NeedsReview && t.ReviewResponse != nil {
-					// Try to acquire semaphore slot
-					select {
-					case semaphore <- struct{}{}:
-						foundWork = true
-						wg.Add(1)
-						go processResumeTask(taskStore, aiClient, cfg, t)
-					default:
-						// No available slots, continue to next task
-					}
+			if idleTimeout > 0 {
+				if busy {
+					idleSince = time.Now() // Still busy with earlier tasks
 				}
-			}
-
-			// Second pass: process Pending tasks
-			for _, t := range tasks {
-				if t.Status == task.Pending {
-					// Try to acquire semaphore slot
-					select {
-					case semaphore <- struct{}{}:
-						foundWork = true
-						wg.Add(1)
-						go processNewTask(taskStore, aiClient, cfg, t)
-					default:
-						// No available slots, continue to next task
-					}
+				idle := time.Since(idleSince)
+				if idle >= idleTimeout {
+					stopIdle(idleTimeout)
+					return
 				}
+				wait = min(wait, idleTimeout-idle)
 			}
-
-			if !foundWork {
-				time.Sleep(2 * time.Second) // No tasks available, wait before polling again
+			// Wake early for Stop rather than making it wait out the poll
+			select {
+			case <-stopCh:
+				logger.Infof("Orchestrator stopped")
+				return
+			case <-time.After(wait):
 			}
+			continue
 		}
+		idleSince = time.Now()
+		backoff.Reset()
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer func() { <-semaphore }() // Release semaphore slot
+			defer release()
+			runTask(taskStore, aiClient, cfg, t)
+		}()
 	}
 }
 
-// processResumeTask handles a NeedsReview task with a user response.
-func processResumeTask(taskStore *storage.FileTaskStorage, aiClient clients.AIClient, cfg *config.Config, t *task.Task) {
-	defer wg.Done()
-	defer func() { <-semaphore }() // Release semaphore slot
+// RunOnce processes at most one task with the project's config, returning once it
+// reaches its next resting state. The configured scheduling policy picks the task
+// (by default answered reviews before Pending ones); processed is false when there
+// was nothing to run.
+func RunOnce(taskStore storage.TaskStorage, aiClient clients.AIClient) (processed bool, err error) {
+	cfg, cfgErr := config.LoadConfig()
+	if cfgErr != nil {
+		logger.Warnf("Could not load config, using defaults: %v", cfgErr)
+	}
 
+	t, release, err := claimNext(taskStore, schedulerFor(cfg))
+	if err != nil || t == nil {
+		return false, err
+	}
+	defer release()
+	runTask(taskStore, aiClient, cfg, t)
+	return true, nil
+}
+
+// isResumable reports whether t is waiting on review and has been answered
+func isResumable(t *task.Task) bool {
+	return t.Status == task.NeedsReview && t.ReviewResponse != nil
+}
+
+// isStartable reports whether t is waiting for its first (or a retried) run
+func isStartable(t *task.Task) bool {
+	return t.Status == task.Pending
+}
+
+// claimNext finds the next runnable task in schedule order and marks it active so
+// concurrent workers never pick the same one. The task is re-read after claiming in
+// case another worker finished it since the list was taken. Call release once the
+// task is done.
+func claimNext(taskStore storage.TaskStorage, schedule Scheduler) (*task.Task, func(), error) {
+	tasks, err := taskStore.ListTasks()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	for _, t := range schedule(tasks) {
+		release, ok := claimActive(t)
+		if !ok {
+			continue
+		}
+		fresh, err := taskStore.GetTask(t.ID)
+		if err != nil || fresh.Archived || (!isResumable(fresh) && !isStartable(fresh)) {
+			release()
+			continue
+		}
+		return fresh, release, nil
+	}
+	return nil, nil, nil
+}
+
+// runTask dispatches t to the handler for its status
+func runTask(taskStore storage.TaskStorage, aiClient clients.AIClient, cfg *config.Config, t *task.Task) {
+	switch {
+	case isResumable(t):
+		logger.Infof("Resuming task %s: %s", t.ShortID(), t.Name)
+		processResumeTask(taskStore, aiClient, cfg, t)
+	case isStartable(t):
+		logger.Infof("Starting task %s: %s", t.ShortID(), t.Name)
+		processNewTask(taskStore, aiClient, cfg, t)
+	}
+}
+
+// processResumeTask handles a NeedsReview task with a user response.
+func processResumeTask(taskStore storage.TaskStorage, aiClient clients.AIClient, cfg *config.Config, t *task.Task) {
 	t.Status = task.InProgress
 	if err := taskStore.UpdateTask(t); err != nil {
 		return
 	}
+	publish(TaskStarted, t)
 
 	optionLabels := make([]string, len(t.Review.Options))
 	for i, opt := range t.Review.Options {
 		optionLabels[i] = opt.Label
 	}
 	prompt := BuildResumePrompt(t.Name, t.WorkInProgress, t.Review.Question, optionLabels, t.ReviewResponse.ChosenLabel, t.ReviewResponse.UserNotes)
+	prompt = ScopePrompt(prompt, t.SubPath)
 
 	// Apply rate limiting before request
 	applyRateLimit(cfg)
 
-	// Create response writer for streaming
-	respWriter, respPath, err := storage.NewResponseWriter(t.ID)
+	// Append to the task's existing response file so the whole review cycle is one log
+	respWriter, respPath, err := openResumeWriter(t)
 	if err != nil {
 		t.Status = task.NeedsReview
 		_ = taskStore.UpdateTask(t)
 		return
 	}
 	defer respWriter.Close()
+	configureResponseWriter(respWriter, cfg, t)
 
 	// Store response file path immediately so it's available during streaming
 	t.ResponseFile = respPath
@@ -173,48 +304,79 @@ func processResumeTask(taskStore *storage.FileTaskStorage, aiClient clients.AICl
 		// Failure to save path is non-critical
 	}
 
-	_, err = aiClient.SendPromptWithDir(prompt, respWriter, t.WorktreePath)
+	guard := startPathGuard(cfg, t)
+	response, err := sendPrompt(aiClient, cfg, t, prompt, respWriter)
+	if cause := stopCause(t); cause != nil {
+		failStopped(taskStore, cfg, t, respWriter, cause)
+		return
+	}
+	if escaped := guard.Escaped(); len(escaped) > 0 {
+		flagEscape(taskStore, t, respWriter, response, escaped)
+		return
+	}
 	if err != nil {
+		notify.Warnf("Task %s run failed, returning to review: %v", t.ShortID(), err)
 		t.Status = task.NeedsReview
+		t.Failures++
+		taskFailures.Inc()
 		_ = taskStore.UpdateTask(t)
 		return
 	}
 
-	t.Status = task.Completed
-	// ResponseFile already set above when streaming started
-	_ = taskStore.UpdateTask(t)
-
-	// Commit any uncommitted work before removing worktree
-	if t.WorktreePath != "" {
-		_ = CommitAnyChanges(t.WorktreePath, t.ID)
-		_ = RemoveWorktree(t.WorktreePath)
-		t.WorktreePath = ""
-		_ = taskStore.UpdateTask(t)
-	}
+	finishTask(taskStore, aiClient, cfg, t, response)
 }
 
 // processNewTask handles a Pending task that needs initial processing.
-func processNewTask(taskStore *storage.FileTaskStorage, aiClient clients.AIClient, cfg *config.Config, t *task.Task) {
-	defer wg.Done()
-	defer func() { <-semaphore }() // Release semaphore slot
+func processNewTask(taskStore storage.TaskStorage, aiClient clients.AIClient, cfg *config.Config, t *task.Task) {
+	prompt := BuildTaskPrompt(t.Name)
+	if worktreeExists(t.WorktreePath) {
+		// A previous run failed part way; reuse its worktree and show the AI what it wrote
+		logger.Infof("Retrying task %s in its existing worktree", t.ShortID())
+		if diff, err := WorktreeDiff(t.WorktreePath, t.BaseBranch); err == nil {
+			prompt = BuildRetryPrompt(t.Name, diff)
+		}
+	} else {
+		// Generate and create worktree for this task
+		branchName, err := GenerateBranchName(t.Name)
+		if err != nil {
+			return
+		}
 
-	// Generate and create worktree for this task
-	branchName, err := GenerateBranchName(t.Name)
-	if err != nil {
-		return
+		worktreePath, err := CreateWorktree(branchName, t.ID, t.BaseBranch)
+		if errors.Is(err, ErrBaseBranchNotFound) {
+			// Retrying won't make the branch appear, so fail the task rather than loop
+			failTask(taskStore, t, "base branch "+t.BaseBranch+" not found")
+			return
+		}
+		if err != nil {
+			// Counted as a failed run so a worktree that can't be made isn't retried forever
+			logger.Errorf("Could not create worktree for task %s: %v", t.ShortID(), err)
+			t.Failures++
+			taskFailures.Inc()
+			_ = taskStore.UpdateTask(t)
+			return
+		}
+		t.BranchName = branchName
+		t.WorktreePath = worktreePath
 	}
 
-	worktreePath, err := CreateWorktree(branchName, t.ID)
-	if err != nil {
+	// A missing sub path won't appear on a retry, so fail the task rather than loop
+	if info, err := os.Stat(t.WorkDir()); err != nil || !info.IsDir() {
+		_ = RemoveWorktree(t.WorktreePath)
+		t.WorktreePath = ""
+		failTask(taskStore, t, "sub path "+t.SubPath+" not found")
 		return
 	}
-	t.BranchName = branchName
-	t.WorktreePath = worktreePath
+	prompt = ScopePrompt(prompt, t.SubPath)
 
 	t.Status = task.InProgress
+	if t.StartedAt.IsZero() {
+		t.StartedAt = time.Now()
+	}
 	if err := taskStore.UpdateTask(t); err != nil {
 		return
 	}
+	publish(TaskStarted, t)
 
 	// Apply rate limiting before request
 	applyRateLimit(cfg)
@@ -227,6 +389,7 @@ func processNewTask(taskStore *storage.FileTaskStorage, aiClient clients.AIClien
 		return
 	}
 	defer respWriter.Close()
+	configureResponseWriter(respWriter, cfg, t)
 
 	// Store response file path immediately so it's available during streaming
 	t.ResponseFile = respPath
@@ -234,9 +397,27 @@ func processNewTask(taskStore *storage.FileTaskStorage, aiClient clients.AIClien
 		// Failure to save path is non-critical
 	}
 
-	response, err := aiClient.SendPromptWithDir(BuildTaskPrompt(t.Name), respWriter, t.WorktreePath)
+	guard := startPathGuard(cfg, t)
+	response, err := sendPrompt(aiClient, cfg, t, prompt, respWriter)
+	if cause := stopCause(t); cause != nil {
+		failStopped(taskStore, cfg, t, respWriter, cause)
+		return
+	}
+	if escaped := guard.Escaped(); len(escaped) > 0 {
+		flagEscape(taskStore, t, respWriter, response, escaped)
+		return
+	}
 	if err != nil {
+		notify.Warnf("Task %s run failed, will retry: %v", t.ShortID(), err)
+		if cfg != nil && cfg.DiscardFailedWork {
+			// Retry from the last commit rather than on top of the failed run's edits
+			if err := DiscardChanges(t.WorktreePath); err != nil {
+				logger.Warnf("Could not discard the changes of failed task %s: %v", t.ShortID(), err)
+			}
+		}
 		t.Status = task.Pending
+		t.Failures++
+		taskFailures.Inc()
 		_ = taskStore.UpdateTask(t)
 		return
 	}
@@ -244,25 +425,458 @@ func processNewTask(taskStore *storage.FileTaskStorage, aiClient clients.AIClien
 	// Check if response contains a review request
 	workInProgress, review, hasReview := parseReviewRequest(response)
 	if hasReview {
+		logger.Infof("Task %s needs review: %s", t.ShortID(), review.Question)
 		t.Status = task.NeedsReview
 		t.WorkInProgress = workInProgress
+		if worktreeExists(t.WorktreePath) {
+			// Show the reviewer what exists so far alongside the question
+			if stat, err := WorktreeDiffStat(t.WorktreePath, t.BaseBranch); err == nil {
+				review.DiffStat = stat
+			}
+		}
 		t.Review = review
 		// ResponseFile already set above when streaming started
 		_ = taskStore.UpdateTask(t)
+		publish(TaskNeedsReview, t)
+		// Leave the footer off; the resumed run appends to this file
+		_ = respWriter.Suspend()
 		return
 	}
 
-	t.Status = task.Completed
-	// ResponseFile already set above when streaming started
+	finishTask(taskStore, aiClient, cfg, t, response)
+}
+
+// failTask marks a task Failed for a problem an automatic retry can't fix
+func failTask(taskStore storage.TaskStorage, t *task.Task, reason string) {
+	notify.Errorf("Task %s failed: %s", t.ShortID(), reason)
+	t.Status = task.Failed
+	t.FailureReason = reason
+	t.Failures++
+	taskFailures.Inc()
 	_ = taskStore.UpdateTask(t)
+	publish(TaskFailed, t)
+}
+
+// failStopped marks a task whose run was stopped early as Failed. Its uncommitted
+// changes are committed, or thrown away with discardFailedWork; the worktree itself
+// stays so a retry can pick up from there. Runs stopped by Stop or Shutdown aren't
+// the task's fault, so their changes are committed and the task put back instead.
+func failStopped(taskStore storage.TaskStorage, cfg *config.Config, t *task.Task, respWriter *storage.ResponseWriter, cause error) {
+	if isInterrupted(cause) {
+		fmt.Fprintf(respWriter, "\n\n⏸ Run %v, the task will run again\n", cause)
+		if worktreeExists(t.WorktreePath) {
+			if err := CommitAnyChanges(t.WorktreePath, commitMessage(cfg, t)); err != nil {
+				logger.Warnf("Could not commit the work of interrupted task %s: %v", t.ShortID(), err)
+			}
+		}
+		t.Status = interruptedStatus(t)
+		_ = taskStore.UpdateTask(t)
+		return
+	}
+
+	reason := cause.Error()
+	switch {
+	case errors.Is(cause, errKilled):
+		fmt.Fprintf(respWriter, "\n\n⛔ Task killed\n")
+	case errors.Is(cause, storage.ErrResponseTooLarge):
+		// The writer has already marked the file as truncated
+		reason = fmt.Sprintf("response exceeded %d bytes", maxResponseBytes(cfg))
+	}
+	if worktreeExists(t.WorktreePath) {
+		var err error
+		if cfg != nil && cfg.DiscardFailedWork {
+			err = DiscardChanges(t.WorktreePath)
+		} else {
+			err = CommitAnyChanges(t.WorktreePath, commitMessage(cfg, t))
+		}
+		if err != nil {
+			logger.Warnf("Could not clean up the worktree of killed task %s: %v", t.ShortID(), err)
+		}
+	}
+	failTask(taskStore, t, reason)
+}
 
-	// Commit any uncommitted work before removing worktree
+// finishTask commits any leftover work, squashing the branch if configured, removes
+// the worktree and marks the task Completed, or Failed if the run left its branch
+// without a single change. Completed tasks get a summary of the final response.
+func finishTask(taskStore storage.TaskStorage, aiClient clients.AIClient, cfg *config.Config, t *task.Task, response string) {
+	producedChanges := true
+	if t.WorktreePath != "" {
+		// Commit any uncommitted work before removing worktree
+		_ = CommitAnyChanges(t.WorktreePath, commitMessage(cfg, t))
+		if cfg != nil && cfg.SquashCommits {
+			if err := SquashCommits(t.WorktreePath, t.BaseBranch, squashMessage(cfg, t)); err != nil {
+				logger.Warnf("Could not squash commits for task %s, keeping them as they are: %v", t.ShortID(), err)
+			}
+		}
+		if hasCommits, err := BranchHasCommits(t.WorktreePath, t.BaseBranch); err == nil {
+			producedChanges = hasCommits
+		}
+	}
+	if producedChanges {
+		// Before the worktree goes, so an AI summary call runs where the task did
+		t.Summary = summarize(aiClient, cfg, t, response)
+	}
 	if t.WorktreePath != "" {
-		_ = CommitAnyChanges(t.WorktreePath, t.ID)
 		_ = RemoveWorktree(t.WorktreePath)
 		t.WorktreePath = ""
-		_ = taskStore.UpdateTask(t)
 	}
+
+	if !producedChanges {
+		notify.Errorf("Task %s failed: no changes produced", t.ShortID())
+		t.Status = task.Failed
+		t.FailureReason = "no changes produced"
+		t.Failures++
+		taskFailures.Inc()
+	} else {
+		logger.Infof("Task %s completed", t.ShortID())
+		t.Status = task.Completed
+		t.CompletedAt = time.Now()
+		tasksCompleted.Inc()
+	}
+	// ResponseFile already set when streaming started
+	_ = taskStore.UpdateTask(t)
+	if t.Status == task.Failed {
+		publish(TaskFailed, t)
+	} else {
+		publish(TaskCompleted, t)
+	}
+}
+
+// summarize describes what a completed task did, from the "✓ Completed:" lines of
+// its reply or, with aiSummaries, by asking the AI. A failed summary call falls
+// back to the markers; it never fails the task.
+func summarize(aiClient clients.AIClient, cfg *config.Config, t *task.Task, response string) string {
+	reply := utils.ReplyText(response)
+	if cfg != nil && cfg.AISummaries {
+		summary, err := sendPrompt(aiClient, cfg, t, BuildSummaryPrompt(t.Title(), reply), io.Discard)
+		if summary := utils.ReplyText(summary); err == nil && summary != "" {
+			return summary
+		}
+		logger.Warnf("Could not get an AI summary for task %s, using its report: %v", t.ShortID(), err)
+	}
+	return task.FinalSummary(reply)
+}
+
+// NewAIClient creates the AI client selected by the configuration, defaulting to Gemini
+func NewAIClient(cfg *config.Config) clients.AIClient {
+	if cfg == nil {
+		return &clients.GeminiClient{}
+	}
+	client, _ := providerClient(cfg, cfg.AIProvider)
+	return client
+}
+
+// providerClient returns the client for the named provider, using its settings
+// from cfg. Unknown names give Gemini, the default, and false.
+func providerClient(cfg *config.Config, name string) (clients.AIClient, bool) {
+	switch name {
+	case "ollama":
+		return clients.NewOllamaClient(cfg.OllamaBaseURL, cfg.OllamaModel), true
+	case "copilot":
+		return clients.NewCopilotClient(cfg.CopilotModel), true
+	case "gemini":
+		return &clients.GeminiClient{}, true
+	default:
+		return &clients.GeminiClient{}, false
+	}
+}
+
+// providerName returns the name of the configured primary provider
+func providerName(cfg *config.Config) string {
+	if cfg == nil || cfg.AIProvider == "" {
+		return "gemini"
+	}
+	return cfg.AIProvider
+}
+
+// withFallbacks puts the fallbackProviders after the primary client, skipping
+// unknown names and repeats. Without any it returns primary as it is.
+func withFallbacks(cfg *config.Config, primary clients.AIClient) clients.AIClient {
+	if cfg == nil || len(cfg.FallbackProviders) == 0 {
+		return primary
+	}
+	name := providerName(cfg)
+	providers := []clients.Provider{{Name: name, Client: primary}}
+	seen := map[string]bool{name: true}
+	for _, fallback := range cfg.FallbackProviders {
+		client, ok := providerClient(cfg, fallback)
+		if !ok {
+			logger.Warnf("Ignoring unknown fallback provider %q", fallback)
+			continue
+		}
+		if seen[fallback] {
+			continue
+		}
+		seen[fallback] = true
+		providers = append(providers, clients.Provider{Name: fallback, Client: client})
+	}
+	if len(providers) == 1 {
+		return primary
+	}
+	return clients.WithFallback(providers...)
+}
+
+// NewClientChain wraps the configured provider in the middleware every request goes
+// through. Built once per orchestrator run so all workers share one rate limiter;
+// auditing is added per task in sendPrompt since entries carry the task ID.
+func NewClientChain(cfg *config.Config) clients.AIClient {
+	aiClient := withFallbacks(cfg, NewAIClient(cfg))
+	if cfg != nil && cfg.RequestsPerMinute > 0 {
+		aiClient = clients.WithRateLimit(aiClient, cfg.RequestsPerMinute)
+	}
+	// Retries sit outside the rate limiter so each attempt waits its turn
+	return withRetry(cfg, aiClient)
+}
+
+// withRetry wraps aiClient to retry rate limits with the backoff set by maxRetries
+// and retryDelay, or the defaults
+func withRetry(cfg *config.Config, aiClient clients.AIClient) *clients.RetryClient {
+	retry := clients.WithRetry(aiClient)
+	if cfg == nil {
+		return retry
+	}
+	switch {
+	case cfg.MaxRetries < 0:
+		retry.MaxRetries = 0
+	case cfg.MaxRetries > 0:
+		retry.MaxRetries = cfg.MaxRetries
+	}
+	if cfg.RetryDelay != "" {
+		delay, err := time.ParseDuration(cfg.RetryDelay)
+		if err != nil || delay < 0 {
+			logger.Warnf("Ignoring retryDelay %q, expected a duration such as \"10s\"", cfg.RetryDelay)
+		} else {
+			retry.BaseDelay = delay
+		}
+	}
+	return retry
+}
+
+// ActiveTasks returns the tasks the orchestrator is processing right now
+func ActiveTasks() []task.Task {
+	mu.Lock()
+	defer mu.Unlock()
+	active := make([]task.Task, 0, len(activeTasks))
+	for _, t := range activeTasks {
+		active = append(active, t)
+	}
+	return active
+}
+
+// RunningTask is a task the orchestrator is working on and when it was picked up
+type RunningTask struct {
+	Task  task.Task
+	Since time.Time
+}
+
+// RunningTasks returns the tasks being processed right now, longest running first
+func RunningTasks() []RunningTask {
+	mu.Lock()
+	defer mu.Unlock()
+	running := make([]RunningTask, 0, len(activeTasks))
+	for id, t := range activeTasks {
+		running = append(running, RunningTask{Task: t, Since: activeSince[id]})
+	}
+	sort.Slice(running, func(i, j int) bool {
+		if !running[i].Since.Equal(running[j].Since) {
+			return running[i].Since.Before(running[j].Since)
+		}
+		return running[i].Task.ID < running[j].Task.ID
+	})
+	return running
+}
+
+// ErrTaskNotRunning is returned by Kill for a task the orchestrator isn't processing
+var ErrTaskNotRunning = errors.New("task is not running")
+
+// errKilled is the cause a task's context is cancelled with by Kill
+var errKilled = errors.New("killed")
+
+// DEFAULT_MAX_RESPONSE_BYTES caps a single run's response file unless maxResponseBytes is set
+const DEFAULT_MAX_RESPONSE_BYTES = 50 << 20
+
+// Kill aborts the in-flight AI call of an active task without touching any other
+// task. The task's worker then commits or discards its uncommitted changes, per
+// the discardFailedWork option, and marks it Failed.
+func Kill(taskID string) error {
+	if !stopTask(taskID, errKilled) {
+		return ErrTaskNotRunning
+	}
+	return nil
+}
+
+// stopTask cancels an active task's AI call with cause, reporting whether it was active
+func stopTask(taskID string, cause error) bool {
+	mu.Lock()
+	defer mu.Unlock()
+	cancel, ok := activeCancels[taskID]
+	if ok {
+		cancel(cause)
+	}
+	return ok
+}
+
+// ErrTaskRunning is returned by DiscardWork for a task the orchestrator is processing
+var ErrTaskRunning = errors.New("task is running")
+
+// DiscardWork throws away everything a task's runs produced, committed or not: its
+// worktree is removed and its branch deleted, so a later run starts afresh from
+// the base branch. Running tasks must be killed first.
+func DiscardWork(taskStore storage.TaskStorage, t *task.Task) error {
+	mu.Lock()
+	_, running := activeTasks[t.ID]
+	mu.Unlock()
+	if running {
+		return ErrTaskRunning
+	}
+
+	if worktreeExists(t.WorktreePath) {
+		if err := RemoveWorktree(t.WorktreePath); err != nil {
+			return err
+		}
+	}
+	if t.BranchName != "" {
+		if err := DeleteBranch(t.BranchName); err != nil {
+			return err
+		}
+	}
+	t.WorktreePath = ""
+	t.BranchName = ""
+	return taskStore.UpdateTask(t)
+}
+
+// taskContext returns the context an active task's AI calls run under
+func taskContext(taskID string) context.Context {
+	mu.Lock()
+	defer mu.Unlock()
+	if ctx, ok := activeContexts[taskID]; ok {
+		return ctx
+	}
+	return context.Background()
+}
+
+// stopCause returns why t's run was stopped early, by Kill or by its response
+// outgrowing the size limit, or nil if it wasn't
+func stopCause(t *task.Task) error {
+	ctx := taskContext(t.ID)
+	if ctx.Err() == nil {
+		return nil
+	}
+	return context.Cause(ctx)
+}
+
+// claimActive records t as being processed, like trackActive, unless it already is
+func claimActive(t *task.Task) (func(), bool) {
+	mu.Lock()
+	defer mu.Unlock()
+	if _, busy := activeTasks[t.ID]; busy {
+		return nil, false
+	}
+	return addActive(t), true
+}
+
+// trackActive records t as being processed until the returned func is called
+func trackActive(t *task.Task) func() {
+	mu.Lock()
+	defer mu.Unlock()
+	return addActive(t)
+}
+
+// addActive records t as active and returns the func that removes it. mu must be held.
+func addActive(t *task.Task) func() {
+	ctx, cancel := context.WithCancelCause(context.Background())
+	activeTasks[t.ID] = *t
+	activeSince[t.ID] = time.Now()
+	activeContexts[t.ID] = ctx
+	activeCancels[t.ID] = cancel
+	return func() {
+		mu.Lock()
+		defer mu.Unlock()
+		cancel(nil)
+		delete(activeTasks, t.ID)
+		delete(activeSince, t.ID)
+		delete(activeContexts, t.ID)
+		delete(activeCancels, t.ID)
+	}
+}
+
+// ProcessTask runs a single task to its next resting state, blocking until done.
+// Pending tasks get a fresh (or retried) run and NeedsReview tasks with a
+// response are resumed; anything else is left untouched.
+func ProcessTask(taskStore storage.TaskStorage, aiClient clients.AIClient, cfg *config.Config, t *task.Task) {
+	if !isResumable(t) && !isStartable(t) {
+		return
+	}
+
+	defer trackActive(t)()
+	runTask(taskStore, aiClient, cfg, t)
+}
+
+// configureResponseWriter applies response file settings from config. A response
+// that outgrows the size limit stops t's run, so a runaway AI can't fill the disk.
+// What reaches the file is also sent to t's SubscribeOutput subscribers.
+func configureResponseWriter(respWriter *storage.ResponseWriter, cfg *config.Config, t *task.Task) {
+	if cfg != nil && cfg.SyncResponses {
+		respWriter.SetFlushMode(storage.FlushSyncEveryWrite)
+	}
+	respWriter.SetRedactor(newRedactor(cfg))
+	respWriter.SetSizeLimit(maxResponseBytes(cfg), func() {
+		logger.Warnf("Task %s response exceeded %d bytes, stopping it", t.ShortID(), maxResponseBytes(cfg))
+		stopTask(t.ID, storage.ErrResponseTooLarge)
+	})
+	respWriter.SetMirror(taskOutput(t.ID))
+}
+
+// maxResponseBytes returns the configured response size limit, or 0 for none
+func maxResponseBytes(cfg *config.Config) int64 {
+	switch {
+	case cfg == nil || cfg.MaxResponseBytes == 0:
+		return DEFAULT_MAX_RESPONSE_BYTES
+	case cfg.MaxResponseBytes < 0:
+		return 0
+	}
+	return cfg.MaxResponseBytes
+}
+
+// newRedactor builds the secret redactor for the configuration, or nil if redaction is disabled.
+// Bad user patterns are reported and skipped so the built-in ones still apply. Values
+// the env option reads from Ludwig's environment are masked even with redaction
+// disabled, since they were kept out of the config to stay secret.
+func newRedactor(cfg *config.Config) *storage.Redactor {
+	if cfg == nil {
+		redactor, _ := storage.NewRedactor(nil)
+		return redactor
+	}
+	_, secrets := TaskEnv(cfg)
+	if cfg.DisableRedaction {
+		if len(secrets) == 0 {
+			return nil
+		}
+		redactor := &storage.Redactor{}
+		redactor.AddValues(secrets...)
+		return redactor
+	}
+	redactor, err := storage.NewRedactor(cfg.RedactPatterns)
+	if err != nil {
+		logger.Warnf("Ignoring redactPatterns: %v", err)
+		redactor, _ = storage.NewRedactor(nil)
+	}
+	redactor.AddValues(secrets...)
+	return redactor
+}
+
+// openResumeWriter reopens the task's response file for appending,
+// falling back to a fresh file if there is none to resume
+func openResumeWriter(t *task.Task) (*storage.ResponseWriter, string, error) {
+	if t.ResponseFile != "" {
+		if stored, err := storage.ResolveResponseFile(t.ID, t.ResponseFile); err == nil {
+			if respWriter, err := storage.OpenResponseWriter(stored); err == nil {
+				return respWriter, stored, nil
+			}
+		}
+	}
+	return storage.NewResponseWriter(t.ID)
 }
 
 // parseReviewRequest extracts a review request and work-in-progress from the AI response
@@ -0,0 +1,57 @@
+package orchestrator
+
+import "sync"
+
+// OUTPUT_BUFFER_SIZE is how many chunks of output a subscriber can fall behind by
+// before new chunks are dropped for it
+const OUTPUT_BUFFER_SIZE = 256
+
+var (
+	outputMu          sync.Mutex
+	outputSubscribers = map[string]map[chan string]struct{}{} // By task ID
+)
+
+// SubscribeOutput returns a channel of a task's response output as it reaches its
+// response file, and a func to stop receiving it. Only runs in this process are
+// seen, and chunks are dropped for a subscriber that falls behind, so the response
+// file stays the record: use this to learn of new output without polling for it.
+func SubscribeOutput(taskID string) (<-chan string, func()) {
+	ch := make(chan string, OUTPUT_BUFFER_SIZE)
+	outputMu.Lock()
+	if outputSubscribers[taskID] == nil {
+		outputSubscribers[taskID] = map[chan string]struct{}{}
+	}
+	outputSubscribers[taskID][ch] = struct{}{}
+	outputMu.Unlock()
+
+	var once sync.Once
+	return ch, func() {
+		once.Do(func() {
+			outputMu.Lock()
+			delete(outputSubscribers[taskID], ch)
+			if len(outputSubscribers[taskID]) == 0 {
+				delete(outputSubscribers, taskID)
+			}
+			outputMu.Unlock()
+			close(ch)
+		})
+	}
+}
+
+// taskOutput is an io.Writer that sends a task's output to its subscribers
+type taskOutput string
+
+// Write sends p to every subscriber of the task without blocking
+func (id taskOutput) Write(p []byte) (int, error) {
+	chunk := string(p)
+	outputMu.Lock()
+	defer outputMu.Unlock()
+	for ch := range outputSubscribers[string(id)] {
+		select {
+		case ch <- chunk:
+		default:
+			// Slow subscriber; drop rather than hold up the others
+		}
+	}
+	return len(p), nil
+}
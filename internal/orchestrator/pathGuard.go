@@ -0,0 +1,197 @@
+package orchestrator
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"ludwig/internal/config"
+	"ludwig/internal/logger"
+	"ludwig/internal/storage"
+	"ludwig/internal/types/task"
+)
+
+// ESCAPE_REVIEW_QUESTION starts the review a task is put in when the path guard
+// finds it changed files outside its worktree
+const ESCAPE_REVIEW_QUESTION = "The AI changed files outside the task's worktree"
+
+// treeSnapshot maps each changed or untracked file of a working tree, relative to
+// it, to a hash of its content, "" once deleted
+type treeSnapshot map[string]string
+
+// pathGuard notes the state of every working tree but a task's own before the AI
+// runs, so changes it makes outside its worktree can be found afterwards
+type pathGuard struct {
+	task     *task.Task
+	repoRoot string
+	skip     []string                // Ludwig's own directories, which change as tasks run
+	trees    map[string]treeSnapshot // By working tree path
+}
+
+// startPathGuard snapshots the main repo and the worktrees of tasks not running,
+// or returns nil if the pathGuard option is off
+func startPathGuard(cfg *config.Config, t *task.Task) *pathGuard {
+	if cfg == nil || !cfg.PathGuard {
+		return nil
+	}
+	g := &pathGuard{task: t, repoRoot: getRepoRoot(), skip: []string{WorktreeBase(cfg)}, trees: map[string]treeSnapshot{}}
+	if dataDir, err := storage.DataDir(); err == nil {
+		g.skip = append(g.skip, dataDir)
+	}
+	for _, dir := range g.guardedTrees() {
+		snapshot, err := g.snapshot(dir)
+		if err != nil {
+			logger.Warnf("Path guard can't watch %s for task %s: %v", dir, t.ShortID(), err)
+			continue
+		}
+		g.trees[dir] = snapshot
+	}
+	return g
+}
+
+// Escaped returns the files, relative to the repo root, that changed outside the
+// task's worktree since the guard started, or nil for a nil guard. Worktrees of
+// tasks that started running meanwhile are left out, as their changes are their own.
+func (g *pathGuard) Escaped() []string {
+	if g == nil {
+		return nil
+	}
+	still := map[string]bool{}
+	for _, dir := range g.guardedTrees() {
+		still[dir] = true
+	}
+
+	var escaped []string
+	for dir, before := range g.trees {
+		if !still[dir] {
+			continue
+		}
+		after, err := g.snapshot(dir)
+		if err != nil {
+			logger.Warnf("Path guard can't check %s for task %s: %v", dir, g.task.ShortID(), err)
+			continue
+		}
+		for path, hash := range after {
+			if old, seen := before[path]; !seen || old != hash {
+				escaped = append(escaped, g.relative(dir, path))
+			}
+		}
+		for path := range before {
+			if _, seen := after[path]; !seen {
+				// Changes undone, e.g. a modified file restored
+				escaped = append(escaped, g.relative(dir, path))
+			}
+		}
+	}
+	sort.Strings(escaped)
+	return escaped
+}
+
+// guardedTrees returns the main repo and every worktree but the task's own and
+// those of other running tasks
+func (g *pathGuard) guardedTrees() []string {
+	dirs := []string{g.repoRoot}
+	worktrees, err := ListWorktrees()
+	if err != nil {
+		logger.Warnf("Path guard can't list worktrees: %v", err)
+		return dirs
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	for _, w := range worktrees {
+		if filepath.Clean(w.Path) == filepath.Clean(g.task.WorktreePath) {
+			continue
+		}
+		if _, running := activeTasks[filepath.Base(w.Path)]; running {
+			continue
+		}
+		dirs = append(dirs, w.Path)
+	}
+	return dirs
+}
+
+// snapshot hashes the files `git status` reports as changed or untracked in dir
+func (g *pathGuard) snapshot(dir string) (treeSnapshot, error) {
+	cmd := exec.Command("git", "status", "--porcelain", "-z", "--untracked-files=all")
+	cmd.Dir = dir
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("git status failed: %w", err)
+	}
+
+	snapshot := treeSnapshot{}
+	entries := bytes.Split(out, []byte{0})
+	for i := 0; i < len(entries); i++ {
+		entry := string(entries[i])
+		if len(entry) < 4 {
+			continue
+		}
+		status, path := entry[:2], entry[3:]
+		if status[0] == 'R' || status[0] == 'C' {
+			i++ // The entry after a rename or copy is its source
+		}
+		full := filepath.Join(dir, path)
+		if g.skipped(full) {
+			continue
+		}
+		snapshot[path] = hashFile(full)
+	}
+	return snapshot, nil
+}
+
+// skipped reports whether path is within one of Ludwig's own directories
+func (g *pathGuard) skipped(path string) bool {
+	for _, dir := range g.skip {
+		if rel, err := filepath.Rel(dir, path); err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			return true
+		}
+	}
+	return false
+}
+
+// relative returns path, within the working tree dir, relative to the repo root
+func (g *pathGuard) relative(dir, path string) string {
+	if rel, err := filepath.Rel(g.repoRoot, filepath.Join(dir, path)); err == nil {
+		return filepath.ToSlash(rel)
+	}
+	return filepath.Join(dir, path)
+}
+
+// hashFile returns a hash of the file's content, or "" if it can't be read
+func hashFile(path string) string {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return ""
+	}
+	sum := sha256.Sum256(content)
+	return hex.EncodeToString(sum[:])
+}
+
+// flagEscape puts t in review for changing the files outside its worktree, keeping
+// the run's reply as its work so far. Answering the review resumes the task, with
+// the question telling the AI what it changed.
+func flagEscape(taskStore storage.TaskStorage, t *task.Task, respWriter *storage.ResponseWriter, response string, escaped []string) {
+	logger.Warnf("Task %s changed %d file(s) outside its worktree", t.ShortID(), len(escaped))
+	t.Status = task.NeedsReview
+	t.WorkInProgress = response
+	t.ReviewResponse = nil
+	t.Review = &task.ReviewRequest{
+		Question: ESCAPE_REVIEW_QUESTION + ": " + strings.Join(escaped, ", ") + ". Check these changes before the task goes on.",
+		Options: []task.ReviewOption{
+			{ID: "continue", Label: "The changes are fine, carry on"},
+			{ID: "revert", Label: "Undo the changes outside the worktree, then carry on"},
+		},
+		CreatedAt: time.Now(),
+	}
+	_ = taskStore.UpdateTask(t)
+	publish(TaskNeedsReview, t)
+	// Leave the footer off; the resumed run appends to this file
+	_ = respWriter.Suspend()
+}
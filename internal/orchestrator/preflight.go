@@ -0,0 +1,62 @@
+package orchestrator
+
+import (
+	"errors"
+	"os/exec"
+
+	"ludwig/internal/config"
+	"ludwig/internal/orchestrator/clients"
+)
+
+// ErrGitMissing is returned when git can't be found; every task runs in a git worktree
+var ErrGitMissing = errors.New("git is not installed or not on PATH; install git and try again")
+
+// PreflightCheck is the result of checking one thing the orchestrator depends on
+type PreflightCheck struct {
+	Name     string // What was checked, e.g. "git" or "gemini CLI"
+	OK       bool
+	Required bool   // The orchestrator refuses to start when a required check fails
+	Guidance string // How to fix the check when it fails
+}
+
+// Preflight checks that git and the configured AI provider are usable. Only a
+// missing git is an error; an unreachable provider is reported in the checks so
+// the user can fix it while tasks wait.
+func Preflight(cfg *config.Config) ([]PreflightCheck, error) {
+	checks := []PreflightCheck{checkGit(), checkProvider(cfg)}
+	if !checks[0].OK {
+		return checks, ErrGitMissing
+	}
+	return checks, nil
+}
+
+func checkGit() PreflightCheck {
+	_, err := exec.LookPath("git")
+	return PreflightCheck{
+		Name:     "git",
+		OK:       err == nil,
+		Required: true,
+		Guidance: "Install git (https://git-scm.com/downloads) and make sure it is on your PATH",
+	}
+}
+
+func checkProvider(cfg *config.Config) PreflightCheck {
+	client := NewAIClient(cfg)
+	check := PreflightCheck{OK: true}
+	if checker, ok := client.(clients.AvailabilityChecker); ok {
+		check.OK = checker.Available()
+	}
+
+	switch c := client.(type) {
+	case *clients.OllamaClient:
+		check.Name = "Ollama server"
+		check.Guidance = "Start Ollama with 'ollama serve' (expected at " + c.BaseURL + ") and pull the model with 'ollama pull " + c.Model + "'"
+	case *clients.CopilotClient:
+		check.Name = "copilot CLI"
+		check.Guidance = "Install the GitHub Copilot CLI with 'npm install -g @github/copilot' and sign in by running 'copilot' once"
+	default:
+		check.Name = "gemini CLI"
+		check.Guidance = "Install the Gemini CLI with 'npm install -g @google/gemini-cli' and sign in by running 'gemini' once"
+	}
+	return check
+}
@@ -103,6 +103,42 @@ func BuildTaskPrompt(taskName string) string {
 	return SystemPrompt + "\n\nTask: " + taskName
 }
 
+// ScopePrompt tells the AI a task is limited to subPath within the repo.
+// Prompts for unscoped tasks are returned unchanged.
+func ScopePrompt(prompt string, subPath string) string {
+	if subPath == "" {
+		return prompt
+	}
+	return prompt + `
+
+SCOPE: This task only concerns the ` + subPath + ` directory of the repository, which is your working directory. Read its README.md if it has one, as well as the root README.md for project-wide guidelines. Keep your changes inside ` + subPath + ` unless the task cannot be done otherwise.`
+}
+
+// MAX_RETRY_DIFF_BYTES caps how much of a failed attempt's diff is sent back to the AI
+const MAX_RETRY_DIFF_BYTES = 64 * 1024
+
+// BuildRetryPrompt creates a prompt for retrying a task whose previous run failed,
+// including the diff of the code that run left behind so the AI can continue from it
+func BuildRetryPrompt(taskName string, diff string) string {
+	if diff == "" {
+		return BuildTaskPrompt(taskName)
+	}
+	if len(diff) > MAX_RETRY_DIFF_BYTES {
+		diff = diff[:MAX_RETRY_DIFF_BYTES] + "\n[diff truncated]\n"
+	}
+
+	return SystemPrompt + `
+
+Task: ` + taskName + `
+
+A previous attempt at this task failed before finishing. Here is the code you wrote in that attempt, as a git diff against the starting point:
+
+[PREVIOUS ATTEMPT DIFF]
+` + diff + `[END PREVIOUS ATTEMPT DIFF]
+
+These changes are still in your working directory. Review them, fix whatever caused the failure, and continue from where you left off rather than starting over.`
+}
+
 // BuildResumePrompt creates a prompt that resumes task execution with user feedback
 func BuildResumePrompt(taskName string, workInProgress string, question string, options []string, chosenLabel string, userNotes string) string {
 	optionsStr := ""
@@ -133,3 +169,23 @@ User chose: ` + chosenLabel + notes + `
 
 Now continue and complete the task using the user's choice.`
 }
+
+// MAX_SUMMARY_REPLY_BYTES caps how much of a task's reply is sent to be summarised;
+// the end of the reply, where the AI reports what it did, is kept
+const MAX_SUMMARY_REPLY_BYTES = 32 * 1024
+
+// BuildSummaryPrompt asks for a short summary of a finished task's reply. It is a
+// plain question, not a task, so SystemPrompt is left out.
+func BuildSummaryPrompt(taskName string, reply string) string {
+	if len(reply) > MAX_SUMMARY_REPLY_BYTES {
+		reply = "[start of reply truncated]\n" + reply[len(reply)-MAX_SUMMARY_REPLY_BYTES:]
+	}
+
+	return `Summarise the work below in at most three short sentences for someone skimming a task board. Say what was changed, not how. Reply with the summary only. Do not use any tools or modify any files.
+
+Task: ` + taskName + `
+
+[WORK REPORT]
+` + reply + `
+[END WORK REPORT]`
+}
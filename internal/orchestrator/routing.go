@@ -0,0 +1,35 @@
+package orchestrator
+
+import (
+	"strings"
+
+	"ludwig/internal/config"
+	"ludwig/internal/types/task"
+)
+
+// COMPLEX_TAG marks a task as needing the powerfulModel, with or without a leading #
+const COMPLEX_TAG = "complex"
+
+// SelectModel returns the model t should run with: the one given when it was added,
+// else powerfulModel for a complex task. "" means the provider's own model.
+func SelectModel(cfg *config.Config, t *task.Task) string {
+	if t.Model != "" {
+		return t.Model
+	}
+	if cfg == nil || cfg.PowerfulModel == "" {
+		return ""
+	}
+	if IsComplex(cfg, t) {
+		return cfg.PowerfulModel
+	}
+	return ""
+}
+
+// IsComplex reports whether t is tagged complex, or its description is longer than
+// complexTaskLength when that is set
+func IsComplex(cfg *config.Config, t *task.Task) bool {
+	if t.HasTag(COMPLEX_TAG) || t.HasTag("#"+COMPLEX_TAG) {
+		return true
+	}
+	return cfg != nil && cfg.ComplexTaskLength > 0 && len([]rune(strings.TrimSpace(t.Name))) > cfg.ComplexTaskLength
+}
@@ -0,0 +1,105 @@
+package orchestrator
+
+import (
+	"fmt"
+	"sort"
+
+	"ludwig/internal/config"
+	"ludwig/internal/logger"
+	"ludwig/internal/types/task"
+)
+
+// Scheduling policies accepted by the schedulingPolicy config option
+const (
+	POLICY_REVIEW_FIRST  = "review-first"  // Answered reviews, then Pending tasks (default)
+	POLICY_PENDING_FIRST = "pending-first" // Pending tasks, then answered reviews
+	POLICY_FIFO          = "fifo"          // Oldest runnable task, whatever its status
+	POLICY_PRIORITY      = "priority"      // Highest Priority first, then review-first
+)
+
+// Scheduler returns the runnable tasks of a list in the order they should be picked up.
+// Ties are always broken oldest first, so the choice is stable between polls.
+type Scheduler func(tasks []*task.Task) []*task.Task
+
+var schedulers = map[string]Scheduler{
+	POLICY_REVIEW_FIRST:  reviewFirst,
+	POLICY_PENDING_FIRST: pendingFirst,
+	POLICY_FIFO:          runnableByAge,
+	POLICY_PRIORITY:      byPriority,
+}
+
+// SchedulerFor returns the scheduler for a policy name; empty means review-first
+func SchedulerFor(policy string) (Scheduler, error) {
+	if policy == "" {
+		policy = POLICY_REVIEW_FIRST
+	}
+	scheduler, ok := schedulers[policy]
+	if !ok {
+		return nil, fmt.Errorf("unknown scheduling policy %q (use %s, %s, %s or %s)",
+			policy, POLICY_REVIEW_FIRST, POLICY_PENDING_FIRST, POLICY_FIFO, POLICY_PRIORITY)
+	}
+	return scheduler, nil
+}
+
+// schedulerFor picks the configured scheduler, falling back to review-first on a bad name
+func schedulerFor(cfg *config.Config) Scheduler {
+	if cfg == nil {
+		return reviewFirst
+	}
+	scheduler, err := SchedulerFor(cfg.SchedulingPolicy)
+	if err != nil {
+		logger.Warnf("%v; using %s", err, POLICY_REVIEW_FIRST)
+		return reviewFirst
+	}
+	return scheduler
+}
+
+// runnableByAge returns the non-archived tasks the orchestrator can run, oldest first
+func runnableByAge(tasks []*task.Task) []*task.Task {
+	runnable := make([]*task.Task, 0, len(tasks))
+	for _, t := range tasks {
+		if !t.Archived && (isResumable(t) || isStartable(t)) {
+			runnable = append(runnable, t)
+		}
+	}
+	sort.Slice(runnable, func(i, j int) bool {
+		a, b := runnable[i], runnable[j]
+		if !a.CreatedAt.Equal(b.CreatedAt) {
+			return a.CreatedAt.Before(b.CreatedAt)
+		}
+		return a.ID < b.ID
+	})
+	return runnable
+}
+
+// reviewRank orders answered reviews ahead of Pending tasks
+func reviewRank(t *task.Task) int {
+	if isResumable(t) {
+		return 0
+	}
+	return 1
+}
+
+func reviewFirst(tasks []*task.Task) []*task.Task {
+	runnable := runnableByAge(tasks)
+	sort.SliceStable(runnable, func(i, j int) bool {
+		return reviewRank(runnable[i]) < reviewRank(runnable[j])
+	})
+	return runnable
+}
+
+func pendingFirst(tasks []*task.Task) []*task.Task {
+	runnable := runnableByAge(tasks)
+	sort.SliceStable(runnable, func(i, j int) bool {
+		return reviewRank(runnable[i]) > reviewRank(runnable[j])
+	})
+	return runnable
+}
+
+func byPriority(tasks []*task.Task) []*task.Task {
+	runnable := reviewFirst(tasks)
+	sort.SliceStable(runnable, func(i, j int) bool {
+		return runnable[i].Priority > runnable[j].Priority
+	})
+	return runnable
+}
@@ -0,0 +1,99 @@
+package orchestrator
+
+import (
+	"errors"
+	"time"
+
+	"ludwig/internal/logger"
+	"ludwig/internal/storage"
+	"ludwig/internal/types/task"
+)
+
+// SHUTDOWN_TIMEOUT is how long Shutdown waits for cancelled runs to save their work
+const SHUTDOWN_TIMEOUT = 10 * time.Second
+
+// errShutdown is the cause active tasks are cancelled with when Ludwig exits
+var errShutdown = errors.New("interrupted by shutdown")
+
+// errStopped is the cause active tasks are cancelled with by Stop
+var errStopped = errors.New("interrupted by stop")
+
+// Shutdown stops the orchestrator because the process is exiting. Like Stop, it
+// cancels the AI calls under way, their uncommitted changes committed and the
+// tasks put back to run again next time, but it only waits timeout for them. If
+// this process was running tasks, any still In Progress after timeout are reset
+// by ResetInterrupted before returning.
+func Shutdown(taskStore storage.TaskStorage, timeout time.Duration) {
+	mu.Lock()
+	// Without this, the In Progress tasks may belong to another process's orchestrator
+	working := running || len(activeCancels) > 0
+	mu.Unlock()
+	interruptActive(errShutdown)
+
+	stopped := make(chan struct{})
+	go func() {
+		Stop()
+		close(stopped)
+	}()
+	select {
+	case <-stopped:
+	case <-time.After(timeout):
+		logger.Warnf("Tasks were still saving their work after %s, exiting anyway", timeout)
+	}
+
+	if !working {
+		return
+	}
+	if reset, err := ResetInterrupted(taskStore); err != nil {
+		logger.Errorf("Could not reset interrupted tasks: %v", err)
+	} else if reset > 0 {
+		logger.Infof("Reset %d interrupted task(s) so they run again", reset)
+	}
+}
+
+// interruptActive cancels every task this process is running with cause, either
+// errShutdown or errStopped, so each commits its work and goes back to run again
+func interruptActive(cause error) {
+	mu.Lock()
+	defer mu.Unlock()
+	for _, cancel := range activeCancels {
+		cancel(cause)
+	}
+}
+
+// isInterrupted reports whether a run was cut short by Stop or Shutdown rather
+// than through any fault of the task's
+func isInterrupted(cause error) bool {
+	return errors.Is(cause, errShutdown) || errors.Is(cause, errStopped)
+}
+
+// ResetInterrupted puts every task left In Progress back where the orchestrator
+// will pick it up: answered reviews go back to Needs Review to be resumed and the
+// rest to Pending. It returns how many tasks were reset. Only call it when no
+// orchestrator is working on the tasks.
+func ResetInterrupted(taskStore storage.TaskStorage) (int, error) {
+	tasks, err := taskStore.ListTasks()
+	if err != nil {
+		return 0, err
+	}
+	reset := 0
+	for _, t := range tasks {
+		if t.Status != task.InProgress {
+			continue
+		}
+		t.Status = interruptedStatus(t)
+		if err := taskStore.UpdateTask(t); err != nil {
+			return reset, err
+		}
+		reset++
+	}
+	return reset, nil
+}
+
+// interruptedStatus is where a task whose run was cut short goes to run again
+func interruptedStatus(t *task.Task) task.Status {
+	if t.Review != nil && t.ReviewResponse != nil {
+		return task.NeedsReview
+	}
+	return task.Pending
+}
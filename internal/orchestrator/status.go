@@ -0,0 +1,59 @@
+package orchestrator
+
+import (
+	"ludwig/internal/config"
+	"ludwig/internal/orchestrator/clients"
+	"ludwig/internal/storage"
+	"ludwig/internal/types/task"
+)
+
+// StatusReport is a point-in-time summary of the orchestrator's health
+type StatusReport struct {
+	Running     bool
+	Counts      map[task.Status]int // Tasks on the board per status
+	ActiveTasks []task.Task         // Tasks being processed right now
+	Provider    string
+	Model       string
+	Available   bool             // Whether the provider's CLI or server can be reached
+	Checks      []PreflightCheck // git and provider checks from Preflight
+}
+
+// GetStatus gathers a StatusReport without changing any state
+func GetStatus(taskStore *storage.FileTaskStorage, cfg *config.Config) (StatusReport, error) {
+	tasks, err := taskStore.ListTasksFiltered(storage.ListOptions{})
+	if err != nil {
+		return StatusReport{}, err
+	}
+
+	report := StatusReport{
+		Running:     IsRunning(),
+		Counts:      map[task.Status]int{},
+		ActiveTasks: ActiveTasks(),
+	}
+	for _, t := range tasks {
+		report.Counts[t.Status]++
+	}
+
+	report.Provider, report.Model = providerInfo(cfg)
+	report.Checks, _ = Preflight(cfg)
+	for _, check := range report.Checks {
+		// The provider is the only check that isn't required
+		if !check.Required {
+			report.Available = check.OK
+		}
+	}
+	return report, nil
+}
+
+// providerInfo returns the configured provider and model names, applying the same
+// defaults the clients do
+func providerInfo(cfg *config.Config) (string, string) {
+	switch client := NewAIClient(cfg).(type) {
+	case *clients.OllamaClient:
+		return "ollama", client.Model
+	case *clients.CopilotClient:
+		return "copilot", client.Model
+	default:
+		return "gemini", "auto (model fallback chain)"
+	}
+}
@@ -0,0 +1,116 @@
+package orchestrator
+
+import (
+	"fmt"
+
+	"ludwig/internal/config"
+	"ludwig/internal/types/task"
+)
+
+// WHY_AHEAD_SHOWN is how many of the tasks ahead of one in the queue Why lists
+const WHY_AHEAD_SHOWN = 3
+
+// Why explains where t stands with the scheduler: whether the orchestrator will pick
+// it up, what goes before it and why, and anything stopping tasks from running at
+// all. tasks are all the tasks, as the orchestrator sees them, and checks the
+// Preflight results, which may be nil.
+func Why(cfg *config.Config, tasks []*task.Task, t *task.Task, checks []PreflightCheck) []string {
+	active := map[string]bool{}
+	for _, a := range ActiveTasks() {
+		active[a.ID] = true
+	}
+	if active[t.ID] {
+		return []string{"It is running now."}
+	}
+	if t.Archived {
+		return []string{"It is archived, and the orchestrator skips archived tasks. Use 'unarchive' to run it."}
+	}
+	if reason := notRunnableReason(t); reason != "" {
+		return []string{reason}
+	}
+
+	var lines []string
+	policy := POLICY_REVIEW_FIRST
+	if cfg != nil && cfg.SchedulingPolicy != "" {
+		if _, err := SchedulerFor(cfg.SchedulingPolicy); err == nil {
+			policy = cfg.SchedulingPolicy
+		}
+	}
+	var ahead []*task.Task
+	for _, queued := range schedulerFor(cfg)(tasks) {
+		if queued.ID == t.ID {
+			break
+		}
+		if !active[queued.ID] {
+			ahead = append(ahead, queued)
+		}
+	}
+	if len(ahead) == 0 {
+		lines = append(lines, "It is next in line under the "+policy+" scheduling policy.")
+	} else {
+		lines = append(lines, fmt.Sprintf("%d task(s) are ahead of it under the %s scheduling policy:", len(ahead), policy))
+		for _, a := range ahead[:min(len(ahead), WHY_AHEAD_SHOWN)] {
+			lines = append(lines, "  "+a.ShortID()+" "+a.Title()+": "+aheadReason(policy, a, t))
+		}
+		if len(ahead) > WHY_AHEAD_SHOWN {
+			lines = append(lines, fmt.Sprintf("  and %d more", len(ahead)-WHY_AHEAD_SHOWN))
+		}
+	}
+
+	if t.Failures > 0 {
+		lines = append(lines, fmt.Sprintf("Its runs have failed %d time(s); it is retried each time it comes up.", t.Failures))
+	}
+	for _, check := range checks {
+		if !check.OK {
+			lines = append(lines, check.Name+" is not available, so runs will fail. "+check.Guidance)
+		}
+	}
+	switch {
+	case !IsRunning():
+		lines = append(lines, "The orchestrator is stopped, so no tasks are being picked up. Use 'start' to run them.")
+	case len(active) >= MAX_CONCURRENT_TASKS:
+		lines = append(lines, fmt.Sprintf("All %d workers are busy, so it waits for a running task to finish.", MAX_CONCURRENT_TASKS))
+	}
+	return lines
+}
+
+// notRunnableReason explains why the orchestrator won't pick t up at all, or
+// returns "" if it will in its turn
+func notRunnableReason(t *task.Task) string {
+	switch {
+	case isStartable(t) || isResumable(t):
+		return ""
+	case t.Status == task.NeedsReview:
+		question := ""
+		if t.Review != nil {
+			question = ": " + t.Review.Question
+		}
+		return "It is blocked waiting for its review to be answered" + question
+	case t.Status == task.InProgress:
+		return "It is In Progress but isn't being run here: another Ludwig may be running it, or it was interrupted and goes back to run when the orchestrator next starts."
+	case t.Status == task.Completed:
+		return "It is Completed, so it won't run again. Use 'rerun' to run it afresh."
+	case t.Status == task.Failed:
+		reason := ""
+		if t.FailureReason != "" {
+			reason = " (" + t.FailureReason + ")"
+		}
+		return "It Failed" + reason + ", so it won't run again unless moved back to To Do. Use 'rerun' to run it afresh."
+	default:
+		return "It is in " + task.StatusString(*t) + ", which the orchestrator doesn't run. Move it to To Do to run it."
+	}
+}
+
+// aheadReason says why the scheduler puts a before t
+func aheadReason(policy string, a, t *task.Task) string {
+	if policy == POLICY_PRIORITY && a.Priority > t.Priority {
+		return fmt.Sprintf("higher priority (%d, this task has %d)", a.Priority, t.Priority)
+	}
+	if (policy == POLICY_REVIEW_FIRST || policy == POLICY_PRIORITY) && isResumable(a) && !isResumable(t) {
+		return "answered reviews go before Pending tasks"
+	}
+	if policy == POLICY_PENDING_FIRST && isStartable(a) && !isStartable(t) {
+		return "Pending tasks go before answered reviews"
+	}
+	return "added earlier"
+}
@@ -0,0 +1,109 @@
+package orchestrator
+
+import (
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"ludwig/internal/types/task"
+)
+
+// Worktree is an entry of `git worktree list`
+type Worktree struct {
+	Path   string
+	Branch string // Without refs/heads/, "" when the HEAD is detached
+	Head   string // Commit checked out
+}
+
+// WorktreeInfo is a worktree with the task it was created for
+type WorktreeInfo struct {
+	Worktree
+	TaskID string     // Taken from the directory name, "" if Ludwig didn't create the worktree
+	Task   *task.Task // nil if the task no longer exists
+}
+
+// Orphaned reports whether Ludwig created the worktree for a task that has since
+// been deleted, so nothing will ever clean it up
+func (w WorktreeInfo) Orphaned() bool {
+	return w.TaskID != "" && w.Task == nil
+}
+
+// ListWorktrees returns the repo's linked worktrees, leaving out the main one
+func ListWorktrees() ([]Worktree, error) {
+	cmd := exec.Command("git", "worktree", "list", "--porcelain")
+	cmd.Dir = getRepoRoot()
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list worktrees: %w: %s", err, strings.TrimSpace(string(out)))
+	}
+	worktrees := ParseWorktreeList(string(out))
+	if len(worktrees) > 0 {
+		worktrees = worktrees[1:] // git always lists the main worktree first
+	}
+	return worktrees, nil
+}
+
+// ParseWorktreeList parses the output of `git worktree list --porcelain`
+func ParseWorktreeList(output string) []Worktree {
+	var worktrees []Worktree
+	for _, block := range strings.Split(strings.TrimSpace(output), "\n\n") {
+		var w Worktree
+		for _, line := range strings.Split(block, "\n") {
+			key, value, _ := strings.Cut(strings.TrimSpace(line), " ")
+			switch key {
+			case "worktree":
+				w.Path = value
+			case "HEAD":
+				w.Head = value
+			case "branch":
+				w.Branch = strings.TrimPrefix(value, "refs/heads/")
+			}
+		}
+		if w.Path != "" {
+			worktrees = append(worktrees, w)
+		}
+	}
+	return worktrees
+}
+
+// MatchWorktrees finds the task each worktree belongs to. A worktree is Ludwig's
+// when a task records its path or it is directly under base (see WorktreeBase);
+// its directory is named after the task ID. A checkout of a task's branch made
+// elsewhere, e.g. to review it, is the user's.
+func MatchWorktrees(worktrees []Worktree, tasks []*task.Task, base string) []WorktreeInfo {
+	byPath := map[string]*task.Task{}
+	byID := map[string]*task.Task{}
+	for _, t := range tasks {
+		if t.WorktreePath != "" {
+			byPath[filepath.Clean(t.WorktreePath)] = t
+		}
+		byID[t.ID] = t
+	}
+
+	infos := make([]WorktreeInfo, 0, len(worktrees))
+	for _, w := range worktrees {
+		info := WorktreeInfo{Worktree: w}
+		path := filepath.Clean(w.Path)
+		if t, ok := byPath[path]; ok {
+			info.TaskID, info.Task = t.ID, t
+		} else if filepath.Dir(path) == filepath.Clean(base) {
+			info.TaskID = filepath.Base(path)
+			info.Task = byID[info.TaskID]
+		}
+		infos = append(infos, info)
+	}
+	return infos
+}
+
+// WorktreeDirty reports whether the worktree has uncommitted or untracked changes,
+// which removing it would lose
+func WorktreeDirty(worktreePath string) (bool, error) {
+	cmd := exec.Command("git", "status", "--porcelain")
+	cmd.Dir = worktreePath
+	out, err := cmd.Output()
+	if err != nil {
+		return false, fmt.Errorf("failed to check git status: %w", err)
+	}
+	return len(strings.TrimSpace(string(out))) > 0, nil
+}
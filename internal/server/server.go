@@ -0,0 +1,374 @@
+// Package server exposes task storage over HTTP so tasks can be managed from
+// a browser or script while the TUI runs elsewhere.
+package server
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"ludwig/internal/logger"
+	"ludwig/internal/metrics"
+	"ludwig/internal/orchestrator"
+	"ludwig/internal/storage"
+	"ludwig/internal/types/task"
+	"ludwig/internal/utils"
+)
+
+// DEFAULT_ADDR is the address serve listens on when --addr isn't given
+const DEFAULT_ADDR = ":8080"
+
+// TOKEN_ENV is read for the API token when --token isn't given
+const TOKEN_ENV = "LUDWIG_API_TOKEN"
+
+// STREAM_POLL_INTERVAL is how often a streamed response file is checked for new output
+const STREAM_POLL_INTERVAL = 500 * time.Millisecond
+
+// Server serves the task API. Every request must carry "Authorization: Bearer <token>".
+type Server struct {
+	store        *storage.FileTaskStorage
+	token        string
+	pollInterval time.Duration
+}
+
+// New creates a Server backed by store. The token must not be empty.
+func New(store *storage.FileTaskStorage, token string) (*Server, error) {
+	if token == "" {
+		return nil, errors.New("an API token is required (pass --token or set " + TOKEN_ENV + ")")
+	}
+	return &Server{store: store, token: token, pollInterval: STREAM_POLL_INTERVAL}, nil
+}
+
+// SetPollInterval changes how often streams check for new output
+func (s *Server) SetPollInterval(interval time.Duration) {
+	s.pollInterval = interval
+}
+
+// Handler returns the routes wrapped in token authentication
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /tasks", s.listTasks)
+	mux.HandleFunc("POST /tasks", s.createTask)
+	mux.HandleFunc("DELETE /tasks/{id}", s.deleteTask)
+	mux.HandleFunc("POST /tasks/{id}/review", s.answerReview)
+	mux.HandleFunc("GET /tasks/{id}/stream", s.streamResponse)
+	mux.HandleFunc("GET /events", s.streamEvents)
+	mux.HandleFunc("GET /metrics", s.writeMetrics)
+	return s.requireToken(mux)
+}
+
+// ListenAndServe serves the API on addr until the listener fails
+func ListenAndServe(addr, token string, store *storage.FileTaskStorage) error {
+	srv, err := New(store, token)
+	if err != nil {
+		return err
+	}
+	logger.Infof("Serving task API on %s", addr)
+	return http.ListenAndServe(addr, srv.Handler())
+}
+
+func (s *Server) requireToken(next http.Handler) http.Handler {
+	expected := []byte("Bearer " + s.token)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got := []byte(r.Header.Get("Authorization"))
+		if subtle.ConstantTimeCompare(got, expected) != 1 {
+			writeError(w, http.StatusUnauthorized, "missing or invalid token")
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// createTaskRequest is the body accepted by POST /tasks
+type createTaskRequest struct {
+	Name       string   `json:"name"`
+	Priority   int      `json:"priority"`
+	Tags       []string `json:"tags"`
+	SubPath    string   `json:"subPath"`
+	BaseBranch string   `json:"baseBranch"`
+	Model      string   `json:"model"`
+}
+
+// reviewAnswer is the body accepted by POST /tasks/{id}/review
+type reviewAnswer struct {
+	OptionID string `json:"optionId"`
+	Notes    string `json:"notes"`
+}
+
+func (s *Server) listTasks(w http.ResponseWriter, r *http.Request) {
+	tasks, err := s.store.ListTasksFilteredCtx(r.Context(), storage.ListOptions{SortBy: storage.SortByCreated})
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	writeJSON(w, http.StatusOK, tasks)
+}
+
+func (s *Server) createTask(w http.ResponseWriter, r *http.Request) {
+	var req createTaskRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid JSON body: "+err.Error())
+		return
+	}
+	req.Name = strings.TrimSpace(req.Name)
+	if req.Name == "" {
+		writeError(w, http.StatusBadRequest, "name is required")
+		return
+	}
+	subPath, err := task.CleanSubPath(req.SubPath)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	req.BaseBranch = strings.TrimSpace(req.BaseBranch)
+	if req.BaseBranch != "" {
+		if exists, _ := orchestrator.BranchExists(req.BaseBranch); !exists {
+			writeError(w, http.StatusBadRequest, "base branch "+req.BaseBranch+" not found")
+			return
+		}
+	}
+
+	newTask := &task.Task{
+		ID:         uuid.New().String(),
+		Name:       req.Name,
+		Status:     task.Pending,
+		CreatedAt:  time.Now(),
+		Priority:   req.Priority,
+		Tags:       req.Tags,
+		SubPath:    subPath,
+		BaseBranch: req.BaseBranch,
+		Model:      strings.TrimSpace(req.Model),
+	}
+	if err := s.store.AddTaskCtx(r.Context(), newTask); err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	logger.Infof("Task %s added over the API: %s", newTask.ShortID(), newTask.Name)
+	writeJSON(w, http.StatusCreated, newTask)
+}
+
+func (s *Server) deleteTask(w http.ResponseWriter, r *http.Request) {
+	t, ok := s.findTask(w, r)
+	if !ok {
+		return
+	}
+	if err := s.store.DeleteTaskCtx(r.Context(), t.ID); err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) answerReview(w http.ResponseWriter, r *http.Request) {
+	t, ok := s.findTask(w, r)
+	if !ok {
+		return
+	}
+	if t.Status != task.NeedsReview || t.Review == nil {
+		writeError(w, http.StatusConflict, "task is not waiting for review")
+		return
+	}
+
+	var answer reviewAnswer
+	if err := json.NewDecoder(r.Body).Decode(&answer); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid JSON body: "+err.Error())
+		return
+	}
+	var chosen *task.ReviewOption
+	for i, opt := range t.Review.Options {
+		if opt.ID == answer.OptionID {
+			chosen = &t.Review.Options[i]
+			break
+		}
+	}
+	if chosen == nil {
+		writeError(w, http.StatusBadRequest, fmt.Sprintf("unknown option %q", answer.OptionID))
+		return
+	}
+
+	// The orchestrator resumes NeedsReview tasks once a response is set
+	t.ReviewResponse = &task.ReviewResponse{
+		ChosenOptionID: chosen.ID,
+		ChosenLabel:    chosen.Label,
+		UserNotes:      answer.Notes,
+		RespondedAt:    time.Now(),
+	}
+	if err := s.store.UpdateTaskCtx(r.Context(), t); err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	writeJSON(w, http.StatusOK, t)
+}
+
+// streamResponse sends a task's latest response file as server-sent events,
+// following it while the task is in progress. It ends with a "done" event. New
+// output from a run in this process is sent straight away; otherwise the file is
+// polled.
+func (s *Server) streamResponse(w http.ResponseWriter, r *http.Request) {
+	t, ok := s.findTask(w, r)
+	if !ok {
+		return
+	}
+	responseFile, err := storage.ResolveResponseFile(t.ID, t.ResponseFile)
+	if t.ResponseFile == "" || err != nil {
+		writeError(w, http.StatusNotFound, "task has no output yet")
+		return
+	}
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		writeError(w, http.StatusInternalServerError, "streaming is not supported")
+		return
+	}
+
+	// Subscribe before the first read so no output slips between the two
+	output, unsubscribe := orchestrator.SubscribeOutput(t.ID)
+	defer unsubscribe()
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.WriteHeader(http.StatusOK)
+
+	var offset int64
+	for {
+		// Check the task before reading so output written just before it
+		// finished is still picked up by the read below
+		current, err := s.store.GetTaskCtx(r.Context(), t.ID)
+		finished := err != nil || current.Status != task.InProgress || current.ResponseFile != t.ResponseFile
+
+		content, next, err := storage.ReadResponseFrom(responseFile, offset)
+		if err != nil {
+			writeEvent(w, "error", err.Error())
+			flusher.Flush()
+			return
+		}
+		offset = next
+		if content != "" {
+			writeEvent(w, "", content)
+			flusher.Flush()
+		}
+		if finished {
+			writeEvent(w, "done", "")
+			flusher.Flush()
+			return
+		}
+
+		// Output is still read from the file, as a slow subscriber misses chunks
+		select {
+		case <-r.Context().Done():
+			return
+		case <-output:
+			for len(output) > 0 {
+				<-output // One read picks up the whole burst
+			}
+		case <-time.After(s.pollInterval):
+		}
+	}
+}
+
+// streamEvents sends orchestrator task transitions as server-sent events until
+// the client disconnects. Only the orchestrator running in this process is seen.
+func (s *Server) streamEvents(w http.ResponseWriter, r *http.Request) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		writeError(w, http.StatusInternalServerError, "streaming is not supported")
+		return
+	}
+	events, unsubscribe := orchestrator.Subscribe()
+	defer unsubscribe()
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case event := <-events:
+			data, err := json.Marshal(event)
+			if err != nil {
+				continue
+			}
+			writeEvent(w, string(event.Type), string(data))
+			flusher.Flush()
+		}
+	}
+}
+
+// writeMetrics serves Prometheus text-format metrics: gauges computed from the
+// stored tasks plus the orchestrator's counters and histograms
+func (s *Server) writeMetrics(w http.ResponseWriter, r *http.Request) {
+	tasks, err := s.store.ListTasksCtx(r.Context())
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	stats := task.ComputeStats(utils.PointerSliceToValueSlice(tasks), time.Now())
+	byStatus := make(map[string]float64, len(stats.ByStatus))
+	for status, count := range stats.ByStatus {
+		label := strings.ReplaceAll(strings.ToLower(task.StatusString(task.Task{Status: status})), " ", "_")
+		byStatus[label] = float64(count)
+	}
+
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4")
+	metrics.WriteGaugeVec(w, "ludwig_tasks", "Tasks in storage by status, including archived tasks.", "status", byStatus)
+	metrics.WriteGauge(w, "ludwig_task_duration_average_seconds", "Mean time from start to completion of completed tasks.", stats.AverageCompletion.Seconds())
+	metrics.WriteText(w)
+}
+
+// findTask looks up the {id} path value, accepting a full ID or a short ID.
+// It writes a 404 and returns false if no single task matches.
+func (s *Server) findTask(w http.ResponseWriter, r *http.Request) (*task.Task, bool) {
+	id := r.PathValue("id")
+	if t, err := s.store.GetTaskCtx(r.Context(), id); err == nil {
+		return t, true
+	}
+	tasks, err := s.store.ListTasksFilteredCtx(r.Context(), storage.ListOptions{Archived: storage.IncludeArchived})
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return nil, false
+	}
+	var match *task.Task
+	for _, t := range tasks {
+		if t.ShortID() == id {
+			if match != nil {
+				writeError(w, http.StatusNotFound, "ambiguous task id "+id)
+				return nil, false
+			}
+			match = t
+		}
+	}
+	if match == nil {
+		writeError(w, http.StatusNotFound, "task not found")
+		return nil, false
+	}
+	return match, true
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, map[string]string{"error": message})
+}
+
+// writeEvent writes one server-sent event, prefixing every line of data
+func writeEvent(w http.ResponseWriter, event, data string) {
+	if event != "" {
+		fmt.Fprintf(w, "event: %s\n", event)
+	}
+	for _, line := range strings.Split(data, "\n") {
+		fmt.Fprintf(w, "data: %s\n", line)
+	}
+	fmt.Fprint(w, "\n")
+}
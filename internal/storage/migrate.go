@@ -0,0 +1,165 @@
+package storage
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"ludwig/internal/config"
+)
+
+// ErrStorageConflict is returned by Migrate when the new directory already holds
+// a different copy of one of the files being moved
+var ErrStorageConflict = errors.New("the new directory already holds different data")
+
+// Migrate moves tasks.json, its backup and every response file into newDir and
+// points the storageDir option at it, reporting whether anything had to move.
+// Files are copied and checked before any original is removed, so a migration
+// that is interrupted can simply be run again. Relative paths are within the
+// project and are saved as given. config.json stays in .ludwig to say where the
+// data went.
+func (s *FileTaskStorage) Migrate(newDir string) (bool, error) {
+	cwd, err := os.Getwd()
+	if err != nil {
+		return false, fmt.Errorf("failed to get current working directory: %w", err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	oldDir := filepath.Dir(s.filePath)
+	dest := resolveDir(cwd, newDir)
+	if dest == oldDir {
+		return false, nil
+	}
+
+	files, err := dataFiles(oldDir)
+	if err != nil {
+		return false, err
+	}
+	for _, file := range files {
+		if err := copyVerified(filepath.Join(oldDir, file), filepath.Join(dest, file)); err != nil {
+			return false, err
+		}
+	}
+
+	// Point at the copies before removing anything, so data is never only in a
+	// directory the config doesn't name
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		return false, fmt.Errorf("failed to load config: %w", err)
+	}
+	if cfg == nil {
+		cfg = &config.Config{}
+	}
+	cfg.StorageDir = filepath.Clean(newDir)
+	if err := config.SaveConfig(cfg); err != nil {
+		return false, fmt.Errorf("failed to save config: %w", err)
+	}
+	s.filePath = filepath.Join(dest, "tasks.json")
+	if _, err := refreshLudwigDirPath(); err != nil {
+		return false, err
+	}
+
+	for _, file := range files {
+		if err := os.Remove(filepath.Join(oldDir, file)); err != nil {
+			return true, fmt.Errorf("copied everything, but could not remove the original %s: %w", file, err)
+		}
+	}
+	_ = os.Remove(filepath.Join(oldDir, "responses")) // Only succeeds once it is empty
+	return true, nil
+}
+
+// dataFiles lists the files Migrate moves, relative to dir
+func dataFiles(dir string) ([]string, error) {
+	var files []string
+	for _, name := range []string{"tasks.json", "tasks.json" + BACKUP_SUFFIX} {
+		if _, err := os.Stat(filepath.Join(dir, name)); err == nil {
+			files = append(files, name)
+		} else if !errors.Is(err, os.ErrNotExist) {
+			return nil, err
+		}
+	}
+
+	entries, err := os.ReadDir(filepath.Join(dir, "responses"))
+	if errors.Is(err, os.ErrNotExist) {
+		return files, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read responses directory: %w", err)
+	}
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			files = append(files, filepath.Join("responses", entry.Name()))
+		}
+	}
+	return files, nil
+}
+
+// copyVerified copies src to dst and checks the copy matches. A dst left by an
+// earlier, interrupted migration is kept if it already matches.
+func copyVerified(src, dst string) error {
+	srcHash, err := hashFile(src)
+	if err != nil {
+		return err
+	}
+	if dstHash, err := hashFile(dst); err == nil {
+		if bytes.Equal(dstHash, srcHash) {
+			return nil
+		}
+		return fmt.Errorf("%w: %s", ErrStorageConflict, dst)
+	} else if !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(dst), 0755); err != nil {
+		return err
+	}
+	// Copy under a temporary name so an interrupted copy never looks finished
+	tmp := dst + ".migrating"
+	if err := copyFile(src, tmp); err != nil {
+		os.Remove(tmp)
+		return err
+	}
+	tmpHash, err := hashFile(tmp)
+	if err != nil || !bytes.Equal(tmpHash, srcHash) {
+		os.Remove(tmp)
+		return fmt.Errorf("copy of %s does not match the original", src)
+	}
+	return os.Rename(tmp, dst)
+}
+
+// copyFile writes the contents of src to dst, replacing dst
+func copyFile(src, dst string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+	out, err := os.Create(dst)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		return err
+	}
+	return out.Close()
+}
+
+// hashFile returns the SHA-256 of a file's contents
+func hashFile(path string) ([]byte, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	hash := sha256.New()
+	if _, err := io.Copy(hash, file); err != nil {
+		return nil, err
+	}
+	return hash.Sum(nil), nil
+}
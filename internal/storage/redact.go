@@ -0,0 +1,89 @@
+package storage
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// REDACTED replaces every secret the Redactor finds
+const REDACTED = "[REDACTED]"
+
+// DEFAULT_SECRET_PATTERNS match common secret shapes. When a pattern has a capture
+// group only the group is masked, so "API_KEY=abc" keeps its "API_KEY=" prefix.
+var DEFAULT_SECRET_PATTERNS = []string{
+	`AKIA[0-9A-Z]{16}`,                        // AWS access key ID
+	`gh[pousr]_[A-Za-z0-9]{36,}`,              // GitHub tokens
+	`github_pat_[A-Za-z0-9_]{22,}`,            // GitHub fine-grained tokens
+	`sk-[A-Za-z0-9_-]{20,}`,                   // OpenAI / Anthropic style API keys
+	`AIza[0-9A-Za-z_-]{35}`,                   // Google API key
+	`xox[abprs]-[A-Za-z0-9-]{10,}`,            // Slack tokens
+	`(?i)bearer\s+([A-Za-z0-9._~+/-]{20,}=*)`, // Authorization headers
+	// .env style assignments, e.g. DB_PASSWORD=hunter22 or "apiKey": "..."
+	`(?i)[A-Z0-9_]*(?:SECRET|TOKEN|PASSWORD|PASSWD|API_?KEY|PRIVATE_KEY)[A-Z0-9_]*"?\s*[=:]\s*["']?([^\s"',]{8,})`,
+}
+
+// Redactor masks secrets in text before it is written to disk
+type Redactor struct {
+	patterns []*regexp.Regexp
+}
+
+// NewRedactor compiles DEFAULT_SECRET_PATTERNS plus any user-supplied patterns
+func NewRedactor(extra []string) (*Redactor, error) {
+	r := &Redactor{}
+	for _, pattern := range append(append([]string{}, DEFAULT_SECRET_PATTERNS...), extra...) {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid redaction pattern %q: %w", pattern, err)
+		}
+		r.patterns = append(r.patterns, re)
+	}
+	return r, nil
+}
+
+// MIN_SECRET_VALUE_LENGTH is the shortest value AddValues masks; shorter ones would
+// mask ordinary text, like every "1" for a value of 1
+const MIN_SECRET_VALUE_LENGTH = 4
+
+// AddValues masks each of values wherever it appears, on top of the patterns. Values
+// shorter than MIN_SECRET_VALUE_LENGTH are ignored.
+func (r *Redactor) AddValues(values ...string) {
+	for _, value := range values {
+		if len(value) >= MIN_SECRET_VALUE_LENGTH {
+			r.patterns = append(r.patterns, regexp.MustCompile(regexp.QuoteMeta(value)))
+		}
+	}
+}
+
+// Redact returns text with every match replaced by REDACTED
+func (r *Redactor) Redact(text string) string {
+	if r == nil {
+		return text
+	}
+	for _, re := range r.patterns {
+		text = redactMatches(re, text)
+	}
+	return text
+}
+
+// redactMatches masks each match of re, or just its first capture group if it has one
+func redactMatches(re *regexp.Regexp, text string) string {
+	matches := re.FindAllStringSubmatchIndex(text, -1)
+	if matches == nil {
+		return text
+	}
+
+	var b strings.Builder
+	last := 0
+	for _, m := range matches {
+		start, end := m[0], m[1]
+		if len(m) >= 4 && m[2] >= 0 {
+			start, end = m[2], m[3]
+		}
+		b.WriteString(text[last:start])
+		b.WriteString(REDACTED)
+		last = end
+	}
+	b.WriteString(text[last:])
+	return b.String()
+}
@@ -1,31 +1,143 @@
 package storage
 
 import (
+	"bufio"
+	"bytes"
+	"errors"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
+	"sort"
+	"strings"
 	"sync"
 	"time"
+	"unicode/utf8"
+
+	"ludwig/internal/config"
+	"ludwig/internal/logger"
 )
 
 const ludwigDir = ".ludwig"
 
-// getLudwigDirPath returns the path to the .ludwig directory within the current working directory.
+// responseTimestampLayout is the timestamp format used in response filenames
+const responseTimestampLayout = "20060102-150405"
+
+// dataDirCache holds the data directory as last resolved for a working directory,
+// so path lookups don't re-read config.json every time
+var dataDirCache struct {
+	sync.Mutex
+	cwd string
+	dir string
+}
+
+// getLudwigDirPath returns the directory tasks and responses are kept in: the
+// configured storageDir, or the .ludwig directory within the current working directory.
 func getLudwigDirPath() (string, error) {
 	cwd, err := os.Getwd()
 	if err != nil {
 		return "", fmt.Errorf("failed to get current working directory: %w", err)
 	}
-	ludwigPath := filepath.Join(cwd, ludwigDir)
-	return ludwigPath, nil
+	dataDirCache.Lock()
+	defer dataDirCache.Unlock()
+	if dataDirCache.cwd != cwd {
+		dataDirCache.cwd, dataDirCache.dir = cwd, resolveDataDir(cwd)
+	}
+	return dataDirCache.dir, nil
+}
+
+// refreshLudwigDirPath re-reads the config for where data is kept, for when the
+// storage is opened or storageDir has just changed
+func refreshLudwigDirPath() (string, error) {
+	dataDirCache.Lock()
+	dataDirCache.cwd = ""
+	dataDirCache.Unlock()
+	return getLudwigDirPath()
 }
 
+// resolveDataDir works out the data directory for the project at cwd. A config
+// that can't be read is reported and ignored, so it doesn't stop ludwig starting.
+func resolveDataDir(cwd string) string {
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		logger.Warnf("failed to load config, keeping data in %s: %v", ludwigDir, err)
+	}
+	if cfg != nil && cfg.StorageDir != "" {
+		return resolveDir(cwd, cfg.StorageDir)
+	}
+	return filepath.Join(cwd, ludwigDir)
+}
+
+// resolveDir makes dir absolute, taking relative paths to be within the project at cwd
+func resolveDir(cwd, dir string) string {
+	if filepath.IsAbs(dir) {
+		return filepath.Clean(dir)
+	}
+	return filepath.Join(cwd, dir)
+}
+
+// DataDir returns the directory tasks.json and responses are kept in
+func DataDir() (string, error) {
+	return getLudwigDirPath()
+}
+
+// ResponsePath returns the full path of a response file from its path relative
+// to the data directory, as stored in tasks.json
+func ResponsePath(relativePath string) (string, error) {
+	ludwigPath, err := getLudwigDirPath()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(ludwigPath, relativePath), nil
+}
+
+// FlushMode controls how eagerly a ResponseWriter pushes data to disk
+type FlushMode int
+
+const (
+	// FlushBuffered buffers writes and flushes on newline or every flushInterval (default)
+	FlushBuffered FlushMode = iota
+	// FlushSyncEveryWrite writes and fsyncs every chunk (slow, but durable)
+	FlushSyncEveryWrite
+)
+
+// flushInterval is the longest buffered data waits before being flushed
+const flushInterval = time.Second
+
+// maxPendingLine caps how much of an unfinished line is held back for redaction
+const maxPendingLine = 4096
+
+// ErrResponseTooLarge is returned by WriteChunk once a response passes its size limit
+var ErrResponseTooLarge = errors.New("response exceeded its size limit")
+
 // ResponseWriter streams AI responses to a file
 type ResponseWriter struct {
-	mu       sync.Mutex
-	filePath string
-	file     *os.File
-	taskID   string
+	mu        sync.Mutex
+	filePath  string
+	file      *os.File
+	buf       *bufio.Writer
+	mode      FlushMode
+	lastFlush time.Time
+	taskID    string
+	redactor  *Redactor
+	pending   string     // Partial line held back until it can be redacted whole
+	written   int64      // Response bytes written by this writer, excluding header and markers
+	maxBytes  int64      // Size limit for written; 0 for none
+	truncated bool       // Set once the limit was hit; later writes are dropped
+	onLimit   func()     // Called once when the limit is hit
+	tee       *TeeWriter // Copies what reaches the file to a mirror; nil without one
+}
+
+// newResponseWriter wraps an open response file in a buffered writer
+func newResponseWriter(file *os.File, filePath, taskID string) *ResponseWriter {
+	return &ResponseWriter{
+		filePath:  filePath,
+		file:      file,
+		buf:       bufio.NewWriter(file),
+		mode:      FlushBuffered,
+		lastFlush: time.Now(),
+		taskID:    taskID,
+	}
 }
 
 // NewResponseWriter creates a new response writer for a task
@@ -43,7 +155,7 @@ func NewResponseWriter(taskID string) (*ResponseWriter, string, error) {
 	}
 
 	// Create filename with timestamp to ensure uniqueness
-	timestamp := time.Now().Format("20060102-150405")
+	timestamp := time.Now().Format(responseTimestampLayout)
 	filename := fmt.Sprintf("%s-%s.md", taskID, timestamp)
 	filePath := filepath.Join(responseDir, filename)
 
@@ -60,18 +172,136 @@ func NewResponseWriter(taskID string) (*ResponseWriter, string, error) {
 		return nil, "", err
 	}
 
-	rw := &ResponseWriter{
-		filePath: filePath,
-		file:     file,
-		taskID:   taskID,
-	}
+	rw := newResponseWriter(file, filePath, taskID)
 
 	// Return relative path for storage
 	relativePath := filepath.Join("responses", filename) // This relative path is relative to .ludwig
 	return rw, relativePath, nil
 }
 
+// OpenResponseWriter reopens an existing response file in append mode
+// Used when a task resumes after review so all runs share a single log
+// relativePath is the path stored in tasks.json (relative to .ludwig)
+func OpenResponseWriter(relativePath string) (*ResponseWriter, error) {
+	ludwigPath, err := getLudwigDirPath()
+	if err != nil {
+		return nil, err
+	}
+
+	filePath := filepath.Join(ludwigPath, relativePath)
+	file, err := os.OpenFile(filePath, os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		return nil, fmt.Errorf("failed to reopen response file: %w", err)
+	}
+
+	// Mark where the resumed run starts (avoid "---", which delimits the header/footer)
+	section := fmt.Sprintf("\n\n## Resumed\n\nResumed: %s\n\n", time.Now().Format(time.RFC3339))
+	if _, err := file.WriteString(section); err != nil {
+		file.Close()
+		return nil, err
+	}
+
+	return newResponseWriter(file, filePath, taskIDFromResponseFile(filepath.Base(filePath))), nil
+}
+
+// taskIDFromResponseFile strips the "-<timestamp>.md" suffix from a response filename
+func taskIDFromResponseFile(filename string) string {
+	name := strings.TrimSuffix(filename, ".md")
+	if len(name) > len(responseTimestampLayout)+1 {
+		return name[:len(name)-len(responseTimestampLayout)-1]
+	}
+	return name
+}
+
+// SetFlushMode switches between buffered writes and syncing every chunk
+func (rw *ResponseWriter) SetFlushMode(mode FlushMode) {
+	rw.mu.Lock()
+	defer rw.mu.Unlock()
+	rw.mode = mode
+}
+
+// SetMirror copies everything written to the file from now on to w, as it reaches
+// the file: redacted, cut at the size limit and including the footer. w is written
+// from its own goroutine (see TeeWriter), so it can't slow the response down.
+func (rw *ResponseWriter) SetMirror(w io.Writer) {
+	rw.mu.Lock()
+	defer rw.mu.Unlock()
+	if rw.file == nil {
+		return
+	}
+	// Whatever is buffered was written before the mirror was set
+	_ = rw.flushLocked()
+	if rw.tee != nil {
+		rw.tee.Close()
+	}
+	rw.tee = NewTeeWriter(rw.file, w)
+	rw.buf = bufio.NewWriter(rw.tee)
+}
+
+// SetRedactor masks secrets in everything written from now on. Output is then
+// redacted a line at a time so a secret split across chunks is still caught.
+func (rw *ResponseWriter) SetRedactor(r *Redactor) {
+	rw.mu.Lock()
+	defer rw.mu.Unlock()
+	rw.redactor = r
+}
+
+// SetSizeLimit caps how many bytes of response this writer accepts. The write that
+// passes the limit is cut short, a truncation marker is appended, onExceeded is
+// called (if not nil) and ErrResponseTooLarge is returned, as it is for every
+// write after. A limit of 0 or less removes the cap.
+func (rw *ResponseWriter) SetSizeLimit(maxBytes int64, onExceeded func()) {
+	rw.mu.Lock()
+	defer rw.mu.Unlock()
+	rw.maxBytes = max(maxBytes, 0)
+	rw.onLimit = onExceeded
+}
+
+// limitLocked cuts chunk to what fits under the size limit, reporting whether it
+// had to (caller holds rw.mu)
+func (rw *ResponseWriter) limitLocked(chunk string) (string, bool) {
+	if rw.maxBytes == 0 || rw.written+int64(len(chunk)) <= rw.maxBytes {
+		rw.written += int64(len(chunk))
+		return chunk, false
+	}
+	// Don't split a multi-byte character
+	end := int(rw.maxBytes - rw.written)
+	for end > 0 && !utf8.RuneStart(chunk[end]) {
+		end--
+	}
+	rw.written += int64(end)
+	return chunk[:end] + fmt.Sprintf("\n\n[truncated: exceeded %d bytes]\n", rw.maxBytes), true
+}
+
+// redactLocked returns the complete lines of pending+chunk, redacted, keeping any
+// trailing partial line for later (caller holds rw.mu)
+func (rw *ResponseWriter) redactLocked(chunk string) string {
+	rw.pending += chunk
+	end := strings.LastIndex(rw.pending, "\n") + 1
+	if end == 0 && len(rw.pending) < maxPendingLine {
+		return ""
+	}
+	if end == 0 {
+		end = len(rw.pending)
+	}
+	out := rw.redactor.Redact(rw.pending[:end])
+	rw.pending = rw.pending[end:]
+	return out
+}
+
+// drainPendingLocked writes out any held back partial line (caller holds rw.mu)
+func (rw *ResponseWriter) drainPendingLocked() error {
+	if rw.pending == "" {
+		return nil
+	}
+	_, err := rw.buf.WriteString(rw.redactor.Redact(rw.pending))
+	rw.pending = ""
+	return err
+}
+
 // WriteChunk writes a chunk of response data (streaming)
+// In buffered mode data reaches the file on newline or after flushInterval;
+// in sync mode every chunk is written and fsynced immediately
 func (rw *ResponseWriter) WriteChunk(chunk string) error {
 	rw.mu.Lock()
 	defer rw.mu.Unlock()
@@ -79,15 +309,36 @@ func (rw *ResponseWriter) WriteChunk(chunk string) error {
 	if rw.file == nil {
 		return fmt.Errorf("response writer for task %s is closed", rw.taskID)
 	}
+	if rw.truncated {
+		return ErrResponseTooLarge
+	}
 
-	_, err := rw.file.WriteString(chunk)
-	if err != nil {
+	if rw.redactor != nil {
+		chunk = rw.redactLocked(chunk)
+	}
+	chunk, truncated := rw.limitLocked(chunk)
+	if _, err := rw.buf.WriteString(chunk); err != nil {
 		return err
 	}
+	if truncated {
+		rw.truncated = true
+		rw.pending = ""
+		if err := rw.flushLocked(); err != nil {
+			return err
+		}
+		if rw.onLimit != nil {
+			rw.onLimit()
+		}
+		return ErrResponseTooLarge
+	}
 
-	// Flush to ensure data is written
-	if err := rw.file.Sync(); err != nil {
-		return err
+	if rw.mode == FlushSyncEveryWrite {
+		return rw.syncLocked()
+	}
+
+	// Flush complete lines so the viewer sees progress without an fsync per token
+	if strings.Contains(chunk, "\n") || time.Since(rw.lastFlush) >= flushInterval {
+		return rw.flushLocked()
 	}
 
 	return nil
@@ -101,6 +352,42 @@ func (rw *ResponseWriter) Write(p []byte) (n int, err error) {
 	return len(p), nil
 }
 
+// Flush pushes buffered data to the file without forcing it to disk
+func (rw *ResponseWriter) Flush() error {
+	rw.mu.Lock()
+	defer rw.mu.Unlock()
+
+	if rw.file == nil {
+		return nil
+	}
+	return rw.flushLocked()
+}
+
+// Sync flushes buffered data and forces it to disk
+func (rw *ResponseWriter) Sync() error {
+	rw.mu.Lock()
+	defer rw.mu.Unlock()
+
+	if rw.file == nil {
+		return nil
+	}
+	return rw.syncLocked()
+}
+
+// flushLocked flushes the buffer to the file (caller holds rw.mu)
+func (rw *ResponseWriter) flushLocked() error {
+	rw.lastFlush = time.Now()
+	return rw.buf.Flush()
+}
+
+// syncLocked flushes the buffer and fsyncs the file (caller holds rw.mu)
+func (rw *ResponseWriter) syncLocked() error {
+	if err := rw.flushLocked(); err != nil {
+		return err
+	}
+	return rw.file.Sync()
+}
+
 // Close closes the response file
 func (rw *ResponseWriter) Close() error {
 	rw.mu.Lock()
@@ -110,21 +397,56 @@ func (rw *ResponseWriter) Close() error {
 		return nil
 	}
 
+	if err := rw.drainPendingLocked(); err != nil {
+		rw.closeLocked()
+		return err
+	}
+
 	// Write footer
 	footer := fmt.Sprintf("\n\n---\n\nCompleted: %s\n", time.Now().Format(time.RFC3339))
-	if _, err := rw.file.WriteString(footer); err != nil {
-		rw.file.Close()
-		rw.file = nil
+	if _, err := rw.buf.WriteString(footer); err != nil {
+		rw.closeLocked()
 		return err
 	}
 
-	// Ensure footer is synced to disk
-	if err := rw.file.Sync(); err != nil {
-		rw.file.Close()
-		rw.file = nil
+	// Ensure everything, including the footer, is synced to disk
+	if err := rw.syncLocked(); err != nil {
+		rw.closeLocked()
 		return err
 	}
 
+	return rw.closeLocked()
+}
+
+// Suspend closes the response file without writing the Completed footer
+// Use when a task pauses for review; the file is reopened with OpenResponseWriter
+// and the footer is written once the task actually finishes
+func (rw *ResponseWriter) Suspend() error {
+	rw.mu.Lock()
+	defer rw.mu.Unlock()
+
+	if rw.file == nil {
+		return nil
+	}
+
+	if err := rw.drainPendingLocked(); err != nil {
+		rw.closeLocked()
+		return err
+	}
+	if err := rw.syncLocked(); err != nil {
+		rw.closeLocked()
+		return err
+	}
+
+	return rw.closeLocked()
+}
+
+// closeLocked closes the underlying file and marks the writer closed (caller holds rw.mu)
+func (rw *ResponseWriter) closeLocked() error {
+	if rw.tee != nil {
+		rw.tee.Close()
+		rw.tee = nil
+	}
 	err := rw.file.Close()
 	rw.file = nil
 	return err
@@ -152,3 +474,146 @@ func ReadResponse(filePath string) (string, error) {
 
 	return string(content), nil
 }
+
+// ListResponses returns the paths (relative to .ludwig) of every response file
+// recorded for a task, ordered from oldest to most recent run.
+// Returns an empty slice if the task has no responses yet.
+func ListResponses(taskID string) ([]string, error) {
+	ludwigPath, err := getLudwigDirPath()
+	if err != nil {
+		return nil, err
+	}
+
+	responseDir := filepath.Join(ludwigPath, "responses")
+	entries, err := os.ReadDir(responseDir)
+	if os.IsNotExist(err) {
+		return []string{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read responses directory: %w", err)
+	}
+
+	responses := []string{}
+	for _, entry := range entries {
+		if entry.IsDir() || !isResponseFileFor(entry.Name(), taskID) {
+			continue
+		}
+		responses = append(responses, filepath.Join("responses", entry.Name()))
+	}
+
+	// Timestamps are fixed-width, so lexical order is chronological order
+	sort.Strings(responses)
+	return responses, nil
+}
+
+// ResolveResponseFile returns where a task's response file is now, relative to the
+// data directory. stored is the path kept in the task, which may predate a move of
+// the data directory or carry the old "./.ludwig/" prefix; when nothing is found
+// there the task's newest response file is used instead. It returns an error
+// wrapping os.ErrNotExist if the task has no response file at all.
+func ResolveResponseFile(taskID, stored string) (string, error) {
+	ludwigPath, err := getLudwigDirPath()
+	if err != nil {
+		return "", err
+	}
+
+	if stored != "" {
+		relative := filepath.ToSlash(filepath.Clean(stored))
+		relative = strings.TrimPrefix(relative, ludwigDir+"/")
+		if filepath.IsAbs(stored) {
+			// Absolute paths point into wherever the data directory used to be
+			relative = "responses/" + filepath.Base(stored)
+		}
+		relative = filepath.FromSlash(relative)
+		if _, err := os.Stat(filepath.Join(ludwigPath, relative)); err == nil {
+			return relative, nil
+		}
+	}
+
+	responses, err := ListResponses(taskID)
+	if err != nil {
+		return "", err
+	}
+	if len(responses) == 0 {
+		return "", fmt.Errorf("no response file for task %s: %w", taskID, os.ErrNotExist)
+	}
+	return responses[len(responses)-1], nil
+}
+
+// isResponseFileFor checks whether filename is "<taskID>-<timestamp>.md".
+// Matching the full timestamp stops "task-1" from claiming "task-10" files.
+func isResponseFileFor(filename, taskID string) bool {
+	if !strings.HasPrefix(filename, taskID+"-") || !strings.HasSuffix(filename, ".md") {
+		return false
+	}
+	timestamp := strings.TrimSuffix(strings.TrimPrefix(filename, taskID+"-"), ".md")
+	_, err := time.Parse(responseTimestampLayout, timestamp)
+	return err == nil
+}
+
+// ReadResponseTail reads at most the last maxBytes of a response file
+// The result starts on a line boundary so a partial first line is never returned
+// If the file fits within maxBytes (or maxBytes <= 0) the whole file is returned
+func ReadResponseTail(filePath string, maxBytes int64) (string, error) {
+	ludwigPath, err := getLudwigDirPath()
+	if err != nil {
+		return "", err
+	}
+
+	file, err := os.Open(filepath.Join(ludwigPath, filePath))
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+
+	stat, err := file.Stat()
+	if err != nil {
+		return "", err
+	}
+
+	if maxBytes <= 0 || stat.Size() <= maxBytes {
+		content, err := io.ReadAll(file)
+		if err != nil {
+			return "", err
+		}
+		return string(content), nil
+	}
+
+	if _, err := file.Seek(stat.Size()-maxBytes, io.SeekStart); err != nil {
+		return "", err
+	}
+	content := make([]byte, maxBytes)
+	if _, err := io.ReadFull(file, content); err != nil {
+		return "", err
+	}
+
+	// Drop the partial line we seeked into the middle of, unless it's all we have
+	if newline := bytes.IndexByte(content, '\n'); newline != -1 && newline < len(content)-1 {
+		content = content[newline+1:]
+	}
+	return string(content), nil
+}
+
+// ReadResponseFrom reads a response file from offset to its current end
+// Returns the content and the offset to resume from on the next read
+func ReadResponseFrom(filePath string, offset int64) (string, int64, error) {
+	ludwigPath, err := getLudwigDirPath()
+	if err != nil {
+		return "", offset, err
+	}
+
+	file, err := os.Open(filepath.Join(ludwigPath, filePath))
+	if err != nil {
+		return "", offset, err
+	}
+	defer file.Close()
+
+	if _, err := file.Seek(offset, io.SeekStart); err != nil {
+		return "", offset, err
+	}
+	content, err := io.ReadAll(file)
+	if err != nil {
+		return "", offset, err
+	}
+	return string(content), offset + int64(len(content)), nil
+}
@@ -0,0 +1,91 @@
+package storage
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"ludwig/internal/types/task"
+)
+
+// CURRENT_SCHEMA_VERSION is the version written by save. Bump it and append a
+// migration to taskMigrations whenever the stored shape of tasks changes.
+const CURRENT_SCHEMA_VERSION = 2
+
+// taskFile is the envelope tasks.json is stored in.
+// Version 0 files predate the envelope and are a bare map of ID to task.
+type taskFile struct {
+	SchemaVersion int                   `json:"schemaVersion"`
+	Tasks         map[string]*task.Task `json:"tasks"`
+}
+
+// taskMigration upgrades a raw tasks.json document by exactly one version.
+type taskMigration func(data json.RawMessage) (json.RawMessage, error)
+
+// taskMigrations[n] upgrades a version n document to version n+1.
+var taskMigrations = []taskMigration{
+	migrateV0ToV1,
+	migrateV1ToV2,
+}
+
+// migrateV0ToV1 wraps the bare task map in the versioned envelope.
+func migrateV0ToV1(data json.RawMessage) (json.RawMessage, error) {
+	return json.Marshal(struct {
+		SchemaVersion int             `json:"schemaVersion"`
+		Tasks         json.RawMessage `json:"tasks"`
+	}{1, data})
+}
+
+// migrateV1ToV2 marks the document as able to hold custom statuses, which are
+// stored by name. Every version 1 status is a built-in number, which keeps its
+// meaning, so only the version changes; older ludwigs then refuse the file
+// rather than failing on a status name.
+func migrateV1ToV2(data json.RawMessage) (json.RawMessage, error) {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+	fields["schemaVersion"] = json.RawMessage("2")
+	return json.Marshal(fields)
+}
+
+// schemaVersionOf reports which version a raw tasks.json document is in.
+func schemaVersionOf(data json.RawMessage) (int, error) {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return 0, err
+	}
+	raw, ok := fields["schemaVersion"]
+	if !ok {
+		return 0, nil
+	}
+	var version int
+	if err := json.Unmarshal(raw, &version); err != nil {
+		return 0, fmt.Errorf("invalid schemaVersion: %v", err)
+	}
+	return version, nil
+}
+
+// decodeTaskFile migrates data up to CURRENT_SCHEMA_VERSION and decodes the tasks.
+func decodeTaskFile(data json.RawMessage) (map[string]*task.Task, error) {
+	version, err := schemaVersionOf(data)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrCorruptTaskFile, err)
+	}
+	if version > CURRENT_SCHEMA_VERSION {
+		return nil, fmt.Errorf("tasks.json has schema version %d but this ludwig only understands up to %d; please upgrade", version, CURRENT_SCHEMA_VERSION)
+	}
+	for ; version < CURRENT_SCHEMA_VERSION; version++ {
+		if data, err = taskMigrations[version](data); err != nil {
+			return nil, fmt.Errorf("%w: migrating from schema version %d: %v", ErrCorruptTaskFile, version, err)
+		}
+	}
+
+	var file taskFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrCorruptTaskFile, err)
+	}
+	if file.Tasks == nil {
+		file.Tasks = make(map[string]*task.Task)
+	}
+	return file.Tasks, nil
+}
@@ -1,16 +1,32 @@
 package storage
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
 	"sync"
+	"time"
 
+	"ludwig/internal/logger"
 	"ludwig/internal/types/task"
 )
 
+// TaskStorage is the task store the orchestrator works against. FileTaskStorage
+// is the real implementation; tests and scripts can pass their own.
+type TaskStorage interface {
+	AddTask(task *task.Task) error
+	GetTask(id string) (*task.Task, error)
+	ListTasks() ([]*task.Task, error)
+	UpdateTask(task *task.Task) error
+	DeleteTask(id string) error
+}
+
+var _ TaskStorage = (*FileTaskStorage)(nil)
+
 type FileTaskStorage struct {
 	mu       sync.Mutex
 	filePath string
@@ -20,7 +36,7 @@ type FileTaskStorage struct {
 
 // NewFileTaskStorage initializes storage and loads tasks from file.
 func NewFileTaskStorage() (*FileTaskStorage, error) {
-	ludwigPath, err := getLudwigDirPath()
+	ludwigPath, err := refreshLudwigDirPath()
 	if err != nil {
 		return nil, err
 	}
@@ -34,28 +50,88 @@ func NewFileTaskStorage() (*FileTaskStorage, error) {
 		tasks:    make(map[string]*task.Task),
 	}
 	if err := storage.load(); err != nil && !errors.Is(err, os.ErrNotExist) {
-		return nil, err
+		if !errors.Is(err, ErrCorruptTaskFile) {
+			return nil, err
+		}
+		// Don't let a bad file stop ludwig from starting; keep it aside for the user to inspect
+		backupPath, backupErr := quarantineCorruptFile(path)
+		if backupErr != nil {
+			return nil, fmt.Errorf("%w (and could not back it up: %v)", err, backupErr)
+		}
+		logger.Warnf("%v; moved it to %s and started with no tasks", err, backupPath)
 	}
 	return storage, nil
 }
 
-// load reads tasks from the JSON file into memory.
+// ErrCorruptTaskFile is returned when tasks.json exists but can't be decoded
+var ErrCorruptTaskFile = errors.New("tasks.json is corrupted")
+
+// quarantineCorruptFile renames a corrupt tasks file to tasks.json.corrupt-<timestamp>
+// and returns the new path
+func quarantineCorruptFile(path string) (string, error) {
+	backupPath := path + ".corrupt-" + time.Now().Format("20060102-150405")
+	if err := os.Rename(path, backupPath); err != nil {
+		return "", err
+	}
+	return backupPath, nil
+}
+
+// CheckTaskFile reads the tasks file without loading or quarantining it, returning
+// its path and why it can't be used, wrapping ErrCorruptTaskFile when it's corrupt.
+// A missing file is fine: it is created with the first task.
+func CheckTaskFile() (string, error) {
+	ludwigPath, err := getLudwigDirPath()
+	if err != nil {
+		return "", err
+	}
+	path := filepath.Join(ludwigPath, "tasks.json")
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return path, nil
+	}
+	if err != nil {
+		return path, err
+	}
+	_, err = decodeTaskFile(data)
+	return path, err
+}
+
+// load reads tasks from the JSON file into memory, migrating older schema versions.
 func (s *FileTaskStorage) load() error {
 	s.mu.Lock()
 	defer s.mu.Unlock()
-	file, err := os.Open(s.filePath)
+	data, err := os.ReadFile(s.filePath)
 	if err != nil {
 		return err
 	}
-	defer file.Close()
-	tasks := make(map[string]*task.Task)
-	if err := json.NewDecoder(file).Decode(&tasks); err != nil {
+	tasks, err := decodeTaskFile(data)
+	if err != nil {
 		return err
 	}
 	s.tasks = tasks
 	return nil
 }
 
+// loadCtx reloads tasks unless ctx is already done. A missing file is not an error.
+func (s *FileTaskStorage) loadCtx(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if err := s.load(); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+// saveCtx saves tasks unless ctx was cancelled while the change was being made,
+// so an abandoned call leaves the file untouched.
+func (s *FileTaskStorage) saveCtx(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return s.save()
+}
+
 // save writes the in-memory tasks to the JSON file with file locking for atomicity.
 func (s *FileTaskStorage) save() error {
 	dir := filepath.Dir(s.filePath)
@@ -75,6 +151,10 @@ func (s *FileTaskStorage) save() error {
 	if err := lockFileLock(lockFile); err != nil {
 		return err
 	}
+	if err := backupTasksFile(s.filePath); err != nil {
+		lockFileUnlock(lockFile)
+		return fmt.Errorf("failed to back up tasks.json: %w", err)
+	}
 	file, err := os.Create(s.filePath)
 	if err != nil {
 		return err
@@ -82,11 +162,77 @@ func (s *FileTaskStorage) save() error {
 	defer file.Close()
 	enc := json.NewEncoder(file)
 	enc.SetIndent("", "  ")
-	err = enc.Encode(s.tasks)
+	err = enc.Encode(taskFile{SchemaVersion: CURRENT_SCHEMA_VERSION, Tasks: s.tasks})
 	lockFileUnlock(lockFile)
 	return err
 }
 
+// BACKUP_SUFFIX is appended to tasks.json to name the copy taken before each save
+const BACKUP_SUFFIX = ".bak"
+
+// ErrNoBackup is returned by RestoreBackup when no save has happened yet
+var ErrNoBackup = errors.New("no tasks.json backup to restore")
+
+// backupTasksFile copies the current tasks file to tasks.json.bak so the
+// previous state survives a save that is interrupted or goes wrong.
+func backupTasksFile(path string) error {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path+BACKUP_SUFFIX, data, 0644)
+}
+
+// RestoreBackup swaps tasks.json with tasks.json.bak, so running it twice undoes it.
+// The backup is checked before anything is moved so a bad backup can't replace good data.
+func (s *FileTaskStorage) RestoreBackup() error {
+	backupPath := s.filePath + BACKUP_SUFFIX
+	s.mu.Lock()
+	data, err := os.ReadFile(backupPath)
+	if errors.Is(err, os.ErrNotExist) {
+		s.mu.Unlock()
+		return ErrNoBackup
+	}
+	if err != nil {
+		s.mu.Unlock()
+		return err
+	}
+	if _, err := decodeTaskFile(data); err != nil {
+		s.mu.Unlock()
+		return fmt.Errorf("backup can't be restored: %w", err)
+	}
+
+	swapPath := s.filePath + ".swap"
+	current, err := os.ReadFile(s.filePath)
+	hasCurrent := err == nil
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		s.mu.Unlock()
+		return err
+	}
+	if hasCurrent {
+		if err := os.WriteFile(swapPath, current, 0644); err != nil {
+			s.mu.Unlock()
+			return err
+		}
+	}
+	if err := os.Rename(backupPath, s.filePath); err != nil {
+		os.Remove(swapPath)
+		s.mu.Unlock()
+		return err
+	}
+	if hasCurrent {
+		if err := os.Rename(swapPath, backupPath); err != nil {
+			s.mu.Unlock()
+			return err
+		}
+	}
+	s.mu.Unlock()
+	return s.load()
+}
+
 // lockFileLock acquires an exclusive lock on the file (Unix only)
 func lockFileLock(f *os.File) error {
 	// Use syscall for file locking
@@ -101,19 +247,29 @@ func lockFileUnlock(f *os.File) error {
 
 // AddTask adds a new task to storage and saves it.
 func (s *FileTaskStorage) AddTask(task *task.Task) error {
+	return s.AddTaskCtx(context.Background(), task)
+}
+
+// AddTaskCtx is AddTask, abandoned if ctx is done before the task is written.
+func (s *FileTaskStorage) AddTaskCtx(ctx context.Context, task *task.Task) error {
 	// Reload from disk before adding
-	if err := s.load(); err != nil && !errors.Is(err, os.ErrNotExist) {
+	if err := s.loadCtx(ctx); err != nil {
 		return err
 	}
 	s.mu.Lock()
 	s.tasks[task.ID] = task
 	s.mu.Unlock()
-	return s.save()
+	return s.saveCtx(ctx)
 }
 
 // GetTask retrieves a task by ID.
 func (s *FileTaskStorage) GetTask(id string) (*task.Task, error) {
-	if err := s.load(); err != nil && !errors.Is(err, os.ErrNotExist) {
+	return s.GetTaskCtx(context.Background(), id)
+}
+
+// GetTaskCtx is GetTask, abandoned if ctx is done before the file is read.
+func (s *FileTaskStorage) GetTaskCtx(ctx context.Context, id string) (*task.Task, error) {
+	if err := s.loadCtx(ctx); err != nil {
 		return nil, err
 	}
 	s.mu.Lock()
@@ -127,7 +283,12 @@ func (s *FileTaskStorage) GetTask(id string) (*task.Task, error) {
 
 // ListTasks returns all tasks from storage.
 func (s *FileTaskStorage) ListTasks() ([]*task.Task, error) {
-	if err := s.load(); err != nil && !errors.Is(err, os.ErrNotExist) {
+	return s.ListTasksCtx(context.Background())
+}
+
+// ListTasksCtx is ListTasks, abandoned if ctx is done before the file is read.
+func (s *FileTaskStorage) ListTasksCtx(ctx context.Context) ([]*task.Task, error) {
+	if err := s.loadCtx(ctx); err != nil {
 		return nil, err
 	}
 	s.mu.Lock()
@@ -139,9 +300,121 @@ func (s *FileTaskStorage) ListTasks() ([]*task.Task, error) {
 	return tasks, nil
 }
 
+// SortField selects the order ListTasksFiltered returns tasks in.
+type SortField int
+
+const (
+	SortNone SortField = iota // No ordering guarantee, same as ListTasks
+	SortByCreated
+	SortByPriority
+	SortByName
+)
+
+// ArchivedFilter selects whether ListTasksFiltered returns archived tasks.
+type ArchivedFilter int
+
+const (
+	ExcludeArchived ArchivedFilter = iota // Default: only tasks still on the board
+	IncludeArchived
+	OnlyArchived
+)
+
+// ListOptions controls filtering and ordering for ListTasksFiltered.
+// Zero values mean "no filter" and "no sort", except that archived tasks are
+// excluded unless Archived says otherwise.
+type ListOptions struct {
+	Statuses   []task.Status // Only include tasks in one of these statuses
+	Tag        string        // Only include tasks carrying this tag (case-insensitive)
+	Archived   ArchivedFilter
+	SortBy     SortField
+	Descending bool
+}
+
+// ListTasksFiltered returns the tasks matching opts, sorted as requested.
+// Ties are broken by ID so the order is stable between calls.
+func (s *FileTaskStorage) ListTasksFiltered(opts ListOptions) ([]*task.Task, error) {
+	return s.ListTasksFilteredCtx(context.Background(), opts)
+}
+
+// ListTasksFilteredCtx is ListTasksFiltered, abandoned if ctx is done before the file is read.
+func (s *FileTaskStorage) ListTasksFilteredCtx(ctx context.Context, opts ListOptions) ([]*task.Task, error) {
+	all, err := s.ListTasksCtx(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	tasks := make([]*task.Task, 0, len(all))
+	for _, t := range all {
+		if matchesListOptions(t, opts) {
+			tasks = append(tasks, t)
+		}
+	}
+
+	if opts.SortBy == SortNone {
+		return tasks, nil
+	}
+	sort.Slice(tasks, func(i, j int) bool {
+		a, b := tasks[i], tasks[j]
+		if opts.Descending {
+			a, b = b, a
+		}
+		switch opts.SortBy {
+		case SortByCreated:
+			if !a.CreatedAt.Equal(b.CreatedAt) {
+				return a.CreatedAt.Before(b.CreatedAt)
+			}
+		case SortByPriority:
+			if a.Priority != b.Priority {
+				return a.Priority < b.Priority
+			}
+		case SortByName:
+			if a.Name != b.Name {
+				return a.Name < b.Name
+			}
+		}
+		return a.ID < b.ID
+	})
+	return tasks, nil
+}
+
+// matchesListOptions reports whether t passes the status and tag filters.
+func matchesListOptions(t *task.Task, opts ListOptions) bool {
+	if len(opts.Statuses) > 0 {
+		found := false
+		for _, status := range opts.Statuses {
+			if t.Status == status {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	if opts.Tag != "" && !t.HasTag(opts.Tag) {
+		return false
+	}
+	switch opts.Archived {
+	case ExcludeArchived:
+		if t.Archived {
+			return false
+		}
+	case OnlyArchived:
+		if !t.Archived {
+			return false
+		}
+	}
+	return true
+}
+
 // UpdateTask updates an existing task in storage and saves it.
 func (s *FileTaskStorage) UpdateTask(task *task.Task) error {
-	if err := s.load(); err != nil && !errors.Is(err, os.ErrNotExist) {
+	return s.UpdateTaskCtx(context.Background(), task)
+}
+
+// UpdateTaskCtx is UpdateTask, abandoned if ctx is done before the change is written.
+func (s *FileTaskStorage) UpdateTaskCtx(ctx context.Context, task *task.Task) error {
+	if err := s.loadCtx(ctx); err != nil {
 		return err
 	}
 	s.mu.Lock()
@@ -151,12 +424,17 @@ func (s *FileTaskStorage) UpdateTask(task *task.Task) error {
 	}
 	s.tasks[task.ID] = task
 	s.mu.Unlock()
-	return s.save()
+	return s.saveCtx(ctx)
 }
 
 // DeleteTask removes a task from storage by ID and saves the change.
 func (s *FileTaskStorage) DeleteTask(id string) error {
-	if err := s.load(); err != nil && !errors.Is(err, os.ErrNotExist) {
+	return s.DeleteTaskCtx(context.Background(), id)
+}
+
+// DeleteTaskCtx is DeleteTask, abandoned if ctx is done before the change is written.
+func (s *FileTaskStorage) DeleteTaskCtx(ctx context.Context, id string) error {
+	if err := s.loadCtx(ctx); err != nil {
 		return err
 	}
 	s.mu.Lock()
@@ -166,5 +444,5 @@ func (s *FileTaskStorage) DeleteTask(id string) error {
 	}
 	delete(s.tasks, id)
 	s.mu.Unlock()
-	return s.save()
+	return s.saveCtx(ctx)
 }
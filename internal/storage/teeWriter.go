@@ -0,0 +1,121 @@
+package storage
+
+import (
+	"io"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// TEE_BUFFER_SIZE is how many writes a TeeWriter's mirror can fall behind by before
+// new writes are dropped for it
+const TEE_BUFFER_SIZE = 256
+
+// TEE_CLOSE_TIMEOUT is how long Close waits for the mirrors to catch up, so a stuck
+// one can't stop the response file from being closed
+const TEE_CLOSE_TIMEOUT = time.Second
+
+// TeeWriter is io.MultiWriter for live output: the first writer (the response file)
+// is written directly and its result returned, while the rest are mirrors written
+// from their own goroutines, so a slow or stuck one can't hold up the file. A mirror
+// that falls TEE_BUFFER_SIZE writes behind misses writes until it catches up, and
+// one that returns an error is skipped from then on.
+type TeeWriter struct {
+	primary io.Writer
+	mirrors []*teeMirror
+	wg      sync.WaitGroup
+
+	mu      sync.Mutex // Guards closed, so nothing is queued once the mirrors stop
+	closed  bool
+	dropped atomic.Int64
+}
+
+type teeMirror struct {
+	w     io.Writer
+	queue chan []byte
+}
+
+// NewTeeWriter returns a writer that writes to writers[0] and mirrors every write to
+// the rest. With no writers, writes are discarded.
+func NewTeeWriter(writers ...io.Writer) *TeeWriter {
+	tee := &TeeWriter{primary: io.Discard}
+	if len(writers) == 0 {
+		return tee
+	}
+	tee.primary = writers[0]
+	for _, w := range writers[1:] {
+		mirror := &teeMirror{w: w, queue: make(chan []byte, TEE_BUFFER_SIZE)}
+		tee.mirrors = append(tee.mirrors, mirror)
+		tee.wg.Add(1)
+		go tee.run(mirror)
+	}
+	return tee
+}
+
+// Write writes p to the first writer, then queues a copy for each mirror without waiting
+func (t *TeeWriter) Write(p []byte) (int, error) {
+	n, err := t.primary.Write(p)
+	if n == 0 || len(t.mirrors) == 0 {
+		return n, err
+	}
+
+	// Mirrors only see what reached the first writer
+	chunk := append([]byte(nil), p[:n]...)
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.closed {
+		return n, err
+	}
+	for _, mirror := range t.mirrors {
+		select {
+		case mirror.queue <- chunk:
+		default:
+			t.dropped.Add(1)
+		}
+	}
+	return n, err
+}
+
+// Dropped returns how many writes were dropped across all mirrors for falling behind
+func (t *TeeWriter) Dropped() int64 {
+	return t.dropped.Load()
+}
+
+// Close waits up to TEE_CLOSE_TIMEOUT for the mirrors to finish their queued writes;
+// any still going carry on in the background. Later writes only go to the first
+// writer. None of the writers are closed.
+func (t *TeeWriter) Close() error {
+	t.mu.Lock()
+	if !t.closed {
+		t.closed = true
+		for _, mirror := range t.mirrors {
+			close(mirror.queue)
+		}
+	}
+	t.mu.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		t.wg.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(TEE_CLOSE_TIMEOUT):
+	}
+	return nil
+}
+
+// run writes a mirror's queued chunks until Close
+func (t *TeeWriter) run(mirror *teeMirror) {
+	defer t.wg.Done()
+	failed := false
+	for chunk := range mirror.queue {
+		if failed {
+			continue // Keep draining so Close doesn't wait on a dead mirror
+		}
+		if _, err := mirror.w.Write(chunk); err != nil {
+			failed = true
+		}
+	}
+}
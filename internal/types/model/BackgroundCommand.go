@@ -0,0 +1,112 @@
+package model
+
+import (
+	"time"
+
+	"github.com/charmbracelet/bubbles/spinner"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// BACKGROUND_FRAMES animate the message area while a background command runs
+var BACKGROUND_FRAMES = spinner.Dot.Frames
+
+const backgroundFrameInterval = 100 * time.Millisecond
+
+// backgroundCommand is a command whose action is running off the UI thread
+type backgroundCommand struct {
+	id      int // Tells its messages apart from those of an earlier run
+	name    string
+	frame   int
+	refused string // The last command turned away while this one runs
+	hidden  bool   // Esc was pressed, so its result is dropped once it arrives
+}
+
+// backgroundResultMsg carries a background command's output back to Update
+type backgroundResultMsg struct {
+	id     int
+	output string
+}
+
+// backgroundTickMsg advances the animation of a background command
+type backgroundTickMsg struct {
+	id int
+}
+
+// RunInBackground runs cmd's action on input off the UI thread, so keys are still
+// handled while it works, and shows its output when it finishes. The action gets
+// a nil Model since the UI keeps changing meanwhile. Esc cancels it, but the action
+// can't be interrupted, so its result is dropped instead and other commands are
+// still refused until it returns.
+func (m *Model) RunInBackground(cmd Command, input string) tea.Cmd {
+	m.backgroundRuns++
+	id := m.backgroundRuns
+	m.background = &backgroundCommand{id: id, name: cmd.Text}
+	action := cmd.Action
+	return tea.Batch(
+		func() tea.Msg { return backgroundResultMsg{id: id, output: action(input, nil)} },
+		backgroundTick(id),
+	)
+}
+
+// Background returns the name of the command running in the background, even one
+// cancelled with Esc, or "" if none
+func (m *Model) Background() string {
+	if m.background == nil {
+		return ""
+	}
+	return m.background.name
+}
+
+func backgroundTick(id int) tea.Cmd {
+	return tea.Tick(backgroundFrameInterval, func(time.Time) tea.Msg {
+		return backgroundTickMsg{id: id}
+	})
+}
+
+// updateBackground handles a background command finishing, animating and being
+// cancelled with Esc, reporting whether the message was used
+func (m *Model) updateBackground(msg tea.Msg) (bool, tea.Cmd) {
+	switch msg := msg.(type) {
+	case backgroundResultMsg:
+		if !m.isBackground(msg.id) {
+			return true, nil // Cancelled
+		}
+		if m.background.hidden {
+			m.message = "Cancelled " + m.background.name
+		} else {
+			m.message = msg.output
+		}
+		m.background = nil
+		return true, func() tea.Msg { return refreshMsg{} }
+	case backgroundTickMsg:
+		if !m.isBackground(msg.id) {
+			return true, nil
+		}
+		m.background.frame++
+		return true, backgroundTick(msg.id)
+	case tea.KeyMsg:
+		if m.background != nil && !m.background.hidden && msg.Type == tea.KeyEsc {
+			m.background.hidden = true
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// isBackground reports whether id is the background command still being waited for
+func (m *Model) isBackground(id int) bool {
+	return m.background != nil && m.background.id == id
+}
+
+// backgroundStatus is shown in the message area while a command runs
+func (m *Model) backgroundStatus() string {
+	frame := BACKGROUND_FRAMES[m.background.frame%len(BACKGROUND_FRAMES)]
+	status := frame + "Running " + m.background.name + "... (Esc to cancel)"
+	if m.background.hidden {
+		status = frame + "Cancelling " + m.background.name + ", waiting for it to return..."
+	}
+	if m.background.refused != "" {
+		status += "\n" + m.background.refused + " was not run. Send it again once " + m.background.name + " is done."
+	}
+	return status
+}
@@ -0,0 +1,101 @@
+package model
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// DEFAULT_HISTORY_SIZE is how many submitted commands are remembered
+const DEFAULT_HISTORY_SIZE = 100
+
+// HISTORY_FILE is where history is kept between sessions, relative to the working directory
+const HISTORY_FILE = ".ludwig/history"
+
+// CommandHistory remembers submitted commands for shell-style Up/Down recall.
+// The oldest command is dropped once size is reached.
+type CommandHistory struct {
+	entries []string
+	size    int
+	cursor  int    // Index into entries while browsing, len(entries) when not
+	draft   string // What was typed before browsing started, restored past the newest entry
+}
+
+// NewCommandHistory creates an empty history holding at most size commands
+func NewCommandHistory(size int) *CommandHistory {
+	if size <= 0 {
+		size = DEFAULT_HISTORY_SIZE
+	}
+	return &CommandHistory{size: size}
+}
+
+// Add records a submitted command and stops browsing.
+// Blank commands and immediate repeats aren't recorded.
+func (h *CommandHistory) Add(command string) {
+	command = strings.TrimSpace(command)
+	if command != "" && (len(h.entries) == 0 || h.entries[len(h.entries)-1] != command) {
+		h.entries = append(h.entries, command)
+		if len(h.entries) > h.size {
+			h.entries = h.entries[len(h.entries)-h.size:]
+		}
+	}
+	h.cursor = len(h.entries)
+	h.draft = ""
+}
+
+// Previous steps back to an older command. current is the input's contents,
+// kept as the draft when browsing starts. Returns false at the oldest entry.
+func (h *CommandHistory) Previous(current string) (string, bool) {
+	if h.cursor == 0 {
+		return "", false
+	}
+	if h.cursor == len(h.entries) {
+		h.draft = current
+	}
+	h.cursor--
+	return h.entries[h.cursor], true
+}
+
+// Next steps forward to a newer command, returning the draft after the newest.
+// Returns false if not browsing.
+func (h *CommandHistory) Next() (string, bool) {
+	if h.cursor >= len(h.entries) {
+		return "", false
+	}
+	h.cursor++
+	if h.cursor == len(h.entries) {
+		return h.draft, true
+	}
+	return h.entries[h.cursor], true
+}
+
+// Entries returns a copy of the remembered commands, oldest first
+func (h *CommandHistory) Entries() []string {
+	return append([]string(nil), h.entries...)
+}
+
+// LoadCommandHistory reads history saved by Save, starting empty if there is none
+func LoadCommandHistory(size int) *CommandHistory {
+	h := NewCommandHistory(size)
+	data, err := os.ReadFile(HISTORY_FILE)
+	if err != nil {
+		return h
+	}
+	for _, line := range strings.Split(string(data), "\n") {
+		h.Add(line)
+	}
+	return h
+}
+
+// Save writes the history to HISTORY_FILE, one command per line.
+// Multiline commands are saved as a single line.
+func (h *CommandHistory) Save() error {
+	if err := os.MkdirAll(filepath.Dir(HISTORY_FILE), 0755); err != nil {
+		return err
+	}
+	lines := make([]string, len(h.entries))
+	for i, entry := range h.entries {
+		lines[i] = strings.ReplaceAll(entry, "\n", " ")
+	}
+	return os.WriteFile(HISTORY_FILE, []byte(strings.Join(lines, "\n")+"\n"), 0644)
+}
@@ -1,36 +1,51 @@
 package model
 
 import (
+	"ludwig/internal/config"
 	"ludwig/internal/utils"
 	"ludwig/internal/storage"
 	"ludwig/internal/types/task"
 	"ludwig/internal/orchestrator"
 
+	"errors"
+	"fmt"
 	"strings"
 	"time"
 	"strconv"
 	"os"
+	"os/exec"
+	"path/filepath"
 
 	"github.com/google/uuid"
 	"github.com/charmbracelet/bubbles/table"
+	"github.com/charmbracelet/lipgloss"
 )
 
-func PalleteCommands(taskStore *storage.FileTaskStorage) []Command {
+// builtinCommands are the commands Ludwig ships with, registered before any others
+func builtinCommands(taskStore *storage.FileTaskStorage) []Command {
 	actions := []Command {
 		{
 			Text: "add",
 			Action: func(text string, m *Model) string {
 				parts := strings.Fields(text)
-				if !checkArgumentsCountMin(2, parts, true) {
-					return "Usage: add <task description> - Add a new task. Tasks can be multiple words. No quotation marks needed."
-				}
 
-				// skip the first part which is the command itself
+				// Keep everything after the command word as typed, including newlines
+				name := strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(text), parts[0]))
+				model := ""
+				if parts[1] == "--model" {
+					if len(parts) < 4 {
+						return "Usage: add --model <model> <task description>"
+					}
+					model = parts[2]
+					name = strings.TrimSpace(strings.TrimPrefix(name, parts[1]))
+					name = strings.TrimSpace(strings.TrimPrefix(name, model))
+				}
 				newTask := &task.Task{
-					Name: strings.Join(parts[1:], " "),
+					Name: name,
 					Status: task.Pending,
 					ID: uuid.New().String(),
 					CreatedAt: time.Now(),
+					Model: model,
 				}
 
 				if err := taskStore.AddTask(newTask); err != nil {
@@ -39,84 +54,90 @@ func PalleteCommands(taskStore *storage.FileTaskStorage) []Command {
 				}
 				return "Added new task: " + newTask.Name
 			},
-			Description: "add <task description> - Add a new task. Tasks can be multiple words. No quotation marks needed.",
+			Description: "Add a new task. Tasks can be multiple words or lines (Alt+Enter for a new line). No quotation marks needed. Start with --model to pick the AI model it runs with.",
+			Usage: "[--model name] <task description>",
+			MinArgs: 1,
+			MaxArgs: ANY_ARGS,
 		},
 		{
 			Text: "delete",
-			Description: "delete <task ref> - Delete a task by it's ref, can be seen to the left of the task name on the kanban. Do not include the # symbol.",
+			Description: "Delete a task by it's ref: the ID shown to the left of the task name on the kanban, or the start of its name. Asks y/n first unless --yes is given.",
+			Usage: "<task ref> [--yes]",
+			MinArgs: 1,
+			MaxArgs: 2,
+			TakesTaskRef: true,
 			Action: func(text string, m *Model) string {
 				parts := strings.Fields(text)
-				if !checkArgumentsCount(2, parts) {
-					return "Usage: delete <task ref> - Delete a task by it's ref, can be seen to the left of the task name on the kanban."
-				}
-				taskIndex, err := strconv.Atoi(parts[1])
-				if err != nil {
-					return "Invalid task ref. Must be a number."
+				if len(parts) == 3 && parts[2] != "--yes" {
+					return "Unknown option " + parts[2] + ". The only option is --yes."
 				}
-				
-				tasksPointers, err := taskStore.ListTasks()
+				taskToDelete, err := ResolveTaskRef(taskStore, parts[1], storage.ListOptions{})
 				if err != nil {
-					return "Error retrieving tasks: " + err.Error()
+					return "Invalid task ref: " + err.Error()
 				}
-
-				tasks := utils.PointerSliceToValueSlice(tasksPointers)
-
-				if taskIndex < 0 || taskIndex >= len(tasks) {
-					return "Task ref out of range."
+				deleteTask := func() string {
+					if err := taskStore.DeleteTask(taskToDelete.ID); err != nil {
+						return "Error deleting task: " + err.Error()
+					}
+					return "Deleted task: " + taskToDelete.Name
 				}
-				taskToDelete := tasks[taskIndex]
-				if err := taskStore.DeleteTask(taskToDelete.ID); err != nil {
-					return "Error deleting task: " + err.Error()
+				// Outside the TUI there is nobody to ask
+				if m == nil || len(parts) == 3 {
+					return deleteTask()
 				}
-				return "Deleted task: " + taskToDelete.Name
+				// The task is held by ID, so refs shifting before the answer can't change which is deleted
+				return m.Confirm("Delete task "+taskToDelete.ShortID()+" '"+taskToDelete.Title()+"'?", deleteTask)
 			},
 		},
 		{
 			Text: "start",
 			Action: func(text string, m *Model) string {
-				parts := strings.Fields(text)
-				if !checkArgumentsCount(1, parts) {
-					return "Usage: start method takes no arguments"
+				cfg, err := config.LoadConfig()
+				if err != nil {
+					return "Error loading config: " + err.Error()
 				}
-				orchestrator.Start()
-				return "AI Orchestrator started."
+				checks, err := orchestrator.Preflight(cfg)
+				if err != nil {
+					return "Cannot start the AI Orchestrator: " + err.Error()
+				}
+				if err := orchestrator.Start(); err != nil {
+					return "Cannot start the AI Orchestrator: " + err.Error()
+				}
+				message := "AI Orchestrator started."
+				// Tasks wait rather than fail while the provider is missing, so only warn
+				for _, check := range checks {
+					if !check.OK {
+						message += " Warning: " + check.Name + " is not available. " + check.Guidance + "."
+					}
+				}
+				return message
 			},
-			Description: "start - Start the AI Orchestrator",
+			Description: "Start the AI Orchestrator",
+			// Checking the AI provider can mean waiting on the network
+			Background: true,
 		},
 		{
 			Text: "stop",
 			Action: func(text string, m *Model) string {
-				parts := strings.Fields(text)
-				if len(parts) > 1 {
-					//utils.Println("Usage: stop method takes no arguments")
-					return "Usage: stop method takes no arguments"
-				}
 				//utils.Println("Stopping AI Orchestrator...")
 				orchestrator.Stop()
 				return "AI Orchestrator stopped."
 			},
-			Description: "stop - Stop the AI Orchestrator",
+			Description: "Stop the AI Orchestrator",
+			// Stop interrupts running tasks, committing their work so far and requeuing them
+			Background: true,
 		},
 		{
 			Text: "clear",
-			Description: "clear - Clear the command line so that only the kanban board is visible",
+			Description: "Clear the command line so that only the kanban board is visible",
 			Action: func(text string, m *Model) string {
-				parts := strings.Fields(text)
-				if !checkArgumentsCount(1, parts) {
-					return  "Usage: clear method takes no arguments"
-				}
 				return ""
 			},
 		},
 		{
 			Text: "exit",
-			Description: "exit - Exit the CLI",
+			Description: "Exit the CLI",
 			Action: func(text string, m *Model) string {
-				parts := strings.Fields(text)
-				if !checkArgumentsCount(1, parts) {
-					return "Usage: exit method takes no arguments"
-				}
-
 				//utils.Println("Exiting CLI...")
 				os.Exit(0)
 				return ""
@@ -124,69 +145,830 @@ func PalleteCommands(taskStore *storage.FileTaskStorage) []Command {
 		},
 		{
 			Text: "view",
-			Description: "view <task ref> - View the streamed output log of a task by it's ref. Do not include the # symbol.",
+			Description: "View the streamed output log of a task by it's ref. Shows the latest run unless a run number (1 = oldest) is given.",
+			Usage: "<task ref> [run]",
+			MinArgs: 1,
+			MaxArgs: 2,
+			TakesTaskRef: true,
 			Action: func(text string, m *Model) string {
 				parts := strings.Fields(text)
-				if !checkArgumentsCount(2, parts) {
-					return "Usage: view command takes 1 argument: <task ref>"
-				}
 
-				taskIndex, err := strconv.Atoi(parts[1])
+				taskRef, err := ResolveTaskRef(taskStore, parts[1], storage.ListOptions{})
 				if err != nil {
-					return "Invalid task ref. Must be a number."
+					return "Invalid task ref: " + err.Error()
 				}
-				
-				tasksPointers, err := taskStore.ListTasks()
+				taskToView := *taskRef
+
+				responses, err := responseFiles(taskToView)
 				if err != nil {
-					return "Error retrieving tasks: " + err.Error()
+					return "Error listing responses: " + err.Error()
 				}
-
-				tasks := utils.PointerSliceToValueSlice(tasksPointers)
-
-				if taskIndex < 0 || taskIndex >= len(tasks) {
-					return "Task ref out of range."
+				if len(responses) == 0 {
+					return "No output recorded for task: " + taskToView.Name
 				}
-				taskToView := tasks[taskIndex]
-				filePath := "./.ludwig/" + taskToView.ResponseFile
 
+				responseFile := responses[len(responses)-1]
+				if len(parts) == 3 {
+					run, err := strconv.Atoi(parts[2])
+					if err != nil || run < 1 || run > len(responses) {
+						return "Invalid run number. Task has " + strconv.Itoa(len(responses)) + " run(s)."
+					}
+					responseFile = responses[run-1]
+				}
 				m.viewingViewport = true
-				m.taskViewport = *m.taskViewport.SetViewingTask(&taskToView, filePath)
-				m.taskViewport.ViewportUpdateLoop()
+				m.taskViewport = *m.taskViewport.SetViewingTask(&taskToView, responseFile)
+				m.afterCommand = m.taskViewport.ViewportUpdateLoop()
 
 				return ""
 			},
 		},
 	}
-	return append(actions, Command {
-		Text: "help",
-		Description: "help - Show this help message",
+	actions = append(actions, Command {
+		Text: "move",
+		Description: "Manually set a task's status (Pending, InProgress, NeedsReview, Completed, Failed or one of the customStatuses).",
+		Usage: "<task ref> <status>",
+		MinArgs: 2,
+		MaxArgs: ANY_ARGS,
+		TakesTaskRef: true,
+		Action: func(text string, m *Model) string {
+			parts := strings.Fields(text)
+			// Allow multi-word column names such as "In Review"
+			status, err := task.ParseStatus(strings.Join(parts[2:], " "))
+			if err != nil {
+				return "Invalid status: " + err.Error()
+			}
+			taskToMove, err := ResolveTaskRef(taskStore, parts[1], storage.ListOptions{})
+			if err != nil {
+				return "Invalid task ref: " + err.Error()
+			}
+
+			if taskToMove.Status == status {
+				return "Task is already " + task.StatusString(*taskToMove) + ": " + taskToMove.Name
+			}
+			// The orchestrator owns in-progress tasks while it runs and would overwrite the change
+			if taskToMove.Status == task.InProgress && orchestrator.IsRunning() {
+				return "Task is being processed by the orchestrator. Run 'stop' before moving it."
+			}
+
+			from := task.StatusString(*taskToMove)
+			taskToMove.MoveTo(status, "Moved manually")
+			if err := taskStore.UpdateTask(taskToMove); err != nil {
+				return "Error moving task: " + err.Error()
+			}
+			return "Moved task from " + from + " to " + task.StatusString(*taskToMove) + ": " + taskToMove.Name
+		},
+	})
+	actions = append(actions, Command {
+		Text: "open",
+		Description: "Open a task's worktree in $VISUAL or $EDITOR (falling back to code, then vim).",
+		Usage: "<task ref>",
+		MinArgs: 1,
+		MaxArgs: 1,
+		TakesTaskRef: true,
+		Action: func(text string, m *Model) string {
+			parts := strings.Fields(text)
+			taskToOpen, err := ResolveTaskRef(taskStore, parts[1], storage.ListOptions{})
+			if err != nil {
+				return "Invalid task ref: " + err.Error()
+			}
+			// Worktrees are removed once a task completes, but its branch is kept
+			if _, err := os.Stat(taskToOpen.WorktreePath); taskToOpen.WorktreePath == "" || err != nil {
+				if taskToOpen.BranchName != "" {
+					return "Task has no worktree. Its work is on branch " + taskToOpen.BranchName + ": git checkout " + taskToOpen.BranchName
+				}
+				return "Task has no worktree yet: " + taskToOpen.Name
+			}
+
+			editor, err := utils.ResolveEditor(taskToOpen.WorktreePath, os.Getenv, exec.LookPath)
+			if err != nil {
+				return "No editor found (set $VISUAL or $EDITOR). Worktree: " + taskToOpen.WorktreePath
+			}
+			if editor.Terminal {
+				return editor.Args[0] + " needs the terminal, so run it from another shell: cd " + taskToOpen.WorktreePath + " && " + strings.Join(editor.Args[:len(editor.Args)-1], " ") + " ."
+			}
+			if err := editor.Start(); err != nil {
+				return "Error launching " + editor.Args[0] + ": " + err.Error() + ". Worktree: " + taskToOpen.WorktreePath
+			}
+			return "Opened " + taskToOpen.WorktreePath + " in " + editor.Args[0]
+		},
+	})
+	actions = append(actions, Command {
+		Text: "archive",
+		Description: "Remove a task from the board without deleting its record, branch or logs.",
+		Usage: "<task ref>",
+		MinArgs: 1,
+		MaxArgs: 1,
+		TakesTaskRef: true,
+		Action: func(text string, m *Model) string {
+			parts := strings.Fields(text)
+			taskToArchive, err := ResolveTaskRef(taskStore, parts[1], storage.ListOptions{})
+			if err != nil {
+				return "Invalid task ref: " + err.Error()
+			}
+			if taskToArchive.Status == task.InProgress && orchestrator.IsRunning() {
+				return "Task is being processed by the orchestrator. Run 'stop' before archiving it."
+			}
+
+			taskToArchive.Archived = true
+			if err := taskStore.UpdateTask(taskToArchive); err != nil {
+				return "Error archiving task: " + err.Error()
+			}
+			return "Archived task: " + taskToArchive.Name
+		},
+	})
+	actions = append(actions, Command {
+		Text: "unarchive",
+		Description: "Return an archived task to the board. Refs are shown by 'list --archived'.",
+		Usage: "<task ref>",
+		MinArgs: 1,
+		MaxArgs: 1,
+		TakesTaskRef: true,
+		Action: func(text string, m *Model) string {
+			parts := strings.Fields(text)
+			taskToRestore, err := ResolveTaskRef(taskStore, parts[1], storage.ListOptions{Archived: storage.OnlyArchived})
+			if err != nil {
+				return "Invalid task ref: " + err.Error()
+			}
+
+			taskToRestore.Archived = false
+			if err := taskStore.UpdateTask(taskToRestore); err != nil {
+				return "Error unarchiving task: " + err.Error()
+			}
+			return "Unarchived task: " + taskToRestore.Name
+		},
+	})
+	actions = append(actions, Command {
+		Text: "list",
+		Description: "List tasks on the board, or archived tasks, oldest first with their refs.",
+		Usage: "[--archived]",
+		MaxArgs: 1,
+		Action: func(text string, m *Model) string {
+			parts := strings.Fields(text)
+			opts := storage.ListOptions{SortBy: storage.SortByCreated}
+			switch {
+			case len(parts) == 1:
+			case parts[1] == "--archived":
+				opts.Archived = storage.OnlyArchived
+			default:
+				return "Unknown option " + parts[1] + ". The only option is --archived."
+			}
+			tasksPointers, err := taskStore.ListTasksFiltered(opts)
+			if err != nil {
+				return "Error retrieving tasks: " + err.Error()
+			}
+			if len(tasksPointers) == 0 {
+				if opts.Archived == storage.OnlyArchived {
+					return "No archived tasks."
+				}
+				return "No tasks."
+			}
+			return RenderTaskList(utils.PointerSliceToValueSlice(tasksPointers))
+		},
+	})
+	actions = append(actions, Command {
+		Text: "follow",
+		Description: "Watch a task's live output full-screen. Unlike view, it moves on to each new run and returns to the board once the task finishes.",
+		Usage: "<task ref>",
+		MinArgs: 1,
+		MaxArgs: 1,
+		TakesTaskRef: true,
+		Action: func(text string, m *Model) string {
+			t, err := ResolveTaskRef(taskStore, strings.Fields(text)[1], storage.ListOptions{})
+			if err != nil {
+				return "Invalid task ref: " + err.Error()
+			}
+			if !isFollowable(t.Status) {
+				return "Task has already finished: " + t.Name + ". Use 'view' to see its output."
+			}
+			responses, err := responseFiles(*t)
+			if err != nil {
+				return "Error listing responses: " + err.Error()
+			}
+			if len(responses) == 0 {
+				return "No output recorded yet for task: " + t.Name
+			}
+			m.viewingViewport = true
+			m.taskViewport = *m.taskViewport.SetViewingTask(t, responses[len(responses)-1])
+			m.taskViewport.Follow()
+			m.afterCommand = m.taskViewport.ViewportUpdateLoop()
+			return ""
+		},
+	})
+	actions = append(actions, Command {
+		Text: "logs",
+		Description: "Follow the last N orchestrator log lines (default 50), e.g. which tasks were started and why.",
+		Usage: "[N]",
+		MaxArgs: 1,
+		Action: func(text string, m *Model) string {
+			parts := strings.Fields(text)
+			lines := DEFAULT_LOG_LINES
+			if len(parts) == 2 {
+				n, err := strconv.Atoi(parts[1])
+				if err != nil || n < 1 {
+					return "Invalid line count. Must be a positive number."
+				}
+				lines = n
+			}
+			m.viewingViewport = true
+			m.taskViewport = *m.taskViewport.SetViewingLogs(lines)
+			m.afterCommand = m.taskViewport.ViewportUpdateLoop()
+			return ""
+		},
+	})
+	actions = append(actions, Command {
+		Text: "status",
+		Description: "Show whether the orchestrator is running, what it's working on and whether the AI provider is reachable",
+		Background: true,
+		Action: func(text string, m *Model) string {
+			cfg, err := config.LoadConfig()
+			if err != nil {
+				return "Error loading config: " + err.Error()
+			}
+			report, err := orchestrator.GetStatus(taskStore, cfg)
+			if err != nil {
+				return "Error retrieving status: " + err.Error()
+			}
+			return RenderStatus(report)
+		},
+	})
+	actions = append(actions, Command {
+		Text: "doctor",
+		Description: "Check everything Ludwig needs (git, a repo, the config, the AI provider, writable storage and a readable tasks.json) and say how to fix what's missing",
+		Background: true,
+		Action: func(text string, m *Model) string {
+			cfg, err := config.LoadConfig()
+			var report strings.Builder
+			orchestrator.WriteDoctorReport(&report, orchestrator.Doctor(cfg, err))
+			return strings.TrimRight(report.String(), "\n")
+		},
+	})
+	actions = append(actions, Command {
+		Text: "why",
+		Description: "Explain whether and when the orchestrator will pick a task up, and what's holding it back",
+		Usage: "<task ref>",
+		MinArgs: 1,
+		MaxArgs: 1,
+		TakesTaskRef: true,
+		Background: true,
+		Action: func(text string, m *Model) string {
+			t, err := ResolveTaskRef(taskStore, strings.Fields(text)[1], storage.ListOptions{Archived: storage.IncludeArchived})
+			if err != nil {
+				return "Invalid task ref: " + err.Error()
+			}
+			tasks, err := taskStore.ListTasks()
+			if err != nil {
+				return "Error listing tasks: " + err.Error()
+			}
+			cfg, _ := config.LoadConfig()
+			checks, _ := orchestrator.Preflight(cfg)
+			return t.ShortID() + " " + t.Title() + "\n" + strings.Join(orchestrator.Why(cfg, tasks, t, checks), "\n")
+		},
+	})
+	actions = append(actions, Command {
+		Text: "restore-backup",
+		Description: "Swap tasks.json with the copy taken before the last save. Run it again to undo.",
+		Action: func(text string, m *Model) string {
+			// The orchestrator saves as it works and would immediately overwrite the restored file
+			if orchestrator.IsRunning() {
+				return "The orchestrator is running. Run 'stop' before restoring the backup."
+			}
+			if err := taskStore.RestoreBackup(); err != nil {
+				return "Error restoring backup: " + err.Error()
+			}
+			return "Restored tasks from backup. Run restore-backup again to undo."
+		},
+	})
+	actions = append(actions, Command {
+		Text: "migrate-storage",
+		Description: "Move tasks.json and every task's output into another directory and point the storageDir option at it. Safe to run again if it is interrupted.",
+		Usage: "<dir>",
+		MinArgs: 1,
+		MaxArgs: 1,
+		Action: func(text string, m *Model) string {
+			// Running tasks hold their response files open in the old directory
+			if orchestrator.IsRunning() {
+				return "The orchestrator is running. Run 'stop' before moving its storage."
+			}
+			dir := strings.Fields(text)[1]
+			moved, err := taskStore.Migrate(dir)
+			if err != nil {
+				return "Error migrating storage: " + err.Error()
+			}
+			if !moved {
+				return "Tasks are already stored in " + dir + "."
+			}
+			return "Moved tasks and their output to " + dir + "."
+		},
+	})
+	actions = append(actions, Command {
+		Text: "scope",
+		Description: "Run a task in a subdirectory of the repo, e.g. one project of a monorepo. Leave out the path to use the whole repo again.",
+		Usage: "<task ref> [sub path]",
+		MinArgs: 1,
+		MaxArgs: 2,
+		TakesTaskRef: true,
+		Action: func(text string, m *Model) string {
+			parts := strings.Fields(text)
+			taskToScope, err := ResolveTaskRef(taskStore, parts[1], storage.ListOptions{})
+			if err != nil {
+				return "Invalid task ref: " + err.Error()
+			}
+			if taskToScope.Status == task.InProgress && orchestrator.IsRunning() {
+				return "Task is being processed by the orchestrator. Run 'stop' before changing its scope."
+			}
+
+			subPath := ""
+			if len(parts) == 3 {
+				if subPath, err = task.CleanSubPath(parts[2]); err != nil {
+					return "Invalid sub path: " + err.Error()
+				}
+			}
+			// Worktrees are checkouts of this repo, so the directory should exist here too
+			if info, err := os.Stat(filepath.Join(".", subPath)); subPath != "" && (err != nil || !info.IsDir()) {
+				return "No directory " + subPath + " in this repo"
+			}
+
+			taskToScope.SubPath = subPath
+			if err := taskStore.UpdateTask(taskToScope); err != nil {
+				return "Error updating task: " + err.Error()
+			}
+			if subPath == "" {
+				return "Task now uses the whole repo: " + taskToScope.Name
+			}
+			return "Task scoped to " + subPath + ": " + taskToScope.Name
+		},
+	})
+	actions = append(actions, Command {
+		Text: "base",
+		Description: "Start a task's branch from another branch, e.g. the branch of a task it builds on. Leave out the branch to start from main again.",
+		Usage: "<task ref> [branch]",
+		MinArgs: 1,
+		MaxArgs: 2,
+		TakesTaskRef: true,
+		Action: func(text string, m *Model) string {
+			parts := strings.Fields(text)
+			taskToBase, err := ResolveTaskRef(taskStore, parts[1], storage.ListOptions{})
+			if err != nil {
+				return "Invalid task ref: " + err.Error()
+			}
+			if taskToBase.BranchName != "" {
+				return "Task already has its branch " + taskToBase.BranchName + "; use 'rerun' to start again from another base."
+			}
+
+			baseBranch := ""
+			if len(parts) == 3 {
+				baseBranch = parts[2]
+				if exists, _ := orchestrator.BranchExists(baseBranch); !exists {
+					return "No branch " + baseBranch + " in this repo"
+				}
+			}
+			taskToBase.BaseBranch = baseBranch
+			if err := taskStore.UpdateTask(taskToBase); err != nil {
+				return "Error updating task: " + err.Error()
+			}
+			if baseBranch == "" {
+				return "Task now starts from main: " + taskToBase.Name
+			}
+			return "Task starts from " + baseBranch + ": " + taskToBase.Name
+		},
+	})
+	actions = append(actions, Command {
+		Text: "comment",
+		Description: "Leave a note on a task. Comments are shown when viewing the task and aren't sent to the AI.",
+		Usage: "<task ref> <text>",
+		MinArgs: 2,
+		MaxArgs: ANY_ARGS,
+		TakesTaskRef: true,
+		Action: func(text string, m *Model) string {
+			parts := strings.Fields(text)
+			taskToComment, err := ResolveTaskRef(taskStore, parts[1], storage.ListOptions{})
+			if err != nil {
+				return "Invalid task ref: " + err.Error()
+			}
+
+			// Keep the comment as typed after the ref, including newlines
+			comment := strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(text), parts[0]))
+			comment = strings.TrimSpace(strings.TrimPrefix(comment, parts[1]))
+			taskToComment.AddComment(comment)
+			if err := taskStore.UpdateTask(taskToComment); err != nil {
+				return "Error updating task: " + err.Error()
+			}
+			return fmt.Sprintf("Added comment %d to task: %s", len(taskToComment.Comments), taskToComment.Title())
+		},
+	})
+	actions = append(actions, Command {
+		Text: "rerun",
+		Description: "Add a new Pending task with the same description, tags and priority as another, optionally with more instructions. The original is left as it is.",
+		Usage: "<task ref> [extra instructions]",
+		MinArgs: 1,
+		MaxArgs: ANY_ARGS,
+		TakesTaskRef: true,
+		Action: func(text string, m *Model) string {
+			parts := strings.Fields(text)
+			original, err := ResolveTaskRef(taskStore, parts[1], storage.ListOptions{})
+			if err != nil {
+				return "Invalid task ref: " + err.Error()
+			}
+
+			rerun := original.Rerun(uuid.New().String())
+			// Anything typed after the ref is added below the original description
+			extra := strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(text), parts[0]))
+			extra = strings.TrimSpace(strings.TrimPrefix(extra, parts[1]))
+			if extra != "" {
+				rerun.Name += "\n\n" + extra
+			}
+			if err := taskStore.AddTask(rerun); err != nil {
+				return "Error adding new task: " + err.Error()
+			}
+			return "Added " + rerun.ShortID() + " as a re-run of " + original.ShortID() + ": " + rerun.Title()
+		},
+	})
+	actions = append(actions, Command {
+		Text: "kill",
+		Description: "Abort a running task, leaving other running tasks alone. Its uncommitted changes are committed (or discarded with discardFailedWork) and it's marked Failed.",
+		Usage: "<task ref>",
+		MinArgs: 1,
+		MaxArgs: 1,
+		TakesTaskRef: true,
+		Action: func(text string, m *Model) string {
+			parts := strings.Fields(text)
+			taskToKill, err := ResolveTaskRef(taskStore, parts[1], storage.ListOptions{})
+			if err != nil {
+				return "Invalid task ref: " + err.Error()
+			}
+			if err := orchestrator.Kill(taskToKill.ID); err != nil {
+				if errors.Is(err, orchestrator.ErrTaskNotRunning) {
+					return "Task is not running: " + taskToKill.Title()
+				}
+				return "Error killing task: " + err.Error()
+			}
+			return "Killing task: " + taskToKill.Title()
+		},
+	})
+	actions = append(actions, Command {
+		Text: "discard",
+		Description: "Throw away a Pending or Failed task's work: its worktree is removed and its branch deleted, committed changes included. The next run starts afresh.",
+		Usage: "<task ref>",
+		MinArgs: 1,
+		MaxArgs: 1,
+		TakesTaskRef: true,
+		Action: func(text string, m *Model) string {
+			parts := strings.Fields(text)
+			taskToDiscard, err := ResolveTaskRef(taskStore, parts[1], storage.ListOptions{})
+			if err != nil {
+				return "Invalid task ref: " + err.Error()
+			}
+			// Completed work may already be merged, and other statuses are still in use
+			if taskToDiscard.Status != task.Pending && taskToDiscard.Status != task.Failed {
+				return "Only Pending or Failed tasks can be discarded, task is " + task.StatusString(*taskToDiscard) + ": " + taskToDiscard.Title()
+			}
+			if taskToDiscard.BranchName == "" && taskToDiscard.WorktreePath == "" {
+				return "Task has no work to discard: " + taskToDiscard.Title()
+			}
+			if err := orchestrator.DiscardWork(taskStore, taskToDiscard); err != nil {
+				if errors.Is(err, orchestrator.ErrTaskRunning) {
+					return "Task is running. Run 'kill' before discarding its work."
+				}
+				return "Error discarding work: " + err.Error()
+			}
+			return "Discarded the work of task: " + taskToDiscard.Title()
+		},
+	})
+	actions = append(actions, Command {
+		Text: "worktrees",
+		Description: "List git worktrees with their branch and task, marking those whose task was deleted as orphaned. 'worktrees remove <number>' removes an orphaned one; its branch is kept. One with uncommitted changes needs --force.",
+		Usage: "[remove number [--force]]",
+		MaxArgs: 3,
 		Action: func(text string, m *Model) string {
 			parts := strings.Fields(text)
-			if !checkArgumentsCount(1, parts) {
-				return "Usage: help method takes no arguments"
+			force := len(parts) == 4 && parts[3] == "--force"
+			if len(parts) > 1 && (parts[1] != "remove" || (len(parts) != 3 && !force)) {
+				return "Usage: worktrees [remove number [--force]]"
+			}
+			worktrees, err := orchestrator.ListWorktrees()
+			if err != nil {
+				return "Error listing worktrees: " + err.Error()
+			}
+			tasks, err := taskStore.ListTasks()
+			if err != nil {
+				return "Error retrieving tasks: " + err.Error()
+			}
+			cfg, err := config.LoadConfig()
+			if err != nil {
+				return "Error loading config: " + err.Error()
+			}
+			infos := orchestrator.MatchWorktrees(worktrees, tasks, orchestrator.WorktreeBase(cfg))
+			if len(parts) == 1 {
+				if len(infos) == 0 {
+					return "No worktrees besides the repo itself."
+				}
+				return RenderWorktrees(infos)
+			}
+
+			number, err := strconv.Atoi(parts[2])
+			if err != nil || number < 0 || number >= len(infos) {
+				return "Invalid worktree number " + parts[2] + ". Run 'worktrees' to see them."
+			}
+			info := infos[number]
+			if !info.Orphaned() {
+				if info.Task != nil {
+					return "Worktree belongs to task " + info.Task.ShortID() + ". Use 'discard' to remove a task's work."
+				}
+				return "Ludwig didn't create " + info.Path + ", so it's left alone."
+			}
+			if !force {
+				dirty, err := orchestrator.WorktreeDirty(info.Path)
+				if err != nil {
+					return "Error checking worktree: " + err.Error()
+				}
+				if dirty {
+					return info.Path + " has uncommitted changes that removing it would lose. Use 'worktrees remove " + parts[2] + " --force' to remove it anyway."
+				}
+			}
+			if err := orchestrator.RemoveWorktree(info.Path); err != nil {
+				return "Error removing worktree: " + err.Error()
+			}
+			message := "Removed orphaned worktree " + info.Path
+			if info.Branch != "" {
+				message += ". Its branch " + info.Branch + " is kept."
+			}
+			return message
+		},
+	})
+	actions = append(actions, Command {
+		Text: "template",
+		Description: "Reuse a task: 'template save <name> <task ref>' keeps its description, tags and priority, 'template use <name> [text]' adds a new Pending task from it with the text in place of {{.Input}}, and 'template list' shows them.",
+		Usage: "<list|use|save> [name] [text or task ref]",
+		MinArgs: 1,
+		MaxArgs: ANY_ARGS,
+		Action: func(text string, m *Model) string {
+			return templateCommand(taskStore, text)
+		},
+	})
+	actions = append(actions, Command {
+		Text: "new",
+		Description: "Open a form to create a task with a name, tags, priority and instructions",
+		Action: func(text string, m *Model) string {
+			if m == nil {
+				return "The new task form is only available in the TUI"
+			}
+			m.OpenTaskForm()
+			return ""
+		},
+	})
+	actions = append(actions, Command {
+		Text: "update",
+		Description: "Download and install the latest version of Ludwig. The result is shown here once done; restart Ludwig to use the new version.",
+		Action: func(text string, m *Model) string {
+			if m == nil {
+				return "Run 'ludwig --update' to update outside the TUI"
+			}
+			m.installUpdate()
+			return "Checking for updates..."
+		},
+	})
+	actions = append(actions, Command {
+		Text: "stats",
+		Description: "Show a summary of task counts, completion times and success rate",
+		Action: func(text string, m *Model) string {
+			tasksPointers, err := taskStore.ListTasks()
+			if err != nil {
+				return "Error retrieving tasks: " + err.Error()
 			}
+			stats := task.ComputeStats(utils.PointerSliceToValueSlice(tasksPointers), time.Now())
+			return RenderStats(stats)
+		},
+	})
+	actions = append(actions, Command {
+		Text: "help",
+		Description: "Show this help message",
+		Action: func(text string, m *Model) string {
 			//utils.PrintHelp(actions)
-			return PrintHelpTable(actions)
+			// Registered commands are listed too
+			return PrintHelpTable(PalleteCommands(taskStore))
 		},
 	})
+	return actions
+}
+
+// ANY_ARGS as a command's MaxArgs accepts any number of arguments, e.g. free text
+const ANY_ARGS = -1
+
+// withArgumentCheck wraps cmd's action so it only runs with between MinArgs and
+// MaxArgs arguments, returning the command's usage otherwise
+func withArgumentCheck(cmd Command) func(text string, m *Model) string {
+	action := cmd.Action
+	return func(text string, m *Model) string {
+		if message := ValidateArgs(cmd, text); message != "" {
+			return message
+		}
+		return action(text, m)
+	}
+}
+
+// ValidateArgs checks the number of arguments typed after a command, returning ""
+// if it is accepted and the command's usage if not
+func ValidateArgs(cmd Command, text string) string {
+	args := len(strings.Fields(text)) - 1
+	if args >= cmd.MinArgs && (cmd.MaxArgs == ANY_ARGS || args <= cmd.MaxArgs) {
+		return ""
+	}
+	return "Usage: " + cmd.Synopsis() + " - " + cmd.Description
+}
+
+// Synopsis returns the command with its arguments, e.g. "view <task ref> [run]"
+func (cmd Command) Synopsis() string {
+	if cmd.Usage == "" {
+		return cmd.Text
+	}
+	return cmd.Text + " " + cmd.Usage
+}
+
+// DEFAULT_LOG_LINES is how many log lines 'logs' shows when no count is given
+const DEFAULT_LOG_LINES = 50
+
+// boardTasks returns the tasks shown on the kanban, oldest first like 'list', so
+// the order stays the same between reloads
+func boardTasks(taskStore *storage.FileTaskStorage) ([]*task.Task, error) {
+	return taskStore.ListTasksFiltered(storage.ListOptions{SortBy: storage.SortByCreated})
+}
+
+// ResolveTaskRef finds the task a user-supplied ref points at among those listed
+// with opts, trying in order: its short ID (or full ID), its position in the list
+// as 'list' numbers it (oldest first), then an ID or case-insensitive name prefix.
+// A prefix must match exactly one task. It lives here rather than in the cli
+// package because cli imports model, whose commands all resolve refs.
+func ResolveTaskRef(taskStore *storage.FileTaskStorage, ref string, opts storage.ListOptions) (*task.Task, error) {
+	// Number positions the same way 'list' shows them, so they don't depend on map order
+	opts.SortBy = storage.SortByCreated
+	opts.Descending = false
+	tasks, err := taskStore.ListTasksFiltered(opts)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving tasks: %w", err)
+	}
+	for _, t := range tasks {
+		if t.ShortID() == ref || t.ID == ref {
+			return t, nil
+		}
+	}
+
+	if index, err := strconv.Atoi(ref); err == nil {
+		if index < 0 || index >= len(tasks) {
+			return nil, fmt.Errorf("task ref %d out of range, there are %d tasks", index, len(tasks))
+		}
+		return tasks[index], nil
+	}
+
+	var matches []*task.Task
+	lowerRef := strings.ToLower(ref)
+	for _, t := range tasks {
+		if strings.HasPrefix(t.ID, ref) || strings.HasPrefix(strings.ToLower(t.Name), lowerRef) {
+			matches = append(matches, t)
+		}
+	}
+	switch len(matches) {
+	case 0:
+		return nil, fmt.Errorf("no task matches %q", ref)
+	case 1:
+		return matches[0], nil
+	default:
+		names := make([]string, len(matches))
+		for i, t := range matches {
+			names[i] = t.ShortID() + " " + t.Name
+		}
+		return nil, fmt.Errorf("ambiguous reference %q matches %d tasks: %s", ref, len(matches), strings.Join(names, ", "))
+	}
+}
+
+// RenderTaskList renders tasks as a table with their positions and short IDs,
+// either of which can be used as a ref. Short IDs don't change as tasks come and go
+func RenderTaskList(tasks []task.Task) string {
+	columns := []table.Column {
+		{Title: "#", Width: 4},
+		{Title: "Ref", Width: task.SHORT_ID_LENGTH + 2},
+		{Title: "Name", Width: 60},
+		{Title: "Status", Width: 12},
+	}
+	rows := make([]table.Row, 0, len(tasks))
+	for i, t := range tasks {
+		rows = append(rows, table.Row{strconv.Itoa(i), t.ShortID(), t.Title(), task.StatusString(t)})
+	}
+	t := tableOptions(columns, rows)
+	t.SetHeight(len(rows) + 1)
+
+	return t.View()
+}
+
+// RenderWorktrees renders worktrees as a table numbered for 'worktrees remove'
+func RenderWorktrees(infos []orchestrator.WorktreeInfo) string {
+	columns := []table.Column {
+		{Title: "#", Width: 3},
+		{Title: "Path", Width: 40},
+		{Title: "Branch", Width: 30},
+		{Title: "Task", Width: 40},
+	}
+	rows := make([]table.Row, 0, len(infos))
+	for i, info := range infos {
+		owner := "Not Ludwig's"
+		switch {
+		case info.Orphaned():
+			owner = "Orphaned: task " + info.TaskID + " was deleted"
+		case info.Task != nil:
+			owner = info.Task.ShortID() + " " + info.Task.Title()
+		}
+		branch := info.Branch
+		if branch == "" {
+			branch = "(detached)"
+		}
+		rows = append(rows, table.Row{strconv.Itoa(i), info.Path, branch, owner})
+	}
+	t := tableOptions(columns, rows)
+	return t.View()
 }
 
-func checkArgumentsCount(expected int, parts []string) bool {
-	return checkArgumentsCountMin(expected, parts, false)
+// RenderStatus renders an orchestrator status report as a two column table
+func RenderStatus(report orchestrator.StatusReport) string {
+	running := "Stopped"
+	if report.Running {
+		running = "Running"
+	}
+	available := "No"
+	if report.Available {
+		available = "Yes"
+	}
+	git := "Installed"
+	var guidance []string
+	for _, check := range report.Checks {
+		if check.OK {
+			continue
+		}
+		if check.Name == "git" {
+			git = "Missing"
+		}
+		guidance = append(guidance, check.Name+": "+check.Guidance)
+	}
+	working := "Nothing"
+	if len(report.ActiveTasks) > 0 {
+		names := make([]string, len(report.ActiveTasks))
+		for i, t := range report.ActiveTasks {
+			names[i] = t.ShortID() + " " + t.Name
+		}
+		working = strings.Join(names, ", ")
+	}
+
+	columns := []table.Column {
+		{Title: "Metric", Width: 20},
+		{Title: "Value", Width: 60},
+	}
+	rows := []table.Row {
+		{"Orchestrator", running},
+		{"Working on", working},
+		{"Provider", report.Provider},
+		{"Model", report.Model},
+		{"Provider available", available},
+		{"Git", git},
+		{"To Do", strconv.Itoa(report.Counts[task.Pending])},
+		{"In Progress", strconv.Itoa(report.Counts[task.InProgress])},
+		{"In Review", strconv.Itoa(report.Counts[task.NeedsReview])},
+		{"Completed", strconv.Itoa(report.Counts[task.Completed])},
+		{"Failed", strconv.Itoa(report.Counts[task.Failed])},
+	}
+	t := tableOptions(columns, rows)
+	t.SetHeight(len(rows) + 1)
+
+	if len(guidance) > 0 {
+		return t.View() + "\n\nTo fix:\n  " + strings.Join(guidance, "\n  ")
+	}
+	return t.View()
 }
 
-func checkArgumentsCountMin(expected int, parts []string, canHaveMore bool) bool {
-	if canHaveMore && len(parts) >= expected { return true }
-	if len(parts) != expected {
-		utils.Println("Usage: " + parts[0] + " takes " + strconv.Itoa(expected) + " arguments")
-		return false
+// RenderStats renders task statistics as a two column table
+func RenderStats(stats task.Stats) string {
+	columns := []table.Column {
+		{Title: "Metric", Width: 20},
+		{Title: "Value", Width: 20},
 	}
-	return true
+	rows := []table.Row {
+		{"Total tasks", strconv.Itoa(stats.Total)},
+		{"To Do", strconv.Itoa(stats.ByStatus[task.Pending])},
+		{"In Progress", strconv.Itoa(stats.ByStatus[task.InProgress])},
+		{"In Review", strconv.Itoa(stats.ByStatus[task.NeedsReview])},
+		{"Completed", strconv.Itoa(stats.ByStatus[task.Completed])},
+		{"Failed", strconv.Itoa(stats.ByStatus[task.Failed])},
+		{"Completed today", strconv.Itoa(stats.CompletedToday)},
+		{"Avg completion time", stats.AverageCompletion.Round(time.Second).String()},
+		{"Success rate", fmt.Sprintf("%.0f%%", stats.SuccessRate*100)},
+		{"Failed runs", strconv.Itoa(stats.FailedRuns)},
+	}
+	t := tableOptions(columns, rows)
+	t.SetHeight(len(rows) + 1)
+
+	return t.View()
 }
 
 func PrintHelpTable(actions []Command) string {
 	columns := []table.Column {
-		{Title: "Command", Width: 20},
+		{Title: "Command", Width: 40},
 		{Title: "Description", Width: 200},
 	}
 	rows := genHelpTableRows(actions)
@@ -198,9 +980,8 @@ func PrintHelpTable(actions []Command) string {
 func genHelpTableRows(actions []Command) []table.Row {
 	var rows []table.Row
 	for _, cmd := range actions {
-		rows = append(rows, table.Row{cmd.Text, cmd.Description})
+		rows = append(rows, table.Row{cmd.Synopsis(), cmd.Description})
 	}
-	rows = append(rows, table.Row{"help", "Show this help message"})
 	return rows
 }
 
@@ -213,5 +994,27 @@ func tableOptions(columns []table.Column, rows []table.Row) table.Model {
 	s := table.DefaultStyles()
 	s.Selected = s.Cell.Padding(0,0).Margin(0,0)
 	t.SetStyles(s)
+	// Show every row rather than scrolling, e.g. so help lists all commands
+	t.SetHeight(len(rows) + lipgloss.Height(s.Header.Render("Command")))
 	return t
 }
+
+// responseFiles lists the response files of t's runs, oldest first
+func responseFiles(t task.Task) ([]string, error) {
+	responses, err := storage.ListResponses(t.ID)
+	if err != nil {
+		return nil, err
+	}
+	// Fall back to the stored path for responses written before the index existed
+	if len(responses) == 0 && t.ResponseFile != "" {
+		if stored, err := storage.ResolveResponseFile(t.ID, t.ResponseFile); err == nil {
+			responses = []string{stored}
+		}
+	}
+	return responses, nil
+}
+
+// isFollowable reports whether a task in status may still produce output
+func isFollowable(status task.Status) bool {
+	return status == task.Pending || status == task.InProgress
+}
@@ -0,0 +1,72 @@
+package model
+
+import (
+	"errors"
+	"strings"
+	"sync"
+
+	"ludwig/internal/logger"
+	"ludwig/internal/storage"
+)
+
+// CommandFactory builds commands for a task store, so their actions can use it
+type CommandFactory func(taskStore *storage.FileTaskStorage) []Command
+
+var (
+	registryMu sync.Mutex
+	registry   []CommandFactory // Built-in commands first, then others in the order registered
+)
+
+// ErrInvalidCommandName is returned when registering a command whose name is
+// empty or has spaces, which could never be typed
+var ErrInvalidCommandName = errors.New("command names must be a single word")
+
+func init() {
+	RegisterCommands(builtinCommands)
+}
+
+// RegisterCommand adds cmd to the commands PalleteCommands returns from now on, so
+// it is listed by help and the command finder and runs like a built-in. Commands
+// named like an earlier one are left out, so built-ins can't be replaced.
+func RegisterCommand(cmd Command) error {
+	if cmd.Text == "" || strings.ContainsFunc(cmd.Text, func(r rune) bool { return r == ' ' || r == '\t' || r == '\n' }) {
+		return ErrInvalidCommandName
+	}
+	RegisterCommands(func(*storage.FileTaskStorage) []Command {
+		return []Command{cmd}
+	})
+	return nil
+}
+
+// RegisterCommands adds commands built by factory for each task store, for
+// commands whose actions need the store
+func RegisterCommands(factory CommandFactory) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	registry = append(registry, factory)
+}
+
+// PalleteCommands returns every registered command, built for taskStore
+func PalleteCommands(taskStore *storage.FileTaskStorage) []Command {
+	registryMu.Lock()
+	factories := append([]CommandFactory(nil), registry...)
+	registryMu.Unlock()
+
+	var actions []Command
+	seen := map[string]bool{}
+	for _, factory := range factories {
+		for _, cmd := range factory(taskStore) {
+			if seen[cmd.Text] {
+				logger.Warnf("Ignoring command %q, a command with that name already exists", cmd.Text)
+				continue
+			}
+			seen[cmd.Text] = true
+			// Check argument counts in one place, so actions can rely on them
+			if cmd.Action != nil {
+				cmd.Action = withArgumentCheck(cmd)
+			}
+			actions = append(actions, cmd)
+		}
+	}
+	return actions
+}
@@ -1,10 +1,16 @@
 package model
 
 import (
+	"ludwig/internal/components/commandFinder"
 	"ludwig/internal/components/commandInput"
 	"ludwig/internal/components/outputViewport"
 	"ludwig/internal/components/orchestratorIndicator"
+	"ludwig/internal/components/taskForm"
+	"ludwig/internal/config"
 	"ludwig/internal/kanban"
+	"ludwig/internal/logger"
+	"ludwig/internal/notify"
+	"ludwig/internal/orchestrator"
 	"ludwig/internal/storage"
 	"ludwig/internal/types/task"
 	"ludwig/internal/updater"
@@ -29,18 +35,71 @@ type Model struct {
 	message         string
 	taskViewport    outputViewport.Model
 	viewingViewport bool
+	taskForm        *taskForm.Model // Open task creation form, nil when the board is shown
+	commandFinder   *commandFinder.Model // Open Ctrl+P command finder, nil when closed
+	confirmation    *confirmation // Question waiting for a y/n keypress, nil when none
+	background      *backgroundCommand // Command running off the UI thread, nil when none
+	backgroundRuns  int // Commands run in the background so far, numbering their messages
 	orchestratorIndicator *orchestratorIndicator.Model
+	columnLimit     int // Max tasks per kanban column, 0 uses the kanban default
+	history         *CommandHistory
+	saveHistory     bool // Write history to HISTORY_FILE after each command
+	autoStart       bool // Start the orchestrator on launch, from the autoStart option
+	afterCommand    tea.Cmd // Work started by the running command's action, returned by runCommand
+	width           int // Terminal size, updated from tea.WindowSizeMsg
+	height          int
+	events          <-chan orchestrator.Event // Orchestrator events, read one at a time by waitForEvent
+	notices         <-chan notify.Notice // Background errors and warnings, read one at a time by WaitForNotice
+	notice          *notify.Notice // The notice last put in the message area, nil if none
+	version         string // Version of the running binary, for update checks
 }
 
 type Command struct {
-	Text        string
-	Action      func(Text string, m *Model) string
-	Description string
+	Text         string
+	Action       func(Text string, m *Model) string
+	Description  string
+	Usage        string // Arguments after the command, e.g. "<task ref> [run]"
+	MinArgs      int    // Arguments required after the command
+	MaxArgs      int    // Most arguments accepted, or ANY_ARGS
+	TakesTaskRef bool   // The first argument is a task ref
+	Background   bool   // Slow, so the TUI runs it off the UI thread with a nil Model
 }
 
 // tickMsg is a message sent on a timer to trigger a refresh.
 type tickMsg time.Time
 
+// eventMsg carries an orchestrator event to Update
+type eventMsg orchestrator.Event
+
+// noticeMsg carries a background error or warning to Update
+type noticeMsg notify.Notice
+
+// NOTICE_HOLD is how long a notice stays in the message area before a less
+// severe one may replace it
+const NOTICE_HOLD = 10 * time.Second
+
+// updateAvailableMsg reports a newer release found by CheckForUpdate
+type updateAvailableMsg struct {
+	current string
+	latest  string
+}
+
+// updateResultMsg carries the outcome of the update command
+type updateResultMsg string
+
+// autoStartMsg carries the outcome of starting the orchestrator on launch
+type autoStartMsg string
+
+// refreshMsg asks Update to reload the tasks, e.g. after a confirmed command changed them
+type refreshMsg struct{}
+
+// confirmation is a question shown in the message area, and the action to run if
+// the answer is yes
+type confirmation struct {
+	prompt string
+	action func() string
+}
+
 var loadingStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("62"))
 
 func NewModel(taskStore *storage.FileTaskStorage, version string) *Model {
@@ -61,7 +120,7 @@ func NewModel(taskStore *storage.FileTaskStorage, version string) *Model {
 	ti.CharLimit = 0
 	ti.Focus()
 
-	tasks, err := taskStore.ListTasks()
+	tasks, err := boardTasks(taskStore)
 	if err != nil {
 		// This error will be displayed in the view.
 		return &Model{err: fmt.Errorf("could not load tasks: %w", err)}
@@ -73,22 +132,47 @@ func NewModel(taskStore *storage.FileTaskStorage, version string) *Model {
 		commandInput: commandInput.NewModel(),
 		taskViewport: outputViewport.NewModel(),
 		orchestratorIndicator: orchestratorIndicator.NewModel(),
+		width:        utils.TermWidth(),
+		height:       utils.TermHeight(),
+		history:      NewCommandHistory(DEFAULT_HISTORY_SIZE),
+		version:      version,
 	}
+	// Lives as long as the TUI, so the subscription is never cancelled
+	m.events, _ = orchestrator.Subscribe()
+	m.notices, _ = notify.Subscribe()
 	m.commands = PalleteCommands(taskStore)
 
-	m.checkForUpdate(version)
+	if cfg, err := config.LoadConfig(); err == nil && cfg != nil {
+		m.columnLimit = cfg.KanbanColumnLimit
+		m.taskViewport.SetMaxLines(cfg.ViewportMaxLines)
+		m.autoStart = cfg.AutoStart
+		kanban.SetCustomStatuses(cfg.CustomStatuses)
+		if cfg.SaveCommandHistory {
+			m.saveHistory = true
+			m.history = LoadCommandHistory(DEFAULT_HISTORY_SIZE)
+		}
+	}
 
 	return m
 }
 
-func (m *Model) checkForUpdate(version string) {
-	// Check for updates in the background
-	go func() {
+// CheckForUpdate returns a command that looks for a newer release off the UI
+// thread, reporting it to Update rather than changing the Model itself. The
+// updater's requests time out, so a hanging connection to GitHub can't keep it
+// around.
+func (m *Model) CheckForUpdate() tea.Cmd {
+	version := m.version
+	return func() tea.Msg {
 		isNewer, latestVersion, err := updater.CheckForUpdate(version)
-		if err == nil && isNewer {
-			m.message = fmt.Sprintf("Update available: %s → %s. Exit Ludwig and run 'ludwig --update' to install.", version, latestVersion)
+		if err != nil {
+			notify.Warnf("Could not check for updates: %v", err)
+			return nil
+		}
+		if !isNewer {
+			return nil
 		}
-	}()
+		return updateAvailableMsg{current: version, latest: latestVersion}
+	}
 }
 
 func (m *Model) Init() tea.Cmd {
@@ -98,15 +182,121 @@ func (m *Model) Init() tea.Cmd {
 		tea.Tick(5*time.Second, func(t time.Time) tea.Msg {
 			return tickMsg(t)
 		}),
+		m.waitForEvent(),
+		m.WaitForNotice(),
+		m.AutoStart(),
+		m.CheckForUpdate(),
 	)
 }
 
+// AutoStart returns a command that starts the orchestrator when the autoStart
+// option is on, or nil when it's off. Unlike 'start' it also waits for the AI
+// provider to be available, so launching never starts a run of failing tasks.
+func (m *Model) AutoStart() tea.Cmd {
+	if !m.autoStart {
+		return nil
+	}
+	return func() tea.Msg {
+		return autoStartMsg(autoStartOrchestrator())
+	}
+}
+
+// autoStartOrchestrator starts the orchestrator if every preflight check passes,
+// returning the message to show
+func autoStartOrchestrator() string {
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		return "AI Orchestrator not started automatically: error loading config: " + err.Error()
+	}
+	checks, err := orchestrator.Preflight(cfg)
+	if err != nil {
+		return "AI Orchestrator not started automatically: " + err.Error()
+	}
+	for _, check := range checks {
+		if !check.OK {
+			return "AI Orchestrator not started automatically: " + check.Name + " is not available. " + check.Guidance + ", then run 'start'."
+		}
+	}
+	if err := orchestrator.Start(); err != nil {
+		return "AI Orchestrator not started automatically: " + err.Error()
+	}
+	return "AI Orchestrator started automatically."
+}
+
+// waitForEvent returns a command that delivers the next orchestrator event
+func (m *Model) waitForEvent() tea.Cmd {
+	if m.events == nil {
+		return nil
+	}
+	return func() tea.Msg {
+		event, ok := <-m.events
+		if !ok {
+			return nil
+		}
+		return eventMsg(event)
+	}
+}
+
+// WaitForNotice returns a command that delivers the next background notice, such
+// as an error from the orchestrator, to Update
+func (m *Model) WaitForNotice() tea.Cmd {
+	if m.notices == nil {
+		return nil
+	}
+	return func() tea.Msg {
+		notice, ok := <-m.notices
+		if !ok {
+			return nil
+		}
+		return noticeMsg(notice)
+	}
+}
+
+// showNotice puts a notice in the message area, unless a more severe notice
+// shown less than NOTICE_HOLD ago is still there
+func (m *Model) showNotice(notice notify.Notice) {
+	text := "Warning: " + notice.Text
+	if notice.Level >= logger.LevelError {
+		text = "Error: " + notice.Text
+	}
+	if current := m.notice; current != nil && m.message == current.Text &&
+		notice.Level < current.Level && notice.At.Sub(current.At) < NOTICE_HOLD {
+		return
+	}
+	m.message = text
+	m.notice = &notify.Notice{Level: notice.Level, Text: text, At: notice.At}
+}
+
 // Update handles incoming messages and updates the model.
 func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	//var cmd tea.Cmd
 	var cmds []tea.Cmd
 
-	if !m.viewingViewport {
+	if handled, cmd := m.updateBackground(msg); handled {
+		return m, cmd
+	}
+	if handled, cmd := m.updateConfirmation(msg); handled {
+		return m, cmd
+	}
+	if handled, cmd := m.updateTaskForm(msg); handled {
+		return m, cmd
+	}
+	if handled, cmd := m.updateCommandFinder(msg); handled {
+		return m, cmd
+	}
+
+	if key, ok := msg.(tea.KeyMsg); ok && key.Type == orchestratorIndicator.TOGGLE_KEY {
+		m.orchestratorIndicator.Toggle()
+		return m, nil
+	}
+
+	// Up/Down recall history instead of moving the cursor, unless the input spans several lines
+	if key, ok := msg.(tea.KeyMsg); ok && !m.viewingViewport && m.recallHistory(key.Type) {
+		return m, nil
+	}
+
+	// Enter is handled below rather than letting the textarea insert a newline at the cursor
+	if key, ok := msg.(tea.KeyMsg); !m.viewingViewport && (!ok || key.Type != tea.KeyEnter) {
 		var inputCmd tea.Cmd
 		m.commandInput, inputCmd = m.commandInput.Update(msg)
 		if inputCmd != nil {
@@ -118,66 +308,75 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	if indicatorCmd != nil {
 		cmds = append(cmds, indicatorCmd)
 	}
+	// Esc while typing a search cancels the search rather than closing the view
+	searching := m.taskViewport.Searching()
 	_, viewportCmd := m.taskViewport.Update(msg)
 	if viewportCmd != nil {
 		cmds = append(cmds, viewportCmd)
 	}
 
 	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+		if m.viewingViewport {
+			// The command input only sees messages while the board is shown
+			m.commandInput.Update(msg)
+		}
 	case tea.KeyMsg:
 		switch msg.Type {
 		case tea.KeyCtrlC, tea.KeyEsc:
 			if !m.viewingViewport {
 				return m, tea.Quit
 			}
+			if searching {
+				return m, nil
+			}
 			m.viewingViewport = false
 			return m, nil
 		case tea.KeyEnter:
-			input := strings.TrimSpace(m.commandInput.TextInput.Value())
-			parts := strings.Fields(input)
-			m.commandInput.TextInput.SetValue("")
-			m.err = nil
-
-			if len(parts) == 0 {
+			if msg.Alt {
+				// Alt+Enter starts a new line, e.g. for a structured task description
+				m.commandInput.InsertNewline()
 				return m, nil
 			}
-
-			commandText := parts[0]
-			if commandText == "exit" {
-				return m, tea.Quit
-			}
-
-			for _, cmd := range m.commands {
-				if cmd.Text == commandText {
-					// Execute the command's action.
-					if cmd.Action != nil {
-						output := cmd.Action(strings.Join(parts, " "), m)
-						if parts[0] != "view" {
-							m.message = output
-						}
-					}
-					// After action, refresh tasks immediately.
-					tasks, err := m.taskStore.ListTasks()
-					if err != nil {
-						m.err = err
-					} else {
-						m.tasks = utils.PointerSliceToValueSlice(tasks)
-					}
-					return m, nil
-				}
+			input := strings.TrimSpace(m.commandInput.TextInput.Value())
+			m.err = nil
+			if m.background == nil {
+				// While a command runs the input is kept, to be sent again once it is done
+				m.commandInput.TextInput.SetValue("")
+				m.recordHistory(input)
 			}
-			//m.err = fmt.Errorf("command not found: %q", commandText)
-			m.message = "Command not found: " + parts[0]
-			return m, nil
+			return m, m.runCommand(input)
 		}
 
 	case tickMsg:
 		// On each tick, reload tasks from storage.
-		m.UpdateTasks()
+		follow := m.UpdateTasks()
 		// Return a new tick command to continue polling.
-		return m, tea.Tick(5*time.Second, func(t time.Time) tea.Msg {
+		return m, tea.Batch(follow, tea.Tick(5*time.Second, func(t time.Time) tea.Msg {
 			return tickMsg(t)
-		})
+		}))
+	case refreshMsg:
+		return m, m.UpdateTasks()
+	case updateAvailableMsg:
+		m.message = fmt.Sprintf("Update available: %s → %s. Run 'update' to install it.", msg.current, msg.latest)
+		return m, nil
+	case updateResultMsg:
+		m.message = string(msg)
+		return m, nil
+	case noticeMsg:
+		m.showNotice(notify.Notice(msg))
+		return m, m.WaitForNotice()
+	case autoStartMsg:
+		m.message = string(msg)
+		m.orchestratorIndicator.AutoStarted = orchestrator.IsRunning()
+		return m, nil
+	case eventMsg:
+		if msg.Type == orchestrator.OrchestratorIdleStop {
+			m.message = "AI Orchestrator stopped after being " + msg.Reason + ". Run 'start' to start it again."
+		}
+		return m, tea.Batch(m.UpdateTasks(), m.waitForEvent())
 	case error:
 		m.err = msg
 		return m, nil
@@ -197,8 +396,6 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	return m, tea.Batch(cmds...)
 }
 
-const VIEWPORT_CONTROLS = "\n(Press Ctrl+S to scroll down, Ctrl+W to scroll up, Esc to exit view)"
-
 // getScrollbarChars generates scrollbar characters for each line based on viewport state
 // View renders the UI.
 func (m *Model) View() string {
@@ -206,20 +403,29 @@ func (m *Model) View() string {
 	if m.viewingViewport {
 		return m.taskViewport.View()
 	}
+	if m.taskForm != nil {
+		return m.taskForm.View()
+	}
 	// Render the Kanban board.
-	s.WriteString(kanban.RenderKanban(m.tasks))
+	s.WriteString(kanban.RenderKanbanToFit(m.tasks, m.columnLimit, m.width))
+	if m.commandFinder != nil {
+		// The finder takes the place of the message and command input
+		s.WriteString(m.commandFinder.View())
+		return s.String()
+	}
 
 	linesCount := strings.Count(s.String(), "\n")
 
 	padStyle := lipgloss.NewStyle().
 		Padding(1, 2).
-		Height(utils.TermHeight() - linesCount - m.commandInput.Height - 3).
+		Height(m.height - linesCount - m.commandInput.Height - 3).
 		MarginBottom(0)
+	message := m.Message()
 	// Render output messages
-	if m.message != "" || m.err != nil {
+	if message != "" || m.err != nil {
 		// Only add padding when there's actually content to show
-		if m.message != "" {
-			s.WriteString(padStyle.Render(m.message))
+		if message != "" {
+			s.WriteString(padStyle.Render(message))
 		}
 
 		if m.err != nil {
@@ -240,8 +446,258 @@ func (m *Model) View() string {
 	return s.String()
 }
 
-func (m *Model) UpdateTasks() {
-	tasks, err := m.taskStore.ListTasks()
+// runCommand runs the command typed as input, returning tea.Quit for exit
+func (m *Model) runCommand(input string) tea.Cmd {
+	parts := strings.Fields(input)
+	if len(parts) == 0 {
+		return nil
+	}
+
+	commandText := parts[0]
+	if commandText == "exit" {
+		return tea.Quit
+	}
+
+	if m.background != nil {
+		// Commands could conflict with the running one, e.g. delete during start
+		m.background.refused = commandText
+		return nil
+	}
+
+	for _, cmd := range m.commands {
+		if cmd.Text == commandText {
+			if cmd.Background && cmd.Action != nil {
+				return m.RunInBackground(cmd, input)
+			}
+			// Execute the command's action.
+			if cmd.Action != nil {
+				// Pass the raw input so commands like add can keep newlines and spacing
+				output := cmd.Action(input, m)
+				// Commands that open the viewport report errors but not success
+				if !m.viewingViewport {
+					m.message = output
+				}
+			}
+			// After action, refresh tasks immediately.
+			tasks, err := boardTasks(m.taskStore)
+			if err != nil {
+				m.err = err
+			} else {
+				m.tasks = utils.PointerSliceToValueSlice(tasks)
+			}
+			after := m.afterCommand
+			m.afterCommand = nil
+			return after
+		}
+	}
+	//m.err = fmt.Errorf("command not found: %q", commandText)
+	m.message = "Command not found: " + parts[0]
+	return nil
+}
+
+// OpenCommandFinder shows the Ctrl+P command finder below the board
+func (m *Model) OpenCommandFinder() {
+	entries := make([]commandFinder.Entry, 0, len(m.commands))
+	for _, cmd := range m.commands {
+		entries = append(entries, commandFinder.Entry{Name: cmd.Text, Usage: cmd.Usage, Description: cmd.Description})
+	}
+	finder := commandFinder.NewModel(entries)
+	finder, _ = finder.Update(tea.WindowSizeMsg{Width: m.width, Height: m.height})
+	m.commandFinder = &finder
+}
+
+// updateCommandFinder opens the command finder on Ctrl+P, routes input to it while
+// open and handles it closing, reporting whether the message was used
+func (m *Model) updateCommandFinder(msg tea.Msg) (bool, tea.Cmd) {
+	switch msg := msg.(type) {
+	case commandFinder.CancelMsg:
+		m.commandFinder = nil
+		return true, nil
+	case commandFinder.SelectMsg:
+		m.commandFinder = nil
+		// Commands that need arguments are left in the input to be completed
+		if commandFinder.TakesArguments(msg.Entry) {
+			m.commandInput.SetValue(msg.Entry.Name + " ")
+			m.message = msg.Entry.Name + " " + msg.Entry.Usage + " - " + msg.Entry.Description
+			if m.takesTaskRef(msg.Entry.Name) {
+				m.message += "\nRefs are shown to the left of each task, or by 'list'."
+			}
+			return true, nil
+		}
+		m.err = nil
+		m.recordHistory(msg.Entry.Name)
+		return true, m.runCommand(msg.Entry.Name)
+	case tea.KeyMsg:
+		if m.commandFinder == nil {
+			if msg.Type != commandFinder.OPEN_KEY || m.viewingViewport {
+				return false, nil
+			}
+			m.OpenCommandFinder()
+			return true, nil
+		}
+		finder, cmd := m.commandFinder.Update(msg)
+		m.commandFinder = &finder
+		return true, cmd
+	case tea.WindowSizeMsg:
+		if m.commandFinder != nil {
+			finder, _ := m.commandFinder.Update(msg)
+			m.commandFinder = &finder
+		}
+	}
+	return false, nil
+}
+
+// Confirm asks a y/n question in the message area, running action if the next key
+// is y. It returns the question, for commands to show as their output.
+func (m *Model) Confirm(prompt string, action func() string) string {
+	m.confirmation = &confirmation{prompt: prompt + " (y/n)", action: action}
+	return m.confirmation.prompt
+}
+
+// Confirmation returns the question waiting for an answer, "" if there is none
+func (m *Model) Confirmation() string {
+	if m.confirmation == nil {
+		return ""
+	}
+	return m.confirmation.prompt
+}
+
+// Message returns the text shown above the command input, which is the progress
+// of the background command while one runs
+func (m *Model) Message() string {
+	if m.background != nil {
+		return m.backgroundStatus()
+	}
+	return m.message
+}
+
+// updateConfirmation answers a pending question with the next keypress: y runs its
+// action and anything else cancels it. It reports whether the message was used.
+func (m *Model) updateConfirmation(msg tea.Msg) (bool, tea.Cmd) {
+	key, ok := msg.(tea.KeyMsg)
+	if !ok || m.confirmation == nil {
+		return false, nil
+	}
+	pending := m.confirmation
+	m.confirmation = nil
+	if key.String() != "y" && key.String() != "Y" {
+		m.message = "Cancelled"
+		return true, nil
+	}
+	m.message = pending.action()
+	return true, func() tea.Msg { return refreshMsg{} }
+}
+
+// takesTaskRef reports whether the named command's first argument is a task ref
+func (m *Model) takesTaskRef(name string) bool {
+	for _, cmd := range m.commands {
+		if cmd.Text == name {
+			return cmd.TakesTaskRef
+		}
+	}
+	return false
+}
+
+// installUpdate updates the binary off the UI thread once the update command's
+// action returns, reporting the outcome in the message area since the TUI hides
+// anything printed to stdout
+func (m *Model) installUpdate() {
+	version := m.version
+	m.afterCommand = func() tea.Msg {
+		result, err := updater.Install(version)
+		switch {
+		case err != nil:
+			return updateResultMsg("Update failed: " + err.Error())
+		case result.Version == "":
+			return updateResultMsg("Already on the latest version (" + version + ").")
+		case result.Pending:
+			return updateResultMsg("Update to " + result.Version + " downloaded. Restart Ludwig to apply it.")
+		default:
+			return updateResultMsg("Updated to " + result.Version + " and verified the new binary. Restart Ludwig to use it.")
+		}
+	}
+}
+
+// OpenTaskForm shows the task creation form in place of the board
+func (m *Model) OpenTaskForm() {
+	form := taskForm.NewModel()
+	form, _ = form.Update(tea.WindowSizeMsg{Width: m.width, Height: m.height})
+	m.taskForm = &form
+}
+
+// updateTaskForm routes input to the open task form and handles it closing,
+// reporting whether the message was used
+func (m *Model) updateTaskForm(msg tea.Msg) (bool, tea.Cmd) {
+	switch msg := msg.(type) {
+	case taskForm.CancelMsg:
+		m.taskForm = nil
+		m.message = "Cancelled new task"
+		return true, nil
+	case taskForm.SubmitMsg:
+		m.taskForm = nil
+		if err := m.taskStore.AddTask(msg.Task); err != nil {
+			m.message = "Error adding new task: " + err.Error()
+			return true, nil
+		}
+		m.message = "Added new task: " + msg.Task.Title()
+		return true, m.UpdateTasks()
+	case tea.KeyMsg:
+		if m.taskForm == nil {
+			return false, nil
+		}
+		form, cmd := m.taskForm.Update(msg)
+		m.taskForm = &form
+		return true, cmd
+	case tea.WindowSizeMsg:
+		if m.taskForm != nil {
+			form, _ := m.taskForm.Update(msg)
+			m.taskForm = &form
+		}
+	}
+	// Everything else, including resizes, still reaches the rest of the model
+	return false, nil
+}
+
+// recallHistory replaces a single-line input with an older (Up) or newer (Down)
+// command, reporting whether the key was used
+func (m *Model) recallHistory(key tea.KeyType) bool {
+	if m.history == nil || (key != tea.KeyUp && key != tea.KeyDown) {
+		return false
+	}
+	current := m.commandInput.TextInput.Value()
+	if strings.Contains(current, "\n") {
+		return false
+	}
+	var recalled string
+	var ok bool
+	if key == tea.KeyUp {
+		recalled, ok = m.history.Previous(current)
+	} else {
+		recalled, ok = m.history.Next()
+	}
+	if ok {
+		m.commandInput.SetValue(recalled)
+	}
+	return ok
+}
+
+// recordHistory adds a submitted command to the history, saving it if configured
+func (m *Model) recordHistory(input string) {
+	if m.history == nil {
+		return
+	}
+	m.history.Add(input)
+	if m.saveHistory {
+		if err := m.history.Save(); err != nil {
+			m.err = fmt.Errorf("could not save command history: %w", err)
+		}
+	}
+}
+
+// UpdateTasks reloads the board and the task being viewed, returning the command
+// that keeps a followed task's view updated when it moves on to a new run
+func (m *Model) UpdateTasks() tea.Cmd {
+	tasks, err := boardTasks(m.taskStore)
 	if err != nil {
 		m.err = err
 	} else {
@@ -249,16 +705,42 @@ func (m *Model) UpdateTasks() {
 	}
 
 	if m.taskViewport.ViewingTask == nil {
-		return
+		return nil
 	}
 	// Refresh the viewing task details if in viewport mode
 	updatedTask, err := m.taskStore.GetTask(m.taskViewport.ViewingTask.ID)
 	if err != nil {
 		m.err = err
-		return
+		return nil
 	}
 	if updatedTask != nil {
-		m.taskViewport.ViewingTask = updatedTask
+		m.taskViewport.UpdateViewingTask(updatedTask)
+	}
+	if m.viewingViewport && m.taskViewport.Following() {
+		return m.updateFollow()
 	}
+	return nil
+}
 
+// updateFollow keeps the follow view on the followed task's newest run, and returns
+// to the board once the task has finished
+func (m *Model) updateFollow() tea.Cmd {
+	t := m.taskViewport.ViewingTask
+	if !isFollowable(t.Status) {
+		m.viewingViewport = false
+		m.taskViewport.Close()
+		m.message = "Finished following " + t.Name + ": it is now " + task.StatusString(*t) + ". Use 'view' to see its output again."
+		return nil
+	}
+	responses, err := responseFiles(*t)
+	if err != nil || len(responses) == 0 {
+		return nil
+	}
+	if newest := responses[len(responses)-1]; newest != m.taskViewport.ResponseFile() {
+		// A retry started a new run
+		m.taskViewport = *m.taskViewport.SetViewingTask(t, newest)
+		m.taskViewport.Follow()
+		return m.taskViewport.ViewportUpdateLoop()
+	}
+	return nil
 }
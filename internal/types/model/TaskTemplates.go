@@ -0,0 +1,131 @@
+package model
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"text/template"
+	"time"
+
+	"ludwig/internal/config"
+	"ludwig/internal/storage"
+	"ludwig/internal/types/task"
+
+	"github.com/google/uuid"
+)
+
+// templateData is what a task template's description can refer to
+type templateData struct {
+	Input string // The text given after the template's name
+}
+
+// RenderTaskTemplate fills in a template's description with input. A description
+// without a placeholder has the input added below it, as rerun does with extra
+// instructions.
+func RenderTaskTemplate(tmpl config.TaskTemplate, input string) (string, error) {
+	input = strings.TrimSpace(input)
+	if !strings.Contains(tmpl.Description, "{{") {
+		if input == "" {
+			return strings.TrimSpace(tmpl.Description), nil
+		}
+		return strings.TrimSpace(tmpl.Description) + "\n\n" + input, nil
+	}
+	parsed, err := template.New("task").Parse(tmpl.Description)
+	if err != nil {
+		return "", err
+	}
+	var b strings.Builder
+	if err := parsed.Execute(&b, templateData{Input: input}); err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(b.String()), nil
+}
+
+// templateCommand runs "template use", "template save" and "template list"
+func templateCommand(taskStore *storage.FileTaskStorage, text string) string {
+	parts := strings.Fields(text)
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		return "Error loading config: " + err.Error()
+	}
+	if cfg == nil {
+		cfg = &config.Config{}
+	}
+
+	switch parts[1] {
+	case "list":
+		if len(cfg.TaskTemplates) == 0 {
+			return "No task templates. Save one with 'template save <name> <task ref>'."
+		}
+		names := make([]string, 0, len(cfg.TaskTemplates))
+		for name := range cfg.TaskTemplates {
+			names = append(names, name)
+		}
+		slices.Sort(names)
+		lines := []string{"Task templates:"}
+		for _, name := range names {
+			title, _, _ := strings.Cut(cfg.TaskTemplates[name].Description, "\n")
+			lines = append(lines, "  "+name+": "+title)
+		}
+		return strings.Join(lines, "\n")
+
+	case "use":
+		if len(parts) < 3 {
+			return "Usage: template use <name> [text]"
+		}
+		tmpl, ok := cfg.TaskTemplates[parts[2]]
+		if !ok {
+			return "No task template named " + parts[2] + ". Run 'template list' to see them."
+		}
+		// Keep everything after the name as typed, including newlines
+		input := strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(text), parts[0]))
+		input = strings.TrimSpace(strings.TrimPrefix(input, parts[1]))
+		input = strings.TrimPrefix(input, parts[2])
+		name, err := RenderTaskTemplate(tmpl, input)
+		if err != nil {
+			return "Error filling in template " + parts[2] + ": " + err.Error()
+		}
+		if name == "" {
+			return "Template " + parts[2] + " gives an empty description."
+		}
+		newTask := &task.Task{
+			ID:        uuid.New().String(),
+			Name:      name,
+			Status:    task.Pending,
+			CreatedAt: time.Now(),
+			Priority:  tmpl.Priority,
+			Tags:      append([]string(nil), tmpl.Tags...),
+		}
+		if err := taskStore.AddTask(newTask); err != nil {
+			return "Error adding new task: " + err.Error()
+		}
+		return "Added " + newTask.ShortID() + " from template " + parts[2] + ": " + newTask.Title()
+
+	case "save":
+		if len(parts) != 4 {
+			return "Usage: template save <name> <task ref>"
+		}
+		source, err := ResolveTaskRef(taskStore, parts[3], storage.ListOptions{})
+		if err != nil {
+			return "Invalid task ref: " + err.Error()
+		}
+		if cfg.TaskTemplates == nil {
+			cfg.TaskTemplates = map[string]config.TaskTemplate{}
+		}
+		_, replaced := cfg.TaskTemplates[parts[2]]
+		cfg.TaskTemplates[parts[2]] = config.TaskTemplate{
+			Description: source.Name,
+			Tags:        append([]string(nil), source.Tags...),
+			Priority:    source.Priority,
+		}
+		if err := config.SaveConfig(cfg); err != nil {
+			return "Error saving config: " + err.Error()
+		}
+		verb := "Saved"
+		if replaced {
+			verb = "Replaced"
+		}
+		return fmt.Sprintf("%s template %s from task %s. Add {{.Input}} to its description in config.json to choose where 'template use' puts its text.", verb, parts[2], source.ShortID())
+	}
+	return "Unknown template action " + parts[1] + ". Use list, use or save."
+}
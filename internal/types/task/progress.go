@@ -0,0 +1,86 @@
+package task
+
+import "strings"
+
+// ProgressItem is one line of an AI's work-in-progress report
+type ProgressItem struct {
+	Done bool   // Marked finished ("✓", "Done:", "[x]")
+	Note bool   // Not a checklist line; kept as plain text
+	Text string // The line without its marker
+}
+
+// Markers the system prompt asks the AI to use, plus common variations.
+// Longer markers come first so "✓ Completed:" wins over "✓".
+var (
+	doneMarkers    = []string{"✓ Completed:", "✔ Completed:", "- [x]", "- [X]", "[x]", "[X]", "Done:", "Completed:", "✓", "✔", "✅"}
+	pendingMarkers = []string{"• Pending:", "- [ ]", "[ ]", "Pending:", "•"}
+)
+
+// ParseProgress splits work-in-progress text into checklist items. Lines without
+// a marker become notes, and blank lines are dropped.
+func ParseProgress(text string) []ProgressItem {
+	var items []ProgressItem
+	for _, line := range strings.Split(text, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		if rest, ok := cutMarker(line, doneMarkers); ok {
+			items = append(items, ProgressItem{Done: true, Text: rest})
+		} else if rest, ok := cutMarker(line, pendingMarkers); ok {
+			items = append(items, ProgressItem{Text: rest})
+		} else {
+			items = append(items, ProgressItem{Note: true, Text: line})
+		}
+	}
+	return items
+}
+
+// CountProgress returns how many checklist items are done out of the total, ignoring notes
+func CountProgress(items []ProgressItem) (done, total int) {
+	for _, item := range items {
+		if item.Note {
+			continue
+		}
+		total++
+		if item.Done {
+			done++
+		}
+	}
+	return done, total
+}
+
+// FinalSummary returns the finished items of the last checklist in text, one per
+// line, or "" if it has none. The AI reports progress as it goes, so only the last
+// report describes everything that was done.
+func FinalSummary(text string) string {
+	var block, last []string
+	for _, item := range ParseProgress(text) {
+		if item.Done {
+			block = append(block, item.Text)
+			continue
+		}
+		if item.Note && len(block) > 0 {
+			last, block = block, nil
+		}
+	}
+	if len(block) > 0 {
+		last = block
+	}
+	return strings.Join(last, "\n")
+}
+
+// cutMarker strips the first matching marker from line. A marker alone with no
+// text after it doesn't count, so stray bullets stay as notes.
+func cutMarker(line string, markers []string) (string, bool) {
+	for _, marker := range markers {
+		if rest, ok := strings.CutPrefix(line, marker); ok {
+			rest = strings.TrimSpace(rest)
+			if rest == "" {
+				return "", false
+			}
+			return rest, true
+		}
+	}
+	return "", false
+}
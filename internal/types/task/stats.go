@@ -0,0 +1,62 @@
+package task
+
+import (
+	"time"
+)
+
+// Stats is an aggregate summary of a set of tasks
+type Stats struct {
+	Total             int
+	ByStatus          map[Status]int
+	AverageCompletion time.Duration // Mean StartedAt → CompletedAt for completed tasks
+	SuccessRate       float64       // Completed runs / (completed + failed runs), 0 if none
+	CompletedToday    int
+	FailedRuns        int
+}
+
+// ComputeStats aggregates tasks into a Stats summary
+// now is passed in so "completed today" is deterministic in tests
+func ComputeStats(tasks []Task, now time.Time) Stats {
+	stats := Stats{
+		Total: len(tasks),
+		ByStatus: map[Status]int{
+			Pending:     0,
+			InProgress:  0,
+			NeedsReview: 0,
+			Completed:   0,
+			Failed:      0,
+		},
+	}
+
+	var totalDuration time.Duration
+	timedTasks := 0
+	year, month, day := now.Date()
+
+	for _, t := range tasks {
+		stats.ByStatus[t.Status]++
+		stats.FailedRuns += t.Failures
+
+		if t.Status != Completed {
+			continue
+		}
+		if !t.StartedAt.IsZero() && !t.CompletedAt.IsZero() {
+			totalDuration += t.CompletedAt.Sub(t.StartedAt)
+			timedTasks++
+		}
+		completedYear, completedMonth, completedDay := t.CompletedAt.In(now.Location()).Date()
+		if !t.CompletedAt.IsZero() && completedYear == year && completedMonth == month && completedDay == day {
+			stats.CompletedToday++
+		}
+	}
+
+	if timedTasks > 0 {
+		stats.AverageCompletion = totalDuration / time.Duration(timedTasks)
+	}
+
+	runs := stats.ByStatus[Completed] + stats.FailedRuns
+	if runs > 0 {
+		stats.SuccessRate = float64(stats.ByStatus[Completed]) / float64(runs)
+	}
+
+	return stats
+}
@@ -0,0 +1,84 @@
+package task
+
+import (
+	"encoding/json"
+	"strings"
+	"sync"
+)
+
+// FIRST_CUSTOM_STATUS is the first value given to a custom status. Lower values
+// are reserved for the built-in statuses the orchestrator works with.
+const FIRST_CUSTOM_STATUS Status = 100
+
+var (
+	customMu       sync.Mutex
+	customStatuses = map[string]Status{} // By normalized name
+	customNames    = map[Status]string{} // As first written
+)
+
+// normalizeStatusName makes status names compare ignoring case, spaces, hyphens
+// and underscores
+func normalizeStatusName(name string) string {
+	return strings.NewReplacer(" ", "", "-", "", "_", "").Replace(strings.ToLower(strings.TrimSpace(name)))
+}
+
+// CustomStatus returns the status named name, such as "Blocked" or "Testing",
+// creating it on first use. The orchestrator never picks up tasks in a custom
+// status, so they stay put until moved. Values are only stable within one run;
+// tasks.json stores custom statuses by name.
+func CustomStatus(name string) Status {
+	key := normalizeStatusName(name)
+	customMu.Lock()
+	defer customMu.Unlock()
+	if status, ok := customStatuses[key]; ok {
+		return status
+	}
+	status := FIRST_CUSTOM_STATUS + Status(len(customStatuses))
+	customStatuses[key] = status
+	customNames[status] = strings.TrimSpace(name)
+	return status
+}
+
+// IsCustom reports whether the status is a custom one rather than built in
+func (s Status) IsCustom() bool {
+	return s >= FIRST_CUSTOM_STATUS
+}
+
+// customName returns the name of a custom status, or "" if there is none
+func customName(s Status) string {
+	customMu.Lock()
+	defer customMu.Unlock()
+	return customNames[s]
+}
+
+// lookupCustomStatus finds an existing custom status by name
+func lookupCustomStatus(name string) (Status, bool) {
+	customMu.Lock()
+	defer customMu.Unlock()
+	status, ok := customStatuses[normalizeStatusName(name)]
+	return status, ok
+}
+
+// MarshalJSON writes built-in statuses as their number, as they always have been,
+// and custom statuses as their name so they survive the config changing
+func (s Status) MarshalJSON() ([]byte, error) {
+	if name := customName(s); s.IsCustom() && name != "" {
+		return json.Marshal(name)
+	}
+	return json.Marshal(int(s))
+}
+
+// UnmarshalJSON reads a status written by MarshalJSON
+func (s *Status) UnmarshalJSON(data []byte) error {
+	var name string
+	if err := json.Unmarshal(data, &name); err == nil {
+		*s = CustomStatus(name)
+		return nil
+	}
+	var number int
+	if err := json.Unmarshal(data, &number); err != nil {
+		return err
+	}
+	*s = Status(number)
+	return nil
+}
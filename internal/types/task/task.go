@@ -2,6 +2,8 @@ package task
 
 import (
 	"fmt"
+	"path/filepath"
+	"strings"
 	"time"
 )
 
@@ -12,6 +14,7 @@ const (
 	InProgress
 	NeedsReview
 	Completed
+	Failed
 )
 
 type Task struct {
@@ -19,13 +22,44 @@ type Task struct {
 	Name      string
 	Status    Status
 	CreatedAt time.Time
+	Priority  int      // Higher values are more urgent
+	Tags      []string // Free-form labels for grouping and filtering
 
 	BranchName     string // Git branch created for this task
 	WorktreePath   string // Path to the git worktree directory for this task
+	SubPath        string // Directory within the repo the task is scoped to, "" for the whole repo
+	BaseBranch     string // Branch the task's branch starts from, "" for main
+	Model          string // AI model the task runs with, "" to let the orchestrator choose
 	WorkInProgress string // Stores intermediate work before requesting review
 	Review         *ReviewRequest
 	ReviewResponse *ReviewResponse
 	ResponseFile   string // Path to file containing AI response stream
+
+	StartedAt     time.Time // When the orchestrator first picked the task up
+	CompletedAt   time.Time // When the task reached Completed
+	Failures      int       // Number of AI runs that ended in an error
+	FailureReason string    // Why the task ended Failed, cleared when it moves on
+	Summary       string    // Short description of what the task did, set when it completes
+
+	ClonedFrom string // ID of the task this one was re-run from, "" if it's an original
+
+	History  []HistoryEntry // Manual status changes, oldest first
+	Comments []Comment      // Notes left on the task by users, oldest first
+	Archived bool           // Hidden from the board and skipped by the orchestrator, but kept in storage
+}
+
+// HistoryEntry records a status change made outside the orchestrator
+type HistoryEntry struct {
+	At   time.Time
+	From Status
+	To   Status
+	Note string
+}
+
+// Comment is a note left on a task. Unlike ReviewResponse.UserNotes it isn't sent to the AI.
+type Comment struct {
+	Text string
+	At   time.Time
 }
 
 type ReviewRequest struct {
@@ -33,6 +67,7 @@ type ReviewRequest struct {
 	Options   []ReviewOption
 	Context   string
 	CreatedAt time.Time
+	DiffStat  string // `git diff --stat` of the work so far when the review was asked for
 }
 
 type ReviewOption struct {
@@ -47,6 +82,127 @@ type ReviewResponse struct {
 	RespondedAt    time.Time
 }
 
+// SHORT_ID_LENGTH is how many leading characters of the ID make up the short ID
+const SHORT_ID_LENGTH = 6
+
+// ShortID returns a short, stable identifier for display and refs.
+// Unlike a board position it doesn't change when other tasks are added or deleted.
+func (t Task) ShortID() string {
+	if len(t.ID) <= SHORT_ID_LENGTH {
+		return t.ID
+	}
+	return t.ID[:SHORT_ID_LENGTH]
+}
+
+// Title returns the first line of the task's name, for places that only have room for one line
+func (t Task) Title() string {
+	title, _, _ := strings.Cut(t.Name, "\n")
+	return strings.TrimSpace(title)
+}
+
+// WorkDir returns the directory the AI runs in: the worktree, or SubPath within it
+func (t Task) WorkDir() string {
+	if t.WorktreePath == "" || t.SubPath == "" {
+		return t.WorktreePath
+	}
+	return filepath.Join(t.WorktreePath, t.SubPath)
+}
+
+// CleanSubPath normalises a user-supplied sub path, rejecting ones that are absolute
+// or climb out of the repo. The repo root itself ("", "." or "/") becomes "".
+func CleanSubPath(subPath string) (string, error) {
+	subPath = strings.TrimSpace(subPath)
+	if subPath == "" || subPath == "/" {
+		return "", nil
+	}
+	if filepath.IsAbs(subPath) {
+		return "", fmt.Errorf("sub path %q must be relative to the repo root", subPath)
+	}
+	cleaned := filepath.Clean(subPath)
+	if cleaned == ".." || strings.HasPrefix(cleaned, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("sub path %q is outside the repo", subPath)
+	}
+	if cleaned == "." {
+		return "", nil
+	}
+	return cleaned, nil
+}
+
+// MoveTo sets the task's status and records the change in its history
+func (t *Task) MoveTo(status Status, note string) {
+	t.History = append(t.History, HistoryEntry{
+		At:   time.Now(),
+		From: t.Status,
+		To:   status,
+		Note: note,
+	})
+	t.Status = status
+	if status != Failed {
+		t.FailureReason = ""
+	}
+	if status == Completed {
+		t.CompletedAt = time.Now()
+	}
+}
+
+// Rerun returns a fresh Pending copy of the task's description, tags, priority,
+// scope, base branch and model with the given ID. Run state (branch, worktree, output, review, history) isn't
+// copied, so the copy gets its own branch when the orchestrator picks it up.
+func (t Task) Rerun(id string) *Task {
+	return &Task{
+		ID:         id,
+		Name:       t.Name,
+		Status:     Pending,
+		CreatedAt:  time.Now(),
+		Priority:   t.Priority,
+		Tags:       append([]string(nil), t.Tags...),
+		SubPath:    t.SubPath,
+		BaseBranch: t.BaseBranch,
+		Model:      t.Model,
+		ClonedFrom: t.ID,
+	}
+}
+
+// AddComment appends a comment to the task, timestamped now
+func (t *Task) AddComment(text string) {
+	t.Comments = append(t.Comments, Comment{Text: text, At: time.Now()})
+}
+
+// ParseStatus converts a user-supplied status name into a Status.
+// Matching ignores case, spaces, hyphens and underscores, and accepts the
+// kanban column titles ("To Do", "In Review") as well as the status names.
+// Custom statuses are found by name once they have been created.
+func ParseStatus(name string) (Status, error) {
+	normalized := normalizeStatusName(name)
+	switch normalized {
+	case "pending", "todo":
+		return Pending, nil
+	case "inprogress":
+		return InProgress, nil
+	case "needsreview", "inreview", "review":
+		return NeedsReview, nil
+	case "completed", "done":
+		return Completed, nil
+	case "failed":
+		return Failed, nil
+	default:
+		if status, ok := lookupCustomStatus(name); ok {
+			return status, nil
+		}
+		return Pending, fmt.Errorf("unknown status %q (expected Pending, InProgress, NeedsReview, Completed, Failed or a custom status)", name)
+	}
+}
+
+// HasTag reports whether the task carries the given tag, ignoring case
+func (t Task) HasTag(tag string) bool {
+	for _, existing := range t.Tags {
+		if strings.EqualFold(existing, tag) {
+			return true
+		}
+	}
+	return false
+}
+
 func StatusString(task Task) string {
 	switch task.Status {
 	case Pending:
@@ -57,7 +213,12 @@ func StatusString(task Task) string {
 		return "In Review"
 	case Completed:
 		return "Completed"
+	case Failed:
+		return "Failed"
 	default:
+		if name := customName(task.Status); name != "" {
+			return name
+		}
 		return "Unknown"
 	}
 }
@@ -0,0 +1,94 @@
+package task
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// Spec describes a task to create, as read from a tasks file by ParseTaskList
+type Spec struct {
+	Name         string   `json:"name"`
+	Instructions string   `json:"instructions"` // Added to the name after a blank line, so the board shows just the name
+	Tags         []string `json:"tags"`
+	Priority     int      `json:"priority"`
+	Model        string   `json:"model"`
+}
+
+// NewTask returns a Pending task made from the spec with the given ID
+func (s Spec) NewTask(id string) *Task {
+	name := strings.TrimSpace(s.Name)
+	if instructions := strings.TrimSpace(s.Instructions); instructions != "" {
+		name += "\n\n" + instructions
+	}
+	return &Task{
+		ID:        id,
+		Name:      name,
+		Status:    Pending,
+		CreatedAt: time.Now(),
+		Priority:  s.Priority,
+		Tags:      append([]string(nil), s.Tags...),
+		Model:     strings.TrimSpace(s.Model),
+	}
+}
+
+// ParseTaskList reads the tasks to create from r. The input is either a JSON list of
+// specs, or one task per line: a line starting with "{" is a JSON spec and any other
+// is the task's name. Blank lines and lines starting with "#" or "//" are skipped.
+// Lines that can't be read are left out and reported, one error each, so the rest
+// can still be created.
+func ParseTaskList(r io.Reader) ([]Spec, []error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, []error{err}
+	}
+	if trimmed := bytes.TrimSpace(data); bytes.HasPrefix(trimmed, []byte("[")) {
+		var specs []Spec
+		if err := json.Unmarshal(trimmed, &specs); err != nil {
+			return nil, []error{fmt.Errorf("invalid JSON list: %w", err)}
+		}
+		var valid []Spec
+		var errs []error
+		for i, spec := range specs {
+			if strings.TrimSpace(spec.Name) == "" {
+				errs = append(errs, fmt.Errorf("item %d: name is required", i+1))
+				continue
+			}
+			valid = append(valid, spec)
+		}
+		return valid, errs
+	}
+
+	var specs []Spec
+	var errs []error
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(nil, len(data)+1) // A line is never longer than the input
+	for lineNum := 1; scanner.Scan(); lineNum++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, "//") {
+			continue
+		}
+		if !strings.HasPrefix(line, "{") {
+			specs = append(specs, Spec{Name: line})
+			continue
+		}
+		var spec Spec
+		if err := json.Unmarshal([]byte(line), &spec); err != nil {
+			errs = append(errs, fmt.Errorf("line %d: invalid JSON: %w", lineNum, err))
+			continue
+		}
+		if strings.TrimSpace(spec.Name) == "" {
+			errs = append(errs, fmt.Errorf("line %d: name is required", lineNum))
+			continue
+		}
+		specs = append(specs, spec)
+	}
+	if err := scanner.Err(); err != nil {
+		errs = append(errs, err)
+	}
+	return specs, errs
+}
@@ -0,0 +1,74 @@
+package updater
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// Timeouts and retries for requests to GitHub. A release archive gets longer than
+// an API call since it can be hundreds of megabytes on a slow connection.
+const (
+	API_TIMEOUT         = 15 * time.Second
+	DOWNLOAD_TIMEOUT    = 10 * time.Minute
+	DEFAULT_MAX_RETRIES = 2
+	DEFAULT_RETRY_DELAY = time.Second
+)
+
+// Fetcher makes HTTP GET requests with a timeout, retrying connection errors and
+// server-side failures (5xx and 429) with exponential backoff
+type Fetcher struct {
+	Client     *http.Client
+	MaxRetries int
+	BaseDelay  time.Duration
+	Sleep      func(time.Duration) // Defaults to time.Sleep; tests replace it
+}
+
+// NewFetcher returns a Fetcher whose requests give up after timeout, retrying
+// with the default backoff
+func NewFetcher(timeout time.Duration) *Fetcher {
+	return &Fetcher{
+		Client:     &http.Client{Timeout: timeout},
+		MaxRetries: DEFAULT_MAX_RETRIES,
+		BaseDelay:  DEFAULT_RETRY_DELAY,
+		Sleep:      time.Sleep,
+	}
+}
+
+// apiFetcher is used for release metadata, downloadFetcher for release archives
+var (
+	apiFetcher      = NewFetcher(API_TIMEOUT)
+	downloadFetcher = NewFetcher(DOWNLOAD_TIMEOUT)
+)
+
+// Get fetches url, returning the last error or response once retries run out.
+// The caller closes the response body.
+func (f *Fetcher) Get(url string) (*http.Response, error) {
+	sleep := f.Sleep
+	if sleep == nil {
+		sleep = time.Sleep
+	}
+	delay := f.BaseDelay
+	for attempt := 0; ; attempt++ {
+		resp, err := f.Client.Get(url)
+		if !retryable(resp, err) || attempt >= f.MaxRetries {
+			if err != nil && attempt > 0 {
+				err = fmt.Errorf("%w (after %d attempts)", err, attempt+1)
+			}
+			return resp, err
+		}
+		if resp != nil {
+			resp.Body.Close()
+		}
+		sleep(delay)
+		delay *= 2
+	}
+}
+
+// retryable reports whether a request might succeed if made again
+func retryable(resp *http.Response, err error) bool {
+	if err != nil {
+		return true
+	}
+	return resp.StatusCode >= 500 || resp.StatusCode == http.StatusTooManyRequests
+}
@@ -4,18 +4,32 @@ import (
 	"archive/tar"
 	"archive/zip"
 	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
 	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
 	"runtime"
+	"strconv"
 	"strings"
+	"time"
+
+	"ludwig/internal/config"
 )
 
 type Release struct {
-	TagName string  `json:"tag_name"`
-	Assets  []Asset `json:"assets"`
+	TagName    string  `json:"tag_name"`
+	Prerelease bool    `json:"prerelease"`
+	Draft      bool    `json:"draft"`
+	Assets     []Asset `json:"assets"`
 }
 
 type Asset struct {
@@ -23,107 +37,236 @@ type Asset struct {
 	DownloadURL string `json:"browser_download_url"`
 }
 
+// Where releases come from unless the updateOwner, updateRepo and updateAPIURL
+// config options say otherwise
 const (
-	repoOwner = "AlexanderHeffernan"
-	repoName  = "Ludwig-AI"
-	apiURL    = "https://api.github.com/repos/" + repoOwner + "/" + repoName + "/releases/latest"
+	DEFAULT_REPO_OWNER = "AlexanderHeffernan"
+	DEFAULT_REPO_NAME  = "Ludwig-AI"
+	DEFAULT_API_URL    = "https://api.github.com"
 )
 
-// GetLatestVersion fetches the latest release version from GitHub
-func GetLatestVersion() (string, error) {
-	resp, err := http.Get(apiURL)
+// DEFAULT_MAX_DOWNLOAD_BYTES is the largest release archive downloaded unless the
+// maxUpdateBytes config option says otherwise
+const DEFAULT_MAX_DOWNLOAD_BYTES = 200 << 20
+
+// Update channels, chosen with the updateChannel config option
+const (
+	CHANNEL_STABLE = "stable" // Full releases only (default)
+	CHANNEL_BETA   = "beta"   // Pre-releases as well
+)
+
+// Source is where updates are fetched from
+type Source struct {
+	Channel          string // CHANNEL_STABLE or CHANNEL_BETA
+	ReleasesURL      string // GitHub API URL listing the repository's releases
+	MaxDownloadBytes int64  // Largest archive to download, 0 for no limit
+}
+
+// CurrentSource returns the update source configured for the current project
+func CurrentSource() (Source, error) {
+	cfg, err := config.LoadConfig()
 	if err != nil {
-		return "", fmt.Errorf("failed to fetch latest release: %w", err)
+		return Source{}, err
 	}
-	defer resp.Body.Close()
+	return SourceFor(cfg)
+}
 
-	if resp.StatusCode != http.StatusOK {
-		return "", fmt.Errorf("GitHub API returned status %d", resp.StatusCode)
+// SourceFor returns the update source set by cfg, which may be nil for the defaults
+func SourceFor(cfg *config.Config) (Source, error) {
+	if cfg == nil {
+		cfg = &config.Config{}
+	}
+	source := Source{Channel: cfg.UpdateChannel, MaxDownloadBytes: cfg.MaxUpdateBytes}
+	if source.Channel == "" {
+		source.Channel = CHANNEL_STABLE
+	}
+	switch {
+	case source.MaxDownloadBytes == 0:
+		source.MaxDownloadBytes = DEFAULT_MAX_DOWNLOAD_BYTES
+	case source.MaxDownloadBytes < 0:
+		source.MaxDownloadBytes = 0
 	}
 
-	var release Release
-	if err := json.NewDecoder(resp.Body).Decode(&release); err != nil {
-		return "", fmt.Errorf("failed to parse release: %w", err)
+	owner, repo, apiURL := cfg.UpdateOwner, cfg.UpdateRepo, cfg.UpdateAPIURL
+	if owner == "" {
+		owner = DEFAULT_REPO_OWNER
 	}
+	if repo == "" {
+		repo = DEFAULT_REPO_NAME
+	}
+	if apiURL == "" {
+		apiURL = DEFAULT_API_URL
+	}
+	for _, name := range []string{owner, repo} {
+		if strings.ContainsAny(name, "/?# ") {
+			return Source{}, fmt.Errorf("invalid update repository %q", owner+"/"+repo)
+		}
+	}
+	parsed, err := url.Parse(apiURL)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return Source{}, fmt.Errorf("invalid updateAPIURL %q: expected an http(s) URL such as %s", apiURL, DEFAULT_API_URL)
+	}
+
+	source.ReleasesURL = strings.TrimSuffix(apiURL, "/") + "/repos/" + owner + "/" + repo + "/releases"
+	return source, nil
+}
 
+// GetLatestVersion fetches the latest release version on the configured channel from GitHub
+func GetLatestVersion() (string, error) {
+	source, err := CurrentSource()
+	if err != nil {
+		return "", err
+	}
+	release, err := LatestRelease(source)
+	if err != nil {
+		return "", err
+	}
 	return release.TagName, nil
 }
 
+// LatestRelease fetches the newest release on the source's channel from GitHub
+func LatestRelease(source Source) (Release, error) {
+	if source.Channel != CHANNEL_BETA {
+		var release Release
+		err := fetchJSON(source.ReleasesURL+"/latest", &release)
+		return release, err
+	}
+
+	// Unlike /releases/latest this includes pre-releases, newest first
+	var releases []Release
+	if err := fetchJSON(source.ReleasesURL, &releases); err != nil {
+		return Release{}, err
+	}
+	release, ok := SelectRelease(releases, source.Channel)
+	if !ok {
+		return Release{}, fmt.Errorf("no releases found")
+	}
+	return release, nil
+}
+
+// SelectRelease picks the newest release on channel, skipping drafts and, unless
+// channel is beta, pre-releases
+func SelectRelease(releases []Release, channel string) (Release, bool) {
+	var newest Release
+	found := false
+	for _, release := range releases {
+		if release.Draft || (release.Prerelease && channel != CHANNEL_BETA) {
+			continue
+		}
+		if !found || compareVersions(strings.TrimPrefix(release.TagName, "v"), strings.TrimPrefix(newest.TagName, "v")) > 0 {
+			newest = release
+			found = true
+		}
+	}
+	return newest, found
+}
+
+// fetchJSON decodes the GitHub API response at url into v
+func fetchJSON(url string, v any) error {
+	resp, err := apiFetcher.Get(url)
+	if err != nil {
+		return fmt.Errorf("failed to fetch latest release: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("GitHub API returned status %d", resp.StatusCode)
+	}
+	if err := json.NewDecoder(resp.Body).Decode(v); err != nil {
+		return fmt.Errorf("failed to parse release: %w", err)
+	}
+	return nil
+}
+
 // CheckForUpdate returns true if a newer version is available
 func CheckForUpdate(currentVersion string) (bool, string, error) {
 	latestVersion, err := GetLatestVersion()
 	if err != nil {
 		return false, "", err
 	}
+	return isNewer(currentVersion, latestVersion), latestVersion, nil
+}
+
+// isNewer reports whether latestVersion is newer than currentVersion
+func isNewer(currentVersion, latestVersion string) bool {
 
 	// Simple version comparison (strip 'v' prefix)
 	current := strings.TrimPrefix(currentVersion, "v")
 	latest := strings.TrimPrefix(latestVersion, "v")
 
 	// If versions are equal or current is newer, no update needed
-	if compareVersions(current, latest) >= 0 {
-		return false, "", nil
-	}
+	return compareVersions(current, latest) < 0
+}
+
+// SMOKE_TEST_TIMEOUT bounds how long a downloaded binary may take to print its version
+const SMOKE_TEST_TIMEOUT = 10 * time.Second
 
-	return true, latestVersion, nil
+// ErrNoBackup is returned by Rollback when no previous binary was kept
+var ErrNoBackup = errors.New("no previous version to roll back to")
+
+// InstallResult describes what Install did
+type InstallResult struct {
+	Version string // The version installed, "" if already up to date
+	Pending bool   // Staged as <exe>.new and swapped in on the next start, where a running binary can't be replaced
 }
 
-// DownloadAndInstall downloads the latest release and replaces the current binary
+// DownloadAndInstall downloads the latest release and replaces the current binary,
+// reporting the outcome on stdout
 func DownloadAndInstall(currentVersion string) error {
-	latestVersion, err := GetLatestVersion()
+	fmt.Println("Checking for updates...")
+	result, err := Install(currentVersion)
 	if err != nil {
 		return err
 	}
+	switch {
+	case result.Version == "":
+		fmt.Println("Already on the latest version (" + currentVersion + ")")
+	case result.Pending:
+		fmt.Println("Update to " + result.Version + " ready! Please restart Ludwig to apply.")
+	default:
+		fmt.Println("Updated to " + result.Version + "! Please restart Ludwig.")
+	}
+	return nil
+}
 
+// Install downloads the latest release and replaces the current binary with it,
+// checking the new binary is in place before reporting success. On Windows the
+// binary is only staged, to be applied by ApplyPendingUpdate on the next start.
+func Install(currentVersion string) (InstallResult, error) {
 	// Check if update is actually needed
-	isNewer, _, err := CheckForUpdate(currentVersion)
+	source, err := CurrentSource()
 	if err != nil {
-		return err
+		return InstallResult{}, err
 	}
-	if !isNewer {
-		fmt.Println("Already on the latest version (" + currentVersion + ")")
-		return nil
+	release, err := LatestRelease(source)
+	if err != nil {
+		return InstallResult{}, err
+	}
+	latestVersion := release.TagName
+	if !isNewer(currentVersion, latestVersion) {
+		return InstallResult{}, nil
 	}
 
 	// Get current executable path
 	exePath, err := os.Executable()
 	if err != nil {
-		return fmt.Errorf("failed to get executable path: %w", err)
+		return InstallResult{}, fmt.Errorf("failed to get executable path: %w", err)
 	}
-
-	// Fetch release info to find the right asset
-	resp, err := http.Get(apiURL)
-	if err != nil {
-		return fmt.Errorf("failed to fetch release: %w", err)
-	}
-	defer resp.Body.Close()
-
-	var release Release
-	if err := json.NewDecoder(resp.Body).Decode(&release); err != nil {
-		return fmt.Errorf("failed to parse release: %w", err)
+	if resolved, err := filepath.EvalSymlinks(exePath); err == nil {
+		exePath = resolved // Replace the binary itself, not a symlink to it
 	}
 
 	// Find the right asset for current OS/arch
-	osName, archName := getOSAndArch()
-	var downloadURL, assetName string
-
-	for _, asset := range release.Assets {
-		if matchesAsset(asset.Name, osName, archName) {
-			downloadURL = asset.DownloadURL
-			assetName = asset.Name
-			break
-		}
-	}
-
-	if downloadURL == "" {
-		return fmt.Errorf("no binary found for %s/%s", runtime.GOOS, runtime.GOARCH)
+	asset, ok := FindAsset(release.Assets, runtime.GOOS, runtime.GOARCH)
+	if !ok {
+		return InstallResult{}, fmt.Errorf("no binary found for %s/%s", runtime.GOOS, runtime.GOARCH)
 	}
+	downloadURL, assetName := asset.DownloadURL, asset.Name
 
 	// Download the binary
-	fmt.Println("Downloading " + latestVersion + "...")
-	newBinary, err := downloadFile(downloadURL)
+	newBinary, err := DownloadFile(downloadURL, source.MaxDownloadBytes)
 	if err != nil {
-		return err
+		return InstallResult{}, err
 	}
 	defer os.Remove(newBinary)
 
@@ -131,49 +274,204 @@ func DownloadAndInstall(currentVersion string) error {
 	extractedBinary := newBinary
 	if strings.HasSuffix(assetName, ".tar.gz") {
 		var err2 error
-		extractedBinary, err2 = extractTarGz(newBinary)
+		extractedBinary, err2 = ExtractTarGz(newBinary)
 		if err2 != nil {
-			return err2
+			return InstallResult{}, err2
 		}
 		defer os.Remove(extractedBinary)
 	} else if strings.HasSuffix(assetName, ".zip") {
 		var err2 error
-		extractedBinary, err2 = extractZip(newBinary)
+		extractedBinary, err2 = ExtractZip(newBinary)
 		if err2 != nil {
-			return err2
+			return InstallResult{}, err2
 		}
 		defer os.Remove(extractedBinary)
 	}
 
-	// Make executable
-	if err := os.Chmod(extractedBinary, 0755); err != nil {
-		return fmt.Errorf("failed to make binary executable: %w", err)
+	// A binary that can't even report its version would leave the user stuck
+	if err := SmokeTest(extractedBinary); err != nil {
+		return InstallResult{}, err
 	}
 
-	// Move to .new location (will be applied on next startup)
-	newPath := exePath + ".new"
-	if err := os.Rename(extractedBinary, newPath); err != nil {
+	// Windows won't replace a running binary, so leave it for the next start
+	if runtime.GOOS == "windows" {
+		if err := copyFile(extractedBinary, exePath+".new"); err != nil {
+			return InstallResult{}, fmt.Errorf("failed to prepare update: %w", err)
+		}
+		return InstallResult{Version: latestVersion, Pending: true}, nil
+	}
+
+	if err := ReplaceBinary(extractedBinary, exePath); err != nil {
+		return InstallResult{}, err
+	}
+	return InstallResult{Version: latestVersion}, nil
+}
+
+// ReplaceBinary puts a copy of newBinary at exePath, then checks exePath now holds
+// exactly newBinary. The copy is staged beside exePath and renamed over it, so the
+// old binary is never left half written, and the old binary is kept for Rollback.
+func ReplaceBinary(newBinary, exePath string) error {
+	want, err := FileHash(newBinary)
+	if err != nil {
+		return fmt.Errorf("failed to read new binary: %w", err)
+	}
+	stagedPath := exePath + ".new"
+	if err := copyFile(newBinary, stagedPath); err != nil {
 		return fmt.Errorf("failed to prepare update: %w", err)
 	}
+	if err := BackupBinary(exePath); err != nil {
+		os.Remove(stagedPath)
+		return err
+	}
+	if err := os.Rename(stagedPath, exePath); err != nil {
+		os.Remove(stagedPath)
+		return fmt.Errorf("failed to replace %s: %w", exePath, err)
+	}
+	return VerifyInstall(exePath, want)
+}
+
+// SmokeTest runs binary with --version and checks it reports itself as ludwig
+func SmokeTest(binary string) error {
+	if err := os.Chmod(binary, 0755); err != nil {
+		return fmt.Errorf("failed to make new binary executable: %w", err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), SMOKE_TEST_TIMEOUT)
+	defer cancel()
+
+	output, err := exec.CommandContext(ctx, binary, "--version").CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("new binary failed to run, update aborted: %w", err)
+	}
+	if !strings.HasPrefix(strings.TrimSpace(string(output)), "ludwig version") {
+		return fmt.Errorf("new binary did not report a version, update aborted: %q", strings.TrimSpace(string(output)))
+	}
+	return nil
+}
+
+// BackupBinary copies exePath to <exe>.prev, replacing any older backup
+func BackupBinary(exePath string) error {
+	if err := copyFile(exePath, exePath+".prev"); err != nil {
+		return fmt.Errorf("failed to back up current binary: %w", err)
+	}
+	return nil
+}
+
+// Rollback puts back the binary that the last update replaced. On Windows it is
+// staged as <exe>.new like an update, and pending is true.
+func Rollback() (pending bool, err error) {
+	exePath, err := os.Executable()
+	if err != nil {
+		return false, fmt.Errorf("failed to get executable path: %w", err)
+	}
+	if resolved, err := filepath.EvalSymlinks(exePath); err == nil {
+		exePath = resolved
+	}
+	if runtime.GOOS == "windows" {
+		if _, err := os.Stat(exePath + ".prev"); os.IsNotExist(err) {
+			return false, ErrNoBackup
+		}
+		if err := copyFile(exePath+".prev", exePath+".new"); err != nil {
+			return false, fmt.Errorf("failed to prepare rollback: %w", err)
+		}
+		return true, nil
+	}
+	return false, RestoreBinary(exePath)
+}
+
+// RestoreBinary moves <exe>.prev back over exePath and checks it is in place
+func RestoreBinary(exePath string) error {
+	prevPath := exePath + ".prev"
+	want, err := FileHash(prevPath)
+	if os.IsNotExist(err) {
+		return ErrNoBackup
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read previous binary: %w", err)
+	}
+	if err := os.Rename(prevPath, exePath); err != nil {
+		return fmt.Errorf("failed to restore %s: %w", exePath, err)
+	}
+	return VerifyInstall(exePath, want)
+}
 
-	fmt.Println("Update ready! Please restart Ludwig to apply.")
+// VerifyInstall checks the binary at exePath has the SHA-256 hash want
+func VerifyInstall(exePath, want string) error {
+	got, err := FileHash(exePath)
+	if err != nil {
+		return fmt.Errorf("update could not be verified: %w", err)
+	}
+	if got != want {
+		return fmt.Errorf("update did not replace %s: expected sha256 %s, found %s", exePath, want, got)
+	}
 	return nil
 }
 
-func downloadFile(url string) (string, error) {
-	resp, err := http.Get(url)
+// FileHash returns the hex SHA-256 hash of a file's contents
+func FileHash(path string) (string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+
+	hash := sha256.New()
+	if _, err := io.Copy(hash, file); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// copyFile writes src to dst as an executable, replacing dst if it exists. Unlike
+// a rename it works when the two are on different filesystems, as temp files often are.
+func copyFile(src, dst string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	out, err := os.OpenFile(dst, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0755)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		os.Remove(dst)
+		return err
+	}
+	return out.Close()
+}
+
+// DownloadFile saves url to a temp file, refusing anything over maxBytes (0 for no limit)
+func DownloadFile(url string, maxBytes int64) (string, error) {
+	resp, err := downloadFetcher.Get(url)
 	if err != nil {
 		return "", fmt.Errorf("failed to download file: %w", err)
 	}
 	defer resp.Body.Close()
 
-	tmpFile, err := os.CreateTemp("", "ludwig-update-*")
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("download failed with status %d", resp.StatusCode)
+	}
+	if maxBytes > 0 && resp.ContentLength > maxBytes {
+		return "", fmt.Errorf("download is %d bytes, over the %d byte limit", resp.ContentLength, maxBytes)
+	}
+
+	tmpFile, err := os.CreateTemp("", "ludwig-update-*"+exeSuffix())
 	if err != nil {
 		return "", fmt.Errorf("failed to create temp file: %w", err)
 	}
 
-	_, err = io.Copy(tmpFile, resp.Body)
+	// Content-Length can be missing or wrong, so stop reading one byte past the limit
+	body := io.Reader(resp.Body)
+	if maxBytes > 0 {
+		body = io.LimitReader(resp.Body, maxBytes+1)
+	}
+	written, err := io.Copy(tmpFile, body)
 	tmpFile.Close()
+	if err == nil && maxBytes > 0 && written > maxBytes {
+		err = fmt.Errorf("over the %d byte limit", maxBytes)
+	}
 	if err != nil {
 		os.Remove(tmpFile.Name())
 		return "", fmt.Errorf("failed to download: %w", err)
@@ -182,7 +480,28 @@ func downloadFile(url string) (string, error) {
 	return tmpFile.Name(), nil
 }
 
-func extractTarGz(tarGzPath string) (string, error) {
+// binaryName is the file name of the ludwig binary inside a release archive
+func binaryName() string {
+	return "ludwig" + exeSuffix()
+}
+
+// isBinaryMember reports whether an archive member is the ludwig binary, rejecting
+// names that are absolute or climb out of the archive with ".."
+func isBinaryMember(name string) (bool, error) {
+	slashed := strings.ReplaceAll(name, "\\", "/")
+	if path.IsAbs(slashed) || filepath.IsAbs(name) || filepath.VolumeName(name) != "" {
+		return false, fmt.Errorf("unsafe path in archive: %q", name)
+	}
+	for _, part := range strings.Split(slashed, "/") {
+		if part == ".." {
+			return false, fmt.Errorf("unsafe path in archive: %q", name)
+		}
+	}
+	return path.Base(path.Clean(slashed)) == binaryName(), nil
+}
+
+// ExtractTarGz copies the ludwig binary out of a .tar.gz release into a temp file
+func ExtractTarGz(tarGzPath string) (string, error) {
 	file, err := os.Open(tarGzPath)
 	if err != nil {
 		return "", err
@@ -197,71 +516,102 @@ func extractTarGz(tarGzPath string) (string, error) {
 
 	tr := tar.NewReader(gr)
 
+	// Read to the end so an unsafe member is rejected wherever it appears
+	extracted := ""
 	for {
 		header, err := tr.Next()
 		if err == io.EOF {
 			break
 		}
+		if err == nil {
+			var isBinary bool
+			isBinary, err = isBinaryMember(header.Name)
+			if err == nil && isBinary && header.Typeflag == tar.TypeReg && extracted == "" {
+				extracted, err = copyToTemp(tr)
+			}
+		}
 		if err != nil {
+			if extracted != "" {
+				os.Remove(extracted)
+			}
 			return "", err
 		}
+	}
 
-		if header.Typeflag == tar.TypeReg && strings.Contains(header.Name, "ludwig") {
-			tmpFile, err := os.CreateTemp("", "ludwig-bin-*")
-			if err != nil {
-				return "", err
-			}
-			defer tmpFile.Close()
-
-			_, err = io.Copy(tmpFile, tr)
-			if err != nil {
-				return "", err
-			}
+	if extracted == "" {
+		return "", fmt.Errorf("ludwig binary not found in archive")
+	}
+	return extracted, nil
+}
 
-			return tmpFile.Name(), nil
-		}
+// copyToTemp writes r to a new temp file named like a binary, returning its path
+func copyToTemp(r io.Reader) (string, error) {
+	tmpFile, err := os.CreateTemp("", "ludwig-bin-*"+exeSuffix())
+	if err != nil {
+		return "", err
 	}
+	defer tmpFile.Close()
 
-	return "", fmt.Errorf("ludwig binary not found in archive")
+	if _, err := io.Copy(tmpFile, r); err != nil {
+		os.Remove(tmpFile.Name())
+		return "", err
+	}
+	return tmpFile.Name(), nil
 }
 
-func extractZip(zipPath string) (string, error) {
+// ExtractZip copies the ludwig binary out of a .zip release into a temp file
+func ExtractZip(zipPath string) (string, error) {
 	r, err := zip.OpenReader(zipPath)
 	if err != nil {
 		return "", err
 	}
 	defer r.Close()
 
+	// Check every name first so an unsafe member is rejected wherever it appears
 	for _, f := range r.File {
-		if !f.FileInfo().IsDir() && strings.Contains(f.Name, "ludwig") {
+		if _, err := isBinaryMember(f.Name); err != nil {
+			return "", err
+		}
+	}
+	for _, f := range r.File {
+		if isBinary, _ := isBinaryMember(f.Name); isBinary && f.Mode().IsRegular() {
 			rc, err := f.Open()
 			if err != nil {
 				return "", err
 			}
 			defer rc.Close()
 
-			tmpFile, err := os.CreateTemp("", "ludwig-bin-*")
-			if err != nil {
-				return "", err
-			}
-			defer tmpFile.Close()
-
-			_, err = io.Copy(tmpFile, rc)
-			if err != nil {
-				return "", err
-			}
-
-			return tmpFile.Name(), nil
+			return copyToTemp(rc)
 		}
 	}
 
 	return "", fmt.Errorf("ludwig binary not found in archive")
 }
 
-func getOSAndArch() (string, string) {
+// exeSuffix is the extension Windows needs before it will run a downloaded binary
+func exeSuffix() string {
+	if runtime.GOOS == "windows" {
+		return ".exe"
+	}
+	return ""
+}
+
+// FindAsset picks the release archive built for goos/goarch
+func FindAsset(assets []Asset, goos, goarch string) (Asset, bool) {
+	osName, archName := assetOSAndArch(goos, goarch)
+	for _, asset := range assets {
+		if matchesAsset(asset.Name, osName, archName) {
+			return asset, true
+		}
+	}
+	return Asset{}, false
+}
+
+// assetOSAndArch maps Go's names for a platform to the ones used in asset names
+func assetOSAndArch(goos, goarch string) (string, string) {
 	var os, arch string
 
-	switch runtime.GOOS {
+	switch goos {
 	case "darwin":
 		os = "Darwin"
 	case "linux":
@@ -269,16 +619,16 @@ func getOSAndArch() (string, string) {
 	case "windows":
 		os = "Windows"
 	default:
-		os = runtime.GOOS
+		os = goos
 	}
 
-	switch runtime.GOARCH {
+	switch goarch {
 	case "amd64":
 		arch = "x86_64"
 	case "arm64":
 		arch = "arm64"
 	default:
-		arch = runtime.GOARCH
+		arch = goarch
 	}
 
 	return os, arch
@@ -306,21 +656,57 @@ func ApplyPendingUpdate() (bool, error) {
 		return false, nil // No pending update
 	}
 
-	// Replace the old binary with the new one
+	// Replace the old binary with the new one, checking it really was replaced
+	want, err := FileHash(newPath)
+	if err != nil {
+		return false, fmt.Errorf("failed to read pending update: %w", err)
+	}
+	if err := BackupBinary(exePath); err != nil {
+		return false, err
+	}
 	if err := os.Rename(newPath, exePath); err != nil {
 		return false, fmt.Errorf("failed to apply pending update: %w", err)
 	}
+	if err := VerifyInstall(exePath, want); err != nil {
+		return false, err
+	}
 
 	return true, nil
 }
 
 // compareVersions returns -1 if v1 < v2, 0 if equal, 1 if v1 > v2
-// Simple comparison: split by dots and compare numeric parts
+// Pre-releases such as 1.3.0-beta.2 sort before the release they lead up to
 func compareVersions(v1, v2 string) int {
+	core1, pre1, _ := strings.Cut(v1, "-")
+	core2, pre2, _ := strings.Cut(v2, "-")
+	if c := compareParts(core1, core2, false); c != 0 || pre1 == pre2 {
+		return c
+	}
+	switch {
+	case pre1 == "":
+		return 1
+	case pre2 == "":
+		return -1
+	}
+	return compareParts(pre1, pre2, true)
+}
+
+// compareParts compares dot separated parts numerically, or alphabetically where
+// alphabetic is set and a part isn't a number (as in "beta.2")
+func compareParts(v1, v2 string, alphabetic bool) int {
 	parts1 := strings.Split(v1, ".")
 	parts2 := strings.Split(v2, ".")
 
 	for i := 0; i < len(parts1) && i < len(parts2); i++ {
+		_, err1 := strconv.Atoi(parts1[i])
+		_, err2 := strconv.Atoi(parts2[i])
+		if alphabetic && (err1 != nil || err2 != nil) {
+			if c := strings.Compare(parts1[i], parts2[i]); c != 0 {
+				return c
+			}
+			continue
+		}
+
 		// Simple numeric comparison (good enough for semver)
 		var n1, n2 int
 		fmt.Sscanf(parts1[i], "%d", &n1)
@@ -87,6 +87,8 @@ func ClearScreen() {
 	*/
 }
 
+// Println writes a line for the raw-mode CLI. Don't use it on TUI code paths, where
+// stdout belongs to Bubbletea: return the text to show in the message area instead.
 func Println(text string) {
 	fmt.Print(text + "\r\n")
 }
@@ -0,0 +1,30 @@
+package utils
+
+import (
+	"errors"
+
+	"github.com/atotto/clipboard"
+)
+
+// ErrNoClipboard is returned when the system has no clipboard to copy to,
+// e.g. over SSH or on Linux without xclip, xsel or wl-clipboard installed
+var ErrNoClipboard = errors.New("no clipboard available (on Linux install xclip, xsel or wl-clipboard)")
+
+// Clipboard copies text somewhere the user can paste it from
+type Clipboard interface {
+	WriteAll(text string) error
+}
+
+// SystemClipboard writes to the operating system clipboard
+type SystemClipboard struct{}
+
+func (SystemClipboard) WriteAll(text string) error {
+	if clipboard.Unsupported {
+		return ErrNoClipboard
+	}
+	if err := clipboard.WriteAll(text); err != nil {
+		// Headless sessions often have the tools but no display to talk to
+		return errors.Join(ErrNoClipboard, err)
+	}
+	return nil
+}
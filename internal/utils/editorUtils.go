@@ -0,0 +1,70 @@
+package utils
+
+import (
+	"errors"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// FALLBACK_EDITORS are tried in order when neither $VISUAL nor $EDITOR is set
+var FALLBACK_EDITORS = []string{"code", "vim"}
+
+// terminalEditors need the terminal to themselves, so they can't run alongside the TUI
+var terminalEditors = map[string]bool{
+	"vi": true, "vim": true, "nvim": true, "nano": true, "emacs": true,
+	"micro": true, "hx": true, "helix": true, "kak": true, "pico": true,
+}
+
+// ErrNoEditor is returned when no editor is configured or installed
+var ErrNoEditor = errors.New("no editor found; set $VISUAL or $EDITOR")
+
+// EditorLaunch is a resolved editor command for opening a directory
+type EditorLaunch struct {
+	Args     []string // Editor binary, its configured flags and the directory
+	Dir      string   // Working directory to launch in
+	Terminal bool     // The editor runs inside a terminal and can't be detached from the TUI
+}
+
+// ResolveEditor picks the editor to open dir with: $VISUAL, then $EDITOR, then the
+// first installed FALLBACK_EDITORS entry. getenv and lookPath are passed in so the
+// choice can be tested without touching the real environment.
+func ResolveEditor(dir string, getenv func(string) string, lookPath func(string) (string, error)) (EditorLaunch, error) {
+	var args []string
+	for _, name := range []string{"VISUAL", "EDITOR"} {
+		// Allow flags such as EDITOR="code --new-window"
+		if fields := strings.Fields(getenv(name)); len(fields) > 0 {
+			args = fields
+			break
+		}
+	}
+	if args == nil {
+		for _, editor := range FALLBACK_EDITORS {
+			if _, err := lookPath(editor); err == nil {
+				args = []string{editor}
+				break
+			}
+		}
+	}
+	if args == nil {
+		return EditorLaunch{}, ErrNoEditor
+	}
+
+	return EditorLaunch{
+		Args:     append(args, dir),
+		Dir:      dir,
+		Terminal: terminalEditors[filepath.Base(args[0])],
+	}, nil
+}
+
+// Start launches the editor detached, without waiting for it to exit
+func (l EditorLaunch) Start() error {
+	cmd := exec.Command(l.Args[0], l.Args[1:]...)
+	cmd.Dir = l.Dir
+	if err := cmd.Start(); err != nil {
+		return err
+	}
+	// Reap the process when the editor closes
+	go cmd.Wait()
+	return nil
+}
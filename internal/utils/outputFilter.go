@@ -0,0 +1,215 @@
+package utils
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"regexp"
+	"strings"
+)
+
+// EventKind categorises a line of a response file
+type EventKind int
+
+const (
+	EVENT_MESSAGE   EventKind = iota // The AI's reply, or plain text output
+	EVENT_TOOL_CALL                  // A tool call or its successful result
+	EVENT_COMMIT                     // A message reporting a commit
+	EVENT_ERROR                      // An error event, failed tool call or failed run
+	EVENT_OTHER                      // Bookkeeping such as init and result events
+)
+
+// OutputFilter picks which events of a task's output are shown
+type OutputFilter int
+
+const (
+	FILTER_ALL OutputFilter = iota
+	FILTER_COMMITS
+	FILTER_ERRORS
+)
+
+func (f OutputFilter) String() string {
+	switch f {
+	case FILTER_COMMITS:
+		return "commits"
+	case FILTER_ERRORS:
+		return "errors"
+	default:
+		return "all"
+	}
+}
+
+// Next returns the filter after f, cycling all → commits → errors → all
+func (f OutputFilter) Next() OutputFilter {
+	return (f + 1) % (FILTER_ERRORS + 1)
+}
+
+// COMMIT_PATTERN matches the "Committed: <message>" lines the task prompt asks the AI
+// to report its commits with, optionally after a bullet such as "✓"
+var COMMIT_PATTERN = regexp.MustCompile(`(?m)^\W*Committed: .+$`)
+
+// ERROR_PATTERN matches plain text lines that report a failure, e.g. from other providers
+var ERROR_PATTERN = regexp.MustCompile(`(?i)\berror\b|\bfailed\b|^panic:|^FAIL\b`)
+
+// ClassifyLine works out what kind of event a response file line holds
+func ClassifyLine(line string) EventKind {
+	object, ok := parseEvent(line)
+	if !ok {
+		switch {
+		case COMMIT_PATTERN.MatchString(line):
+			return EVENT_COMMIT
+		case ERROR_PATTERN.MatchString(line):
+			return EVENT_ERROR
+		}
+		return EVENT_MESSAGE
+	}
+
+	switch stringField(object, "type") {
+	case "message":
+		if COMMIT_PATTERN.MatchString(stringField(object, "content")) {
+			return EVENT_COMMIT
+		}
+		return EVENT_MESSAGE
+	case "tool_use":
+		return EVENT_TOOL_CALL
+	case "tool_result":
+		if stringField(object, "status") == "error" {
+			return EVENT_ERROR
+		}
+		return EVENT_TOOL_CALL
+	case "error":
+		return EVENT_ERROR
+	case "result":
+		if status := stringField(object, "status"); status != "" && status != "success" {
+			return EVENT_ERROR
+		}
+	}
+	return EVENT_OTHER
+}
+
+// FilterLine renders a response file line if it passes filter, or returns "".
+// With FILTER_COMMITS only the commit lines of a message are kept.
+func FilterLine(line string, filter OutputFilter) string {
+	switch filter {
+	case FILTER_COMMITS:
+		if ClassifyLine(line) != EVENT_COMMIT {
+			return ""
+		}
+		text := line
+		if object, ok := parseEvent(line); ok {
+			text = stringField(object, "content")
+		}
+		commits := COMMIT_PATTERN.FindAllString(text, -1)
+		for i, commit := range commits {
+			commits[i] = strings.TrimSpace(commit)
+		}
+		return strings.Join(commits, "\n")
+	case FILTER_ERRORS:
+		if ClassifyLine(line) != EVENT_ERROR {
+			return ""
+		}
+	}
+	return OutputLine(line)
+}
+
+// ReplyText extracts what the AI said from a response: the assistant messages of
+// stream-json output, or plain text output as it is. Tool calls and bookkeeping
+// events are dropped, but each one ends the message before it.
+func ReplyText(response string) string {
+	var b strings.Builder
+	endLine := func() {
+		if b.Len() > 0 && !strings.HasSuffix(b.String(), "\n") {
+			b.WriteString("\n")
+		}
+	}
+	var events jsonReassembler
+	lines := strings.Split(response, "\n")
+	for i, line := range lines {
+		ready := events.next(line)
+		if i == len(lines)-1 {
+			ready = append(ready, events.flush()...)
+		}
+		for _, line := range ready {
+			object, ok := parseEvent(line)
+			if !ok {
+				b.WriteString(line + "\n")
+				continue
+			}
+			if stringField(object, "type") == "message" && stringField(object, "role") != "user" {
+				b.WriteString(stringField(object, "content"))
+				continue
+			}
+			endLine()
+		}
+	}
+	return strings.TrimSpace(b.String())
+}
+
+// MAX_PARTIAL_JSON_BYTES is how much of an event broken across lines is held while
+// waiting for the rest, before it is given up on
+const MAX_PARTIAL_JSON_BYTES = 1 << 20
+
+// EVENT_START is how every stream-json event line begins, which tells a split
+// event apart from plain text that happens to start with a brace
+const EVENT_START = `{"type"`
+
+// jsonReassembler puts back together stream-json events broken across lines, as
+// happens when a CLI wraps its output. A line that starts an event but stops part
+// way is held and the lines after it are joined on until the event is complete.
+// Anything else passes straight through.
+type jsonReassembler struct {
+	held []string
+}
+
+// next returns the lines to parse for line: none while an event is still
+// incomplete, the joined event once it is whole, or, when line doesn't carry the
+// event on, the held lines as they were followed by line itself.
+func (j *jsonReassembler) next(line string) []string {
+	if len(j.held) > 0 {
+		joined := strings.Join(j.held, "") + line
+		switch {
+		case json.Valid([]byte(joined)):
+			j.held = nil
+			return []string{joined}
+		case isTruncatedJSON(joined) && len(joined) <= MAX_PARTIAL_JSON_BYTES:
+			j.held = append(j.held, line)
+			return nil
+		}
+		return append(j.flush(), j.next(line)...)
+	}
+	if strings.HasPrefix(line, EVENT_START) && isTruncatedJSON(line) {
+		j.held = []string{line}
+		return nil
+	}
+	return []string{line}
+}
+
+// flush returns the lines held for an event that never completed, as they were
+func (j *jsonReassembler) flush() []string {
+	held := j.held
+	j.held = nil
+	return held
+}
+
+// isTruncatedJSON reports whether line is the start of a JSON object that stops
+// part way, rather than a whole one or something else entirely
+func isTruncatedJSON(line string) bool {
+	if !strings.HasPrefix(line, "{") {
+		return false
+	}
+	var object map[string]any
+	err := json.NewDecoder(strings.NewReader(line)).Decode(&object)
+	return errors.Is(err, io.ErrUnexpectedEOF)
+}
+
+// parseEvent decodes a stream-json event line
+func parseEvent(line string) (map[string]any, bool) {
+	if !strings.HasPrefix(line, "{") {
+		return nil, false
+	}
+	var object map[string]any
+	if err := json.Unmarshal([]byte(line), &object); err != nil {
+		return nil, false
+	}
+	return object, true
+}
@@ -13,6 +13,7 @@ import (
 	"hash/fnv"
 	"io"
 	"bytes"
+	"sort"
 )
 
 var OUTPUT_STYLE lipgloss.Style = lipgloss.NewStyle().Padding(0, 0)
@@ -134,6 +135,22 @@ func NewDelayedTask(duration time.Duration, task func()) *DelayedTask {
 	return dt
 }
 
+// Styles for stream-json events that aren't the AI's own messages, so the
+// reply stands out from the tool calls made along the way
+var (
+	TOOL_STYLE  lipgloss.Style = lipgloss.NewStyle().Faint(true)
+	ERROR_STYLE lipgloss.Style = lipgloss.NewStyle().Foreground(lipgloss.Color("196"))
+	EVENT_STYLE lipgloss.Style = lipgloss.NewStyle().Faint(true).Italic(true)
+)
+
+const (
+	TOOL_ICON   = "⚙ "
+	RESULT_ICON = "↳ "
+	ERROR_ICON  = "⚠ "
+)
+
+// OutputLine renders one line of a response file. Lines holding gemini stream-json
+// events are formatted by type; anything else is shown as written.
 func OutputLine(line string) string {
 	// remove surrounding {}
 	if len(line) == 0 {
@@ -151,53 +168,75 @@ func OutputLine(line string) string {
 	if err != nil {
 		return ""
 	}
-	if object["type"] == "init" {
+	eventType := stringField(object, "type")
+	switch eventType {
+	case "init":
 		return ""
-	}
-	if object["type"] == "message" {
-		timestamp := object["timestamp"].(string)
-		styledTimestamp := FormatTimestamp(timestamp)
-		builder.WriteString(styledTimestamp)
-		output := strings.Builder{}
-		output.WriteString(object["content"].(string))
-		builder.WriteString(OUTPUT_STYLE.Render(output.String()))
+	case "message":
+		builder.WriteString(FormatTimestamp(stringField(object, "timestamp")))
+		builder.WriteString(OUTPUT_STYLE.Render(stringField(object, "content")))
 		return builder.String()
-	}
-	if object["type"] == "tool_use" {
-		timestamp := object["timestamp"].(string)
-		timestamp = FormatTimestamp(timestamp)
-		builder.WriteString(timestamp)
+	case "tool_use":
+		builder.WriteString(FormatTimestamp(stringField(object, "timestamp")))
 
 		output := strings.Builder{}
-		toolName := object["tool_name"].(string)
-		params := object["parameters"].(map[string]any)
-		output.WriteString("Using tool: " + toolName + "\n")
-		writeParams(&output, params)
-		builder.WriteString(OUTPUT_STYLE.Render(output.String()))
-
-		return builder.String()
-	}
-	if object["type"] == "tool_result" {
-		output := strings.Builder{}
-		result := object["status"].(string)
-		output.WriteString("Tool result: ")
-		output.WriteString(result)
-		output.WriteString("\n")
-		builder.WriteString(OUTPUT_STYLE.Render(output.String()))
-
-		return builder.String()
-	}
-	if object["type"] == "result" {
+		output.WriteString(TOOL_ICON + "Using tool: " + stringField(object, "tool_name") + "\n")
+		if params, ok := object["parameters"].(map[string]any); ok && len(params) > 0 {
+			writeParams(&output, params)
+		}
+		builder.WriteString(TOOL_STYLE.Render(strings.TrimSuffix(output.String(), "\n")))
 		return builder.String()
+	case "tool_result":
+		status := stringField(object, "status")
+		if status == "error" {
+			message := status
+			if toolErr, ok := object["error"].(map[string]any); ok && stringField(toolErr, "message") != "" {
+				message = stringField(toolErr, "message")
+			}
+			return ERROR_STYLE.Render(RESULT_ICON + "Tool failed: " + message)
+		}
+		return TOOL_STYLE.Render(RESULT_ICON + "Tool result: " + status)
+	case "error":
+		return ERROR_STYLE.Render(ERROR_ICON + stringField(object, "severity") + ": " + stringField(object, "message"))
+	case "result":
+		// The reply has already been shown message by message; only a failed run needs a note
+		if status := stringField(object, "status"); status != "" && status != "success" {
+			message := status
+			if runErr, ok := object["error"].(map[string]any); ok && stringField(runErr, "message") != "" {
+				message = stringField(runErr, "message")
+			}
+			return ERROR_STYLE.Render(ERROR_ICON + "Run ended: " + message)
+		}
+		return ""
+	case "":
+		return line
+	default:
+		// Event types added after this was written are shown dimmed so they don't
+		// read as the AI's reply
+		return EVENT_STYLE.Render("[" + eventType + "] " + line)
 	}
+}
 
-	return line
+// stringField returns a JSON field as a string, or "" when it is missing or not a string
+func stringField(object map[string]any, key string) string {
+	value, _ := object[key].(string)
+	return value
 }
 
 func writeParams(builder *strings.Builder, params map[string]any) {
 	builder.WriteString("With parameters:\n")
-	for key, value := range params {
-		paramLine := "  - " + key + ": " + value.(string) + "\n"
+	keys := make([]string, 0, len(params))
+	for key := range params {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		value, ok := params[key].(string)
+		if !ok {
+			encoded, _ := json.Marshal(params[key])
+			value = string(encoded)
+		}
+		paramLine := "  - " + key + ": " + value + "\n"
 		builder.WriteString(paramLine)
 	}
 }
@@ -228,36 +267,104 @@ func colouredStrings(output string) string {
 }
 
 func OutputLines(lines []string) string {
-	output := strings.Builder{}
+	return outputLines(lines, false, 2)
+}
+
+// OutputTailLines renders lines taken from the middle of a response file,
+// where the header (and its "---" separator) has already been cut off
+func OutputTailLines(lines []string) string {
+	return outputLines(lines, true, 0)
+}
+
+func outputLines(lines []string, started bool, linesToSkip int) string {
 	if len(lines) == 0 {
 		return "no output"
 	}
-	started := false
-	linesToSkip := 2
+	stream := &OutputStream{started: started, linesToSkip: linesToSkip}
+	return colourOutput(stream.renderLines(lines))
+}
+
+func colourOutput(output string) string {
+	outputStr := colouredUnorderedLists(output)
+	outputStr = colouredStrings(outputStr)
+	return colouredOrderedLists(outputStr)
+}
+
+// OutputStream renders a response file incrementally as it is written.
+// It keeps the header/footer parsing state between chunks and holds back
+// any trailing partial line until the rest of it arrives, and any event
+// broken across lines until it is whole.
+type OutputStream struct {
+	Filter      OutputFilter // Which events to render; lines filtered out are dropped
+	started     bool
+	finished    bool
+	linesToSkip int
+	pending     string
+	events      jsonReassembler // Holds an event broken across lines until it's whole
+}
+
+// NewOutputStream creates a stream for a response file read from the start
+func NewOutputStream() *OutputStream {
+	return &OutputStream{linesToSkip: 2}
+}
+
+// NewTailOutputStream creates a stream for content read from the middle of a file
+func NewTailOutputStream() *OutputStream {
+	return &OutputStream{started: true}
+}
+
+// Feed consumes newly read file content and returns the rendered output for
+// every line completed by it
+func (o *OutputStream) Feed(chunk string) string {
+	o.pending += chunk
+	lastNewline := strings.LastIndex(o.pending, "\n")
+	if lastNewline == -1 {
+		return ""
+	}
+	lines := strings.Split(o.pending[:lastNewline], "\n")
+	o.pending = o.pending[lastNewline+1:]
+	return colourOutput(o.renderLines(lines))
+}
+
+func (o *OutputStream) renderLines(lines []string) string {
+	output := strings.Builder{}
+	render := func(lines []string) {
+		for _, line := range lines {
+			if o.Filter == FILTER_ALL {
+				output.WriteString(OutputLine(line))
+				output.WriteString("\n")
+			} else if rendered := FilterLine(line, o.Filter); rendered != "" {
+				output.WriteString(rendered)
+				output.WriteString("\n")
+			}
+		}
+	}
 	for _, line := range lines {
-		if line == "---" && started {
+		if o.finished {
+			break
+		}
+		if line == "---" && o.started {
+			o.finished = true
+			render(o.events.flush())
 			break
 		}
 		if line == "---" {
-			started = true
+			o.started = true
 			continue
 		}
-		if !started {
+		if !o.started {
 			continue
 		}
 		if line == "" {
 			continue
 		}
-		if linesToSkip > 0 {
-			linesToSkip--
+		if o.linesToSkip > 0 {
+			o.linesToSkip--
 			continue
 		}
-		output.WriteString(OutputLine(line))
-		output.WriteString("\n")
+		render(o.events.next(line))
 	}
-	outputStr := colouredUnorderedLists(output.String())
-	outputStr = colouredStrings(outputStr)
-	return colouredOrderedLists(outputStr)
+	return output.String()
 }
 
 func GetTaskByPath(tasks []task.Task, path string) *task.Task {
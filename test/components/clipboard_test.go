@@ -0,0 +1,74 @@
+package components_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"ludwig/internal/components/outputViewport"
+	"ludwig/internal/logger"
+	"ludwig/internal/utils"
+)
+
+// fakeClipboard records what was copied, or fails with err
+type fakeClipboard struct {
+	copied string
+	err    error
+}
+
+func (c *fakeClipboard) WriteAll(text string) error {
+	if c.err != nil {
+		return c.err
+	}
+	c.copied = text
+	return nil
+}
+
+func TestCtrlYCopiesViewedOutput(t *testing.T) {
+	logger.Reset()
+	defer logger.Reset()
+	logger.Infof("Task abc123 completed")
+
+	clip := &fakeClipboard{}
+	m := outputViewport.NewModel()
+	m.SetClipboard(clip)
+	m.SetViewingLogs(10)
+
+	m.Update(tea.KeyMsg{Type: tea.KeyCtrlY})
+
+	if !strings.Contains(clip.copied, "Task abc123 completed") {
+		t.Errorf("expected log output to be copied, got %q", clip.copied)
+	}
+	if strings.Contains(clip.copied, "\x1b[") {
+		t.Errorf("expected styling to be stripped, got %q", clip.copied)
+	}
+	if !strings.Contains(m.Notice(), "Copied") {
+		t.Errorf("expected a confirmation notice, got %q", m.Notice())
+	}
+
+	m.Update(tea.KeyMsg{Type: tea.KeyCtrlS})
+	if m.Notice() != "" {
+		t.Errorf("expected the notice to clear on the next key, got %q", m.Notice())
+	}
+}
+
+func TestCopyWithoutClipboardIsFriendly(t *testing.T) {
+	logger.Reset()
+	defer logger.Reset()
+	logger.Infof("Something happened")
+
+	m := outputViewport.NewModel()
+	m.SetClipboard(&fakeClipboard{err: errors.Join(utils.ErrNoClipboard, errors.New("exit status 1"))})
+	m.SetViewingLogs(10)
+
+	m.CopyContent()
+
+	if !strings.Contains(m.Notice(), "no clipboard available") {
+		t.Errorf("expected a friendly no-clipboard notice, got %q", m.Notice())
+	}
+	if strings.Contains(m.Notice(), "exit status") {
+		t.Errorf("expected the underlying error to be hidden, got %q", m.Notice())
+	}
+}
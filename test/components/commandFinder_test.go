@@ -0,0 +1,108 @@
+package components_test
+
+import (
+	"slices"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"ludwig/internal/components/commandFinder"
+)
+
+var finderEntries = []commandFinder.Entry{
+	{Name: "add", Usage: "<task description>", Description: "Add a new task."},
+	{Name: "delete", Usage: "<task ref>", Description: "Delete a task by it's ref."},
+	{Name: "discard", Usage: "<task ref>", Description: "Throw away everything a task produced."},
+	{Name: "stats", Description: "Show task counts and success rate."},
+	{Name: "start", Description: "Start the AI Orchestrator"},
+	{Name: "stop", Description: "Stop the AI Orchestrator"},
+}
+
+func names(entries []commandFinder.Entry) []string {
+	var result []string
+	for _, entry := range entries {
+		result = append(result, entry.Name)
+	}
+	return result
+}
+
+func typeIntoFinder(finder commandFinder.Model, text string) commandFinder.Model {
+	for _, r := range text {
+		finder, _ = finder.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+	}
+	return finder
+}
+
+func TestMatchRanksPrefixesFirst(t *testing.T) {
+	got := names(commandFinder.Match("sa", finderEntries))
+	// Word starts beat the mid-word match in "discard"; the tie keeps the list order
+	want := []string{"stats", "start", "discard"}
+	if !slices.Equal(got, want) {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+}
+
+func TestMatchIsFuzzy(t *testing.T) {
+	got := names(commandFinder.Match("dsc", finderEntries))
+	if len(got) == 0 || got[0] != "discard" {
+		t.Errorf("expected dsc to find discard first, got %v", got)
+	}
+	if got := commandFinder.Match("xyz", finderEntries); len(got) != 0 {
+		t.Errorf("expected no matches, got %v", names(got))
+	}
+}
+
+func TestMatchFallsBackToDescriptions(t *testing.T) {
+	got := names(commandFinder.Match("orchestrator", finderEntries))
+	if len(got) != 2 || !slices.Contains(got, "start") || !slices.Contains(got, "stop") {
+		t.Errorf("expected the commands describing the orchestrator, got %v", got)
+	}
+	// A name match beats a description match however good
+	got = names(commandFinder.Match("add", finderEntries))
+	if got[0] != "add" {
+		t.Errorf("expected the add command first, got %v", got)
+	}
+}
+
+func TestFinderSelectsHighlightedCommand(t *testing.T) {
+	finder := typeIntoFinder(commandFinder.NewModel(finderEntries), "st")
+	finder, _ = finder.Update(tea.KeyMsg{Type: tea.KeyDown})
+
+	_, cmd := finder.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if cmd == nil {
+		t.Fatal("expected Enter to select a command")
+	}
+	msg, ok := cmd().(commandFinder.SelectMsg)
+	if !ok || msg.Entry.Name != "start" {
+		t.Errorf("expected start to be selected, got %#v", cmd())
+	}
+}
+
+func TestFinderWrapsAndCancels(t *testing.T) {
+	finder := typeIntoFinder(commandFinder.NewModel(finderEntries), "sto")
+	finder, _ = finder.Update(tea.KeyMsg{Type: tea.KeyUp})
+	if selected, _ := finder.Selected(); selected.Name != "stop" {
+		t.Errorf("expected Up to wrap around the single match, got %q", selected.Name)
+	}
+
+	_, cmd := finder.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if _, ok := cmd().(commandFinder.CancelMsg); !ok {
+		t.Error("expected Esc to close the finder")
+	}
+}
+
+func TestFinderEnterWithNoMatches(t *testing.T) {
+	finder := typeIntoFinder(commandFinder.NewModel(finderEntries), "zzz")
+	if _, cmd := finder.Update(tea.KeyMsg{Type: tea.KeyEnter}); cmd != nil {
+		t.Error("expected nothing to be selected")
+	}
+}
+
+func TestTakesArguments(t *testing.T) {
+	if !commandFinder.TakesArguments(finderEntries[1]) {
+		t.Error("expected delete to need a task ref")
+	}
+	if commandFinder.TakesArguments(finderEntries[3]) {
+		t.Error("expected stats to run without arguments")
+	}
+}
@@ -0,0 +1,66 @@
+package components_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"ludwig/internal/components/orchestratorIndicator"
+	"ludwig/internal/orchestrator"
+	"ludwig/internal/types/task"
+)
+
+func runningIndicator(tasks ...orchestrator.RunningTask) *orchestratorIndicator.Model {
+	now := time.Date(2026, 1, 2, 10, 0, 0, 0, time.UTC)
+	m := orchestratorIndicator.NewModel()
+	m.IsRunning = func() bool { return true }
+	m.Running = func() []orchestrator.RunningTask { return tasks }
+	m.Now = func() time.Time { return now }
+	m.Update(tea.WindowSizeMsg{Width: 80, Height: 24})
+	return m
+}
+
+func TestIndicatorCompactAndExpanded(t *testing.T) {
+	since := time.Date(2026, 1, 2, 9, 57, 55, 0, time.UTC)
+	m := runningIndicator(
+		orchestrator.RunningTask{Task: task.Task{ID: "a", Name: "Fix the login form\nwith details"}, Since: since},
+		orchestrator.RunningTask{Task: task.Task{ID: "b", Name: "Write docs"}, Since: since.Add(time.Minute)},
+	)
+
+	compact := m.View()
+	if !strings.Contains(compact, "Ludwig composing") || strings.Contains(compact, "Fix the login form") {
+		t.Errorf("expected only the animation when compact, got %q", compact)
+	}
+
+	m.Toggle()
+	expanded := m.View()
+	for _, want := range []string{"Ludwig composing", "Fix the login form (2m5s)", "+1 more"} {
+		if !strings.Contains(expanded, want) {
+			t.Errorf("expected expanded indicator to contain %q, got %q", want, expanded)
+		}
+	}
+	if strings.Count(expanded, "\n") != strings.Count(compact, "\n") {
+		t.Errorf("expected both modes to take the same number of lines")
+	}
+
+	// Resizing keeps the mode and the single line
+	m.Update(tea.WindowSizeMsg{Width: 30, Height: 24})
+	if !m.Expanded || lipgloss.Width(m.View()) > 30 {
+		t.Errorf("expected the expanded indicator to stay on one line within the width, got %q", m.View())
+	}
+}
+
+func TestIndicatorHiddenWhenStopped(t *testing.T) {
+	m := runningIndicator()
+	m.Toggle()
+	if !strings.Contains(m.View(), "waiting for tasks") {
+		t.Errorf("expected an idle note when no task is running, got %q", m.View())
+	}
+	m.IsRunning = func() bool { return false }
+	if m.View() != "" {
+		t.Errorf("expected nothing when the orchestrator is stopped, got %q", m.View())
+	}
+}
@@ -0,0 +1,378 @@
+package components_test
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"ludwig/internal/components/outputViewport"
+	"ludwig/internal/logger"
+	"ludwig/internal/storage"
+	"ludwig/internal/types/task"
+	"ludwig/internal/utils"
+)
+
+func cleanupComponentStorage(t *testing.T) {
+	cwd, _ := os.Getwd()
+	os.RemoveAll(filepath.Join(cwd, ".ludwig"))
+}
+
+// writeRun creates a response file with enough content to fill the viewport
+func writeRun(t *testing.T, taskID string, lines int) (*storage.ResponseWriter, string) {
+	rw, relativePath, err := storage.NewResponseWriter(taskID)
+	if err != nil {
+		t.Fatalf("failed to create response writer: %v", err)
+	}
+	rw.WriteChunk("skip one\nskip two\n")
+	for i := 0; i < lines; i++ {
+		rw.WriteChunk("existing line\n")
+	}
+	return rw, relativePath
+}
+
+func TestViewportRefreshAppendsNewContent(t *testing.T) {
+	cleanupComponentStorage(t)
+	defer cleanupComponentStorage(t)
+
+	rw, relativePath := writeRun(t, "grow-task", 3)
+	defer rw.Close()
+
+	m := outputViewport.NewModel()
+	m.SetViewingTask(&task.Task{ID: "grow-task"}, relativePath)
+	before := m.Content()
+
+	if m.Refresh() {
+		t.Errorf("expected no refresh when the file hasn't grown")
+	}
+
+	rw.WriteChunk("streamed later\n")
+	if !m.Refresh() {
+		t.Fatalf("expected refresh after the file grew")
+	}
+
+	after := m.Content()
+	if !strings.HasPrefix(after, before) {
+		t.Errorf("expected new output to be appended to existing content")
+	}
+	if !strings.Contains(after[len(before):], "streamed later") {
+		t.Errorf("expected appended content to contain new line, got %q", after[len(before):])
+	}
+}
+
+func TestViewportUpdateLoopRefreshesThroughUpdate(t *testing.T) {
+	cleanupComponentStorage(t)
+	defer cleanupComponentStorage(t)
+
+	rw, relativePath := writeRun(t, "loop-task", 3)
+	defer rw.Close()
+
+	m := outputViewport.NewModel()
+	m.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+	m.SetViewingTask(&task.Task{ID: "loop-task"}, relativePath)
+	wait := m.ViewportUpdateLoop()
+
+	rw.WriteChunk("streamed later\n")
+	msg := wait()
+	if strings.Contains(m.Content(), "streamed later") {
+		t.Fatal("expected the view to be left to Update rather than refreshed by the loop")
+	}
+	_, next := m.Update(msg)
+	if !strings.Contains(m.Content(), "streamed later") {
+		t.Errorf("expected Update to show the new output, got %q", m.Content())
+	}
+	if next == nil {
+		t.Fatal("expected the loop to keep waiting for output")
+	}
+
+	m.Close()
+	if _, next := m.Update(next()); next != nil {
+		t.Error("expected the loop to end once the view is closed")
+	}
+}
+
+func TestViewportRefreshFollowsBottom(t *testing.T) {
+	cleanupComponentStorage(t)
+	defer cleanupComponentStorage(t)
+
+	rw, relativePath := writeRun(t, "follow-task", 200)
+	defer rw.Close()
+
+	m := outputViewport.NewModel()
+	m.SetViewingTask(&task.Task{ID: "follow-task"}, relativePath)
+
+	rw.WriteChunk("newest line\n")
+	m.Refresh()
+
+	if !strings.Contains(m.View(), "newest line") {
+		t.Errorf("expected viewport to stay scrolled to the bottom after new content")
+	}
+}
+
+func TestViewportShowsAndFollowsLogs(t *testing.T) {
+	logger.Reset()
+	defer logger.Reset()
+
+	logger.Infof("Starting task abc123: Write docs")
+	logger.Infof("No pending tasks found")
+
+	m := outputViewport.NewModel()
+	m.SetViewingLogs(1)
+	if content := m.Content(); !strings.Contains(content, "No pending tasks found") || strings.Contains(content, "Starting task") {
+		t.Errorf("expected only the last log line, got %q", content)
+	}
+	if m.Refresh() {
+		t.Errorf("expected no refresh when nothing new was logged")
+	}
+
+	logger.Infof("Task abc123 completed")
+	if !m.Refresh() {
+		t.Fatalf("expected refresh after a new log line")
+	}
+	if content := m.Content(); !strings.Contains(content, "Task abc123 completed") {
+		t.Errorf("expected the new log line to be shown, got %q", content)
+	}
+	if !strings.Contains(m.View(), "Task abc123 completed") {
+		t.Errorf("expected log lines to render in the view")
+	}
+}
+
+func TestViewportShowsWorkInProgressChecklist(t *testing.T) {
+	cleanupComponentStorage(t)
+	defer cleanupComponentStorage(t)
+
+	rw, relativePath := writeRun(t, "review-task", 3)
+	defer rw.Close()
+
+	m := outputViewport.NewModel()
+	m.SetSize(100, 40)
+	m.SetViewingTask(&task.Task{ID: "review-task", WorkInProgress: "✓ Wrote the parser\n• Pending: Hook it up"}, relativePath)
+
+	checklist := m.Checklist()
+	for _, want := range []string{"Progress: 1/2 done", "✓ Wrote the parser", "○ Hook it up"} {
+		if !strings.Contains(checklist, want) {
+			t.Errorf("expected checklist to contain %q, got %q", want, checklist)
+		}
+	}
+	if !strings.Contains(m.View(), "Wrote the parser") {
+		t.Errorf("expected the checklist to be rendered in the view")
+	}
+
+	m.SetViewingTask(&task.Task{ID: "review-task"}, relativePath)
+	if m.Checklist() != "" {
+		t.Errorf("expected no checklist without work in progress")
+	}
+}
+
+func TestViewportShowsComments(t *testing.T) {
+	cleanupComponentStorage(t)
+	defer cleanupComponentStorage(t)
+
+	rw, relativePath := writeRun(t, "commented-task", 3)
+	defer rw.Close()
+
+	viewed := &task.Task{ID: "commented-task"}
+	for _, text := range []string{"first", "second", "third", "fourth", "fifth"} {
+		viewed.AddComment(text)
+	}
+	m := outputViewport.NewModel()
+	m.SetSize(100, 40)
+	m.SetViewingTask(viewed, relativePath)
+
+	comments := m.Comments()
+	for _, want := range []string{"Comments: 5", "(2 earlier comments)", "third", "fifth"} {
+		if !strings.Contains(comments, want) {
+			t.Errorf("expected comments to contain %q, got %q", want, comments)
+		}
+	}
+	if strings.Contains(comments, "second") {
+		t.Errorf("expected older comments to be hidden, got %q", comments)
+	}
+	if strings.Index(comments, "third") > strings.Index(comments, "fifth") {
+		t.Errorf("expected comments oldest first, got %q", comments)
+	}
+	if !strings.Contains(m.View(), "fifth") {
+		t.Errorf("expected comments to be rendered in the view")
+	}
+}
+
+func TestViewportFilterPersistsAcrossRefresh(t *testing.T) {
+	cleanupComponentStorage(t)
+	defer cleanupComponentStorage(t)
+
+	rw, relativePath := writeRun(t, "filter-task", 3)
+	defer rw.Close()
+	rw.WriteChunk("✓ Committed: Add the parser\n")
+
+	m := outputViewport.NewModel()
+	m.SetSize(100, 40)
+	m.SetViewingTask(&task.Task{ID: "filter-task"}, relativePath)
+
+	m.Update(tea.KeyMsg{Type: tea.KeyTab})
+	if m.Filter() != utils.FILTER_COMMITS {
+		t.Fatalf("expected Tab to switch to commits, got %s", m.Filter())
+	}
+	if content := m.Content(); strings.Contains(content, "existing line") || !strings.Contains(content, "Add the parser") {
+		t.Errorf("expected only the commit, got %q", content)
+	}
+
+	m.Update(tea.KeyMsg{Type: tea.KeyCtrlW})
+	rw.WriteChunk("more output\nCommitted: Wire it up\n")
+	m.Refresh()
+	if content := m.Content(); strings.Contains(content, "more output") || !strings.Contains(content, "Wire it up") {
+		t.Errorf("expected the filter to apply to new output, got %q", content)
+	}
+	if !strings.Contains(m.View(), "Showing commits only") {
+		t.Errorf("expected the active filter to be shown")
+	}
+
+	m.SetViewingTask(&task.Task{ID: "filter-task"}, relativePath)
+	if m.Filter() != utils.FILTER_ALL {
+		t.Errorf("expected viewing a task to start unfiltered, got %s", m.Filter())
+	}
+}
+
+func TestViewportShowsReviewWithDiffStat(t *testing.T) {
+	cleanupComponentStorage(t)
+	defer cleanupComponentStorage(t)
+
+	rw, relativePath := writeRun(t, "review-stat-task", 3)
+	defer rw.Close()
+
+	waiting := &task.Task{ID: "review-stat-task", Status: task.NeedsReview, Review: &task.ReviewRequest{
+		Question: "Recursive descent or a generator?",
+		Options:  []task.ReviewOption{{ID: "rd", Label: "Recursive descent"}},
+		DiffStat: " lexer.go | 3 +++\n 1 file changed, 3 insertions(+)",
+	}}
+	m := outputViewport.NewModel()
+	m.SetSize(100, 40)
+	m.SetViewingTask(waiting, relativePath)
+
+	panel := m.ReviewPanel()
+	for _, want := range []string{"Needs review: Recursive descent or a generator?", "1. Recursive descent", "Changes so far:", "lexer.go | 3 +++", "1 file changed"} {
+		if !strings.Contains(panel, want) {
+			t.Errorf("expected review panel to contain %q, got %q", want, panel)
+		}
+	}
+	if !strings.Contains(m.View(), "lexer.go") {
+		t.Errorf("expected the diff stat to be rendered in the view")
+	}
+
+	waiting.Status = task.Completed
+	if m.ReviewPanel() != "" {
+		t.Errorf("expected no review panel once the task has moved on")
+	}
+}
+
+func TestViewportShowsSummary(t *testing.T) {
+	cleanupComponentStorage(t)
+	defer cleanupComponentStorage(t)
+
+	rw, relativePath := writeRun(t, "summarised-task", 3)
+	defer rw.Close()
+
+	viewed := &task.Task{ID: "summarised-task", Status: task.Completed, Summary: "Added the lexer\nAdded tests\nUpdated docs\nFixed lint\nBumped version"}
+	m := outputViewport.NewModel()
+	m.SetSize(100, 40)
+	m.SetViewingTask(viewed, relativePath)
+
+	summary := m.SummaryPanel()
+	for _, want := range []string{"Summary:", "Added the lexer", "Updated docs", "(2 more lines)"} {
+		if !strings.Contains(summary, want) {
+			t.Errorf("expected summary to contain %q, got %q", want, summary)
+		}
+	}
+	if strings.Contains(summary, "Bumped version") {
+		t.Errorf("expected long summaries to be cut short, got %q", summary)
+	}
+}
+
+func TestViewportFollowScrollsToNewOutput(t *testing.T) {
+	cleanupComponentStorage(t)
+	defer cleanupComponentStorage(t)
+
+	rw, relativePath := writeRun(t, "followed-task", 200)
+	defer rw.Close()
+
+	m := outputViewport.NewModel()
+	m.SetViewingTask(&task.Task{ID: "followed-task", Name: "Build API", Status: task.InProgress}, relativePath)
+	m.Follow()
+	if !m.Following() || !strings.Contains(m.View(), "Following Build API") {
+		t.Fatalf("expected the view to say the task is followed")
+	}
+
+	offset := m.ScrollOffset()
+	rw.WriteChunk("first new line\nsecond new line\n")
+	m.Refresh()
+	if m.ScrollOffset() <= offset || !strings.Contains(m.View(), "second new line") {
+		t.Errorf("expected new output to scroll the followed view, offset %d -> %d", offset, m.ScrollOffset())
+	}
+
+	// Scrolling up pauses following until End
+	m.Update(tea.KeyMsg{Type: tea.KeyPgUp})
+	offset = m.ScrollOffset()
+	rw.WriteChunk("while paused\n")
+	m.Refresh()
+	if m.ScrollOffset() != offset || !strings.Contains(m.View(), "Paused following Build API") {
+		t.Errorf("expected following to pause while scrolled up, offset %d -> %d", offset, m.ScrollOffset())
+	}
+	m.Update(tea.KeyMsg{Type: tea.KeyEnd})
+	if !strings.Contains(m.View(), "while paused") {
+		t.Errorf("expected End to resume following")
+	}
+
+	m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if m.Following() {
+		t.Errorf("expected Esc to stop following")
+	}
+}
+
+func TestViewportTrimsOldestLinesPastLimit(t *testing.T) {
+	cleanupComponentStorage(t)
+	defer cleanupComponentStorage(t)
+
+	rw, relativePath := writeRun(t, "trim-task", 0)
+	defer rw.Close()
+	rw.WriteChunk("first line\n")
+	for i := 0; i < 9; i++ {
+		rw.WriteChunk("early line\n")
+	}
+
+	m := outputViewport.NewModel()
+	m.SetSize(100, 40)
+	m.SetMaxLines(15)
+	m.SetViewingTask(&task.Task{ID: "trim-task"}, relativePath)
+	if strings.Contains(m.View(), "trimmed") {
+		t.Fatalf("expected no trimmed note while under the limit")
+	}
+
+	for i := 0; i < 10; i++ {
+		rw.WriteChunk(fmt.Sprintf("late line %d\n", i))
+	}
+	if !m.Refresh() {
+		t.Fatalf("expected refresh after the file grew")
+	}
+
+	content := m.Content()
+	if got := strings.Count(content, "\n"); got != 15 {
+		t.Errorf("expected 15 lines kept, got %d", got)
+	}
+	if strings.Contains(content, "first line") {
+		t.Errorf("expected the oldest line to be dropped, got %q", content)
+	}
+	if !strings.Contains(content, "late line 9") {
+		t.Errorf("expected the newest line to be kept, got %q", content)
+	}
+	if !strings.Contains(m.View(), "5 earlier lines trimmed") {
+		t.Errorf("expected a note that earlier lines were trimmed, got %q", m.View())
+	}
+
+	// Loading the full output brings everything back
+	m.LoadFull()
+	if !strings.Contains(m.Content(), "first line") || strings.Contains(m.View(), "trimmed") {
+		t.Errorf("expected the full output without the trimmed note, got %q", m.Content())
+	}
+}
@@ -0,0 +1,105 @@
+package components_test
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"ludwig/internal/components/commandInput"
+	"ludwig/internal/components/outputViewport"
+	"ludwig/internal/types/task"
+)
+
+// widestLine returns the printable width of the widest line in s
+func widestLine(s string) int {
+	widest := 0
+	for _, line := range strings.Split(s, "\n") {
+		widest = max(widest, lipgloss.Width(line))
+	}
+	return widest
+}
+
+// bottomBorderWidth returns the printable width of the output bubble's bottom border
+func bottomBorderWidth(s string) int {
+	for _, line := range strings.Split(s, "\n") {
+		if strings.Contains(line, "╰") {
+			return lipgloss.Width(strings.TrimRight(line, " "))
+		}
+	}
+	return 0
+}
+
+func TestViewportReflowsOnWindowResize(t *testing.T) {
+	cleanupComponentStorage(t)
+	defer cleanupComponentStorage(t)
+
+	rw, relativePath := writeRun(t, "resize-task", 3)
+	defer rw.Close()
+
+	m := outputViewport.NewModel()
+	m.SetViewingTask(&task.Task{ID: "resize-task"}, relativePath)
+
+	m.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+	wideView := m.View()
+	wide := bottomBorderWidth(wideView)
+
+	m.Update(tea.WindowSizeMsg{Width: 70, Height: 30})
+	narrowView := m.View()
+	narrow := bottomBorderWidth(narrowView)
+
+	if wide == 0 || wide-narrow != 50 {
+		t.Errorf("expected output bubble to shrink by 50 columns, got %d -> %d", wide, narrow)
+	}
+	if lines := strings.Count(wideView, "\n") - strings.Count(narrowView, "\n"); lines != 10 {
+		t.Errorf("expected output bubble to lose 10 rows, lost %d", lines)
+	}
+}
+
+func TestCommandInputReflowsOnWindowResize(t *testing.T) {
+	m := commandInput.NewModel()
+
+	m.Update(tea.WindowSizeMsg{Width: 100, Height: 30})
+	wide := widestLine(m.View())
+
+	m.Update(tea.WindowSizeMsg{Width: 60, Height: 30})
+	narrow := widestLine(m.View())
+
+	if wide-narrow != 40 {
+		t.Errorf("expected input to shrink by 40 columns, got %d -> %d", wide, narrow)
+	}
+}
+
+func TestBubbleStyleIsNotSizedAtInit(t *testing.T) {
+	// The bubble is sized on each render; a width captured at package init
+	// would freeze it at whatever the terminal was when ludwig started
+	if w := outputViewport.BUBBLE_STYLE.GetWidth(); w != 0 {
+		t.Errorf("expected BUBBLE_STYLE to have no fixed width, got %d", w)
+	}
+	if h := outputViewport.BUBBLE_STYLE.GetHeight(); h != 0 {
+		t.Errorf("expected BUBBLE_STYLE to have no fixed height, got %d", h)
+	}
+}
+
+func TestViewportRefitsWhenViewedTaskGainsPanels(t *testing.T) {
+	cleanupComponentStorage(t)
+	defer cleanupComponentStorage(t)
+
+	rw, relativePath := writeRun(t, "growing-task", 3)
+	defer rw.Close()
+
+	m := outputViewport.NewModel()
+	m.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+	m.SetViewingTask(&task.Task{ID: "growing-task"}, relativePath)
+	before := strings.Count(m.View(), "\n")
+
+	m.UpdateViewingTask(&task.Task{ID: "growing-task", Summary: "Added a parser\nWith tests\nAnd docs"})
+	after := m.View()
+	if !strings.Contains(after, "Added a parser") {
+		t.Fatalf("expected the new summary to be shown, got %q", after)
+	}
+	if lines := strings.Count(after, "\n"); lines != before {
+		t.Errorf("expected the view to keep its height of %d rows, got %d", before, lines)
+	}
+}
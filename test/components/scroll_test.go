@@ -0,0 +1,64 @@
+package components_test
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"ludwig/internal/components/outputViewport"
+	"ludwig/internal/types/task"
+)
+
+func pressKey(m *outputViewport.Model, key tea.KeyType) int {
+	m.Update(tea.KeyMsg{Type: key})
+	return m.ScrollOffset()
+}
+
+func TestViewportScrollKeys(t *testing.T) {
+	cleanupComponentStorage(t)
+	defer cleanupComponentStorage(t)
+
+	rw, relativePath := writeRun(t, "scroll-task", 300)
+	defer rw.Close()
+
+	m := outputViewport.NewModel()
+	m.SetSize(100, 40)
+	m.SetViewingTask(&task.Task{ID: "scroll-task"}, relativePath)
+	bottom := m.ScrollOffset()
+	if bottom == 0 {
+		t.Fatalf("expected the viewport to open at the bottom of a long output")
+	}
+
+	if got := pressKey(&m, tea.KeyHome); got != 0 {
+		t.Errorf("Home: expected offset 0, got %d", got)
+	}
+	if got := pressKey(&m, tea.KeyDown); got != 1 {
+		t.Errorf("Down: expected offset 1, got %d", got)
+	}
+	if got := pressKey(&m, tea.KeyUp); got != 0 {
+		t.Errorf("Up: expected offset 0, got %d", got)
+	}
+
+	page := pressKey(&m, tea.KeyPgDown)
+	if page < 2 {
+		t.Fatalf("PgDown: expected a full page of movement, got %d", page)
+	}
+	if got := pressKey(&m, tea.KeyPgUp); got != 0 {
+		t.Errorf("PgUp: expected to return to 0, got %d", got)
+	}
+
+	// The Ctrl bindings still move half a page
+	if got := pressKey(&m, tea.KeyCtrlS); got != page/2 {
+		t.Errorf("Ctrl+S: expected offset %d, got %d", page/2, got)
+	}
+	if got := pressKey(&m, tea.KeyCtrlW); got != 0 {
+		t.Errorf("Ctrl+W: expected offset 0, got %d", got)
+	}
+
+	if got := pressKey(&m, tea.KeyEnd); got != bottom {
+		t.Errorf("End: expected offset %d, got %d", bottom, got)
+	}
+	if got := pressKey(&m, tea.KeyDown); got != bottom {
+		t.Errorf("Down at the bottom: expected to stay at %d, got %d", bottom, got)
+	}
+}
@@ -0,0 +1,139 @@
+package components_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"ludwig/internal/components/outputViewport"
+	"ludwig/internal/storage"
+	"ludwig/internal/types/task"
+)
+
+// searchViewport shows 100 lines of output with errors on lines 10, 50 and 90
+func searchViewport(t *testing.T) *outputViewport.Model {
+	t.Helper()
+	cleanupComponentStorage(t)
+	t.Cleanup(func() { cleanupComponentStorage(t) })
+
+	rw, relativePath, err := storage.NewResponseWriter("search-task")
+	if err != nil {
+		t.Fatalf("failed to create response writer: %v", err)
+	}
+	t.Cleanup(func() { rw.Close() })
+	rw.WriteChunk("skip one\nskip two\n")
+	for i := 0; i < 100; i++ {
+		line := fmt.Sprintf("line %d ok", i)
+		if i%40 == 10 {
+			line = fmt.Sprintf("line %d Error: parser.go failed", i)
+		}
+		rw.WriteChunk(line + "\n")
+	}
+
+	m := outputViewport.NewModel()
+	m.SetSize(100, 30)
+	m.SetViewingTask(&task.Task{ID: "search-task"}, relativePath)
+	return &m
+}
+
+func typeSearch(m *outputViewport.Model, query string) {
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("/")})
+	for _, r := range query {
+		m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+	}
+	m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+}
+
+func pressRune(m *outputViewport.Model, key string) {
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(key)})
+}
+
+func TestSearchNavigatesMatches(t *testing.T) {
+	m := searchViewport(t)
+	m.Update(tea.KeyMsg{Type: tea.KeyHome})
+
+	typeSearch(m, "error")
+	if m.Searching() {
+		t.Fatalf("expected Enter to finish typing the query")
+	}
+	if m.MatchCount() != 3 {
+		t.Fatalf("expected 3 case-insensitive matches, got %d", m.MatchCount())
+	}
+	first := m.CurrentMatchLine()
+	if !strings.Contains(m.Notice(), "Match 1/3") {
+		t.Errorf("expected the match position in the notice, got %q", m.Notice())
+	}
+
+	pressRune(m, "n")
+	second := m.CurrentMatchLine()
+	if second != first+40 {
+		t.Errorf("expected n to move 40 lines down to the next match, got line %d after %d", second, first)
+	}
+	if m.ScrollOffset() > second || second >= m.ScrollOffset()+30 {
+		t.Errorf("expected the match on line %d to be scrolled into view, offset %d", second, m.ScrollOffset())
+	}
+
+	pressRune(m, "n")
+	pressRune(m, "n")
+	if m.CurrentMatchLine() != first {
+		t.Errorf("expected n to wrap round to the first match, got line %d", m.CurrentMatchLine())
+	}
+	pressRune(m, "N")
+	if m.CurrentMatchLine() != first+80 || !strings.Contains(m.Notice(), "Match 3/3") {
+		t.Errorf("expected N to wrap back to the last match, got line %d (%q)", m.CurrentMatchLine(), m.Notice())
+	}
+}
+
+func TestSearchNoMatches(t *testing.T) {
+	m := searchViewport(t)
+	offset := m.ScrollOffset()
+
+	typeSearch(m, "panic")
+
+	if m.MatchCount() != 0 || m.CurrentMatchLine() != -1 || !strings.Contains(m.Notice(), "No matches") {
+		t.Errorf("expected no matches to be reported, got %d (%q)", m.MatchCount(), m.Notice())
+	}
+	pressRune(m, "n")
+	if m.ScrollOffset() != offset {
+		t.Errorf("expected n without matches not to scroll")
+	}
+}
+
+func TestSearchLiteralVersusRegex(t *testing.T) {
+	m := searchViewport(t)
+
+	if err := m.Search("line 1.", false); err != nil || m.MatchCount() != 0 {
+		t.Errorf("expected a literal '.' to match nothing, got %d (err %v)", m.MatchCount(), err)
+	}
+	if err := m.Search(`line [0-9]0 `, true); err != nil || m.MatchCount() != 9 {
+		t.Errorf("expected the regex to match lines 10, 20 ... 90, got %d (err %v)", m.MatchCount(), err)
+	}
+	if err := m.Search("line (", true); err == nil {
+		t.Error("expected an invalid regex to be rejected")
+	}
+
+	// Ctrl+R switches the typed query to a regex
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("/")})
+	m.Update(tea.KeyMsg{Type: tea.KeyCtrlR})
+	for _, r := range "Error: .*go" {
+		m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+	}
+	m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if m.MatchCount() != 3 {
+		t.Errorf("expected the regex search to find 3 matches, got %d", m.MatchCount())
+	}
+}
+
+func TestSearchEscCancelsTyping(t *testing.T) {
+	m := searchViewport(t)
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("/")})
+	if !m.Searching() || !strings.Contains(m.View(), "Search: /") {
+		t.Fatalf("expected / to open the search prompt")
+	}
+	m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if m.Searching() || m.Content() == "" {
+		t.Errorf("expected Esc to cancel the search and keep the output")
+	}
+}
@@ -0,0 +1,98 @@
+package components_test
+
+import (
+	"slices"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"ludwig/internal/components/taskForm"
+	"ludwig/internal/types/task"
+)
+
+// typeInto sends text to the form one rune at a time, as a user typing would
+func typeInto(form taskForm.Model, text string) taskForm.Model {
+	for _, r := range text {
+		form, _ = form.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+	}
+	return form
+}
+
+func press(form taskForm.Model, key tea.KeyType) (taskForm.Model, tea.Msg) {
+	form, cmd := form.Update(tea.KeyMsg{Type: key})
+	if cmd == nil {
+		return form, nil
+	}
+	return form, cmd()
+}
+
+func TestTaskFormBuildsTaskFromFields(t *testing.T) {
+	form := taskForm.NewModel()
+	form = typeInto(form, "Refactor the parser")
+	form, _ = press(form, tea.KeyEnter)
+	form = typeInto(form, "backend, parser backend")
+	form, _ = press(form, tea.KeyTab)
+	form = typeInto(form, "3")
+	form, _ = press(form, tea.KeyEnter)
+	form = typeInto(form, "Keep the public API")
+
+	form, msg := press(form, tea.KeyEnter)
+	submit, ok := msg.(taskForm.SubmitMsg)
+	if !ok {
+		t.Fatalf("expected a SubmitMsg, got %#v (err %v)", msg, form.Err())
+	}
+	got := submit.Task
+	if got.Name != "Refactor the parser\n\nKeep the public API" || got.Title() != "Refactor the parser" {
+		t.Errorf("expected instructions below the name, got %q", got.Name)
+	}
+	if !slices.Equal(got.Tags, []string{"backend", "parser"}) {
+		t.Errorf("expected deduplicated tags, got %q", got.Tags)
+	}
+	if got.Priority != 3 || got.Status != task.Pending || got.ID == "" || got.CreatedAt.IsZero() {
+		t.Errorf("expected a new Pending task with priority 3, got %+v", got)
+	}
+}
+
+func TestTaskFormOptionalFieldsDefault(t *testing.T) {
+	form := typeInto(taskForm.NewModel(), "Write docs")
+
+	got, err := form.Task()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got.Name != "Write docs" || got.Priority != 0 || len(got.Tags) != 0 {
+		t.Errorf("expected only a name, got %+v", got)
+	}
+}
+
+func TestTaskFormValidation(t *testing.T) {
+	form := taskForm.NewModel()
+	form, _ = press(form, tea.KeyShiftTab) // Wraps round to the instructions
+	if form.Focused() != taskForm.FIELD_INSTRUCTIONS {
+		t.Fatalf("expected Shift+Tab to wrap to the last field, got %d", form.Focused())
+	}
+	form, msg := press(form, tea.KeyEnter)
+	if msg != nil || form.Err() == nil || form.Focused() != taskForm.FIELD_NAME {
+		t.Errorf("expected a missing name to be rejected and focused, got %#v (err %v)", msg, form.Err())
+	}
+
+	form = typeInto(form, "Tune the cache")
+	form, _ = press(form, tea.KeyTab)
+	form, _ = press(form, tea.KeyTab)
+	form = typeInto(form, "high")
+	if _, err := form.Task(); err == nil {
+		t.Error("expected a non-numeric priority to be rejected")
+	}
+	form, _ = press(form, tea.KeyTab)
+	form, msg = press(form, tea.KeyEnter)
+	if msg != nil || form.Focused() != taskForm.FIELD_PRIORITY {
+		t.Errorf("expected submit to return to the priority field, got %#v focused on %d", msg, form.Focused())
+	}
+}
+
+func TestTaskFormEscCancels(t *testing.T) {
+	form := typeInto(taskForm.NewModel(), "Half typed")
+	if _, msg := press(form, tea.KeyEsc); msg != (taskForm.CancelMsg{}) {
+		t.Errorf("expected Esc to cancel, got %#v", msg)
+	}
+}
@@ -0,0 +1,71 @@
+package kanban_test
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/x/ansi"
+
+	"ludwig/internal/config"
+	"ludwig/internal/kanban"
+	"ludwig/internal/types/task"
+)
+
+func TestCustomStatusGetsItsOwnColumn(t *testing.T) {
+	kanban.SetCustomStatuses([]config.StatusColumn{
+		{Name: "Blocked", Color: "90", Order: 15},
+		{Name: "Testing", Order: 35},
+		{Name: "Done"}, // Already a built-in status
+	})
+	t.Cleanup(func() { kanban.SetCustomStatuses(nil) })
+
+	testingStatus, err := task.ParseStatus("testing")
+	if err != nil || !testingStatus.IsCustom() {
+		t.Fatalf("expected Testing to be a custom status, got %v (%v)", testingStatus, err)
+	}
+	tasks := []task.Task{
+		{ID: "todo", Name: "Plan it", Status: task.Pending},
+		{ID: "stuck", Name: "Waiting on infra", Status: task.CustomStatus("Blocked")},
+		{ID: "checking", Name: "Try the build", Status: testingStatus},
+	}
+
+	titleLine := strings.Split(kanban.RenderKanban(tasks), "\n")[1]
+	order := []string{"To Do (1)", "Blocked (1)", "In Progress (0)", "In Review (0)", "Testing (1)", "Completed (0)"}
+	last := -1
+	for _, title := range order {
+		at := strings.Index(titleLine, title)
+		if at <= last {
+			t.Fatalf("expected columns in the order %v, got %q", order, titleLine)
+		}
+		last = at
+	}
+	if strings.Contains(titleLine, "Done") {
+		t.Errorf("expected a custom status named like a built-in to be ignored, got %q", titleLine)
+	}
+
+	// Tasks are routed to the column of their status
+	row := strings.Split(ansi.Strip(kanban.RenderKanban(tasks)), "\n")[3]
+	var cells []string
+	for i, cell := range strings.Split(row, "│") {
+		if i%2 == 1 {
+			cells = append(cells, strings.TrimSpace(cell))
+		}
+	}
+	want := []string{"todo Plan it", "stuck Waiting on infra", "", "", "checki Try the build", ""}
+	if !slices.Equal(cells, want) {
+		t.Errorf("expected tasks in the columns of their status\ngot:  %q\nwant: %q", cells, want)
+	}
+	if !strings.Contains(kanban.RenderKanban(tasks), "\x1b[90m") {
+		t.Errorf("expected the Blocked column to use its configured color")
+	}
+}
+
+func TestCustomStatusesCanBeCleared(t *testing.T) {
+	kanban.SetCustomStatuses([]config.StatusColumn{{Name: "Parked"}})
+	kanban.SetCustomStatuses(nil)
+
+	if strings.Contains(kanban.RenderKanban(nil), "Parked") {
+		t.Errorf("expected the custom column to be removed")
+	}
+}
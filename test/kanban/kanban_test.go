@@ -0,0 +1,164 @@
+package kanban_test
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"ludwig/internal/kanban"
+	"ludwig/internal/types/task"
+)
+
+func completedTasks(n int) []task.Task {
+	base := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	tasks := make([]task.Task, n)
+	for i := range tasks {
+		tasks[i] = task.Task{
+			ID:        fmt.Sprintf("%06d-task", i),
+			Name:      "Done " + strconv.Itoa(i),
+			Status:    task.Completed,
+			CreatedAt: base.Add(time.Duration(i) * time.Minute),
+		}
+	}
+	return tasks
+}
+
+func TestRenderKanbanCapsLongColumns(t *testing.T) {
+	tasks := completedTasks(25)
+	tasks = append(tasks, task.Task{ID: "pending", Name: "Still to do", Status: task.Pending})
+
+	board := kanban.RenderKanbanWithLimit(tasks, 5)
+
+	// Only the 5 most recently created completed tasks are shown
+	for i := 20; i < 25; i++ {
+		if !strings.Contains(board, fmt.Sprintf("%06d Done %d", i, i)) {
+			t.Errorf("expected board to show recent task %d", i)
+		}
+	}
+	if strings.Contains(board, "Done 19") {
+		t.Errorf("expected older tasks to be hidden")
+	}
+	if !strings.Contains(board, "+ 20 more") {
+		t.Errorf("expected overflow indicator for 20 hidden tasks")
+	}
+	if !strings.Contains(board, "pendin Still to do") {
+		t.Errorf("expected pending task to be shown with its short ID")
+	}
+
+	// Header (3 lines) + 5 tasks + overflow line
+	if rows := strings.Count(board, "\n"); rows != 3+5+1 {
+		t.Errorf("expected %d rows, got %d", 3+5+1, rows)
+	}
+}
+
+func TestRenderKanbanNoOverflowUnderLimit(t *testing.T) {
+	board := kanban.RenderKanbanWithLimit(completedTasks(3), 5)
+
+	if strings.Contains(board, "more") {
+		t.Errorf("expected no overflow indicator when under the limit")
+	}
+}
+
+func TestRenderKanbanNegativeLimitShowsAll(t *testing.T) {
+	board := kanban.RenderKanbanWithLimit(completedTasks(25), -1)
+
+	if strings.Contains(board, "more") {
+		t.Errorf("expected no overflow indicator with a negative limit")
+	}
+	if !strings.Contains(board, "000000 Done 0") {
+		t.Errorf("expected oldest task to be shown with a negative limit")
+	}
+}
+
+func TestRenderKanbanUsesDefaultLimit(t *testing.T) {
+	board := kanban.RenderKanban(completedTasks(kanban.DEFAULT_COLUMN_LIMIT + 2))
+
+	if !strings.Contains(board, "+ 2 more") {
+		t.Errorf("expected default limit to hide 2 tasks")
+	}
+}
+
+func TestRenderKanbanHidesArchivedTasks(t *testing.T) {
+	tasks := []task.Task{
+		{ID: "visible", Name: "Still on the board", Status: task.Completed},
+		{ID: "archived", Name: "Put away", Status: task.Completed, Archived: true},
+	}
+
+	board := kanban.RenderKanban(tasks)
+
+	if !strings.Contains(board, "Still on the board") {
+		t.Errorf("expected unarchived task to be shown")
+	}
+	if strings.Contains(board, "Put away") {
+		t.Errorf("expected archived task to be hidden from the board")
+	}
+}
+
+func TestRenderKanbanShowsFailedColumnOnlyWhenNeeded(t *testing.T) {
+	healthy := []task.Task{{ID: "ok", Name: "Fine", Status: task.Completed}}
+	if strings.Contains(kanban.RenderKanban(healthy), "Failed") {
+		t.Errorf("expected no Failed column when nothing has failed")
+	}
+
+	failed := append(healthy, task.Task{ID: "bad", Name: "Broke", Status: task.Failed})
+	board := kanban.RenderKanban(failed)
+	if !strings.Contains(board, "Failed") || !strings.Contains(board, "bad Broke") {
+		t.Errorf("expected a Failed column containing the failed task")
+	}
+}
+
+func TestRenderKanbanHeaderCountsTasks(t *testing.T) {
+	tasks := append(completedTasks(12),
+		task.Task{ID: "todo-1", Name: "First", Status: task.Pending},
+		task.Task{ID: "todo-2", Name: "Second", Status: task.Pending},
+		task.Task{ID: "doing", Name: "Busy", Status: task.InProgress},
+		task.Task{ID: "hidden", Name: "Archived", Status: task.Pending, Archived: true},
+	)
+
+	lines := strings.Split(kanban.RenderKanbanWithLimit(tasks, 5), "\n")
+	titleLine := lines[1]
+	// Completed counts the tasks the column limit hides, but not archived ones
+	for _, title := range []string{"To Do (2)", "In Progress (1)", "In Review (0)", "Completed (12)"} {
+		if !strings.Contains(titleLine, title) {
+			t.Errorf("expected the header to contain %q, got %q", title, titleLine)
+		}
+	}
+	border := strings.TrimRight(lines[0], " ")
+	if got, want := lipgloss.Width(titleLine), lipgloss.Width(border); got != want {
+		t.Errorf("expected the titles to stay within the columns, %d columns wide instead of %d", got, want)
+	}
+}
+
+func TestFormatElapsed(t *testing.T) {
+	if got := kanban.FormatElapsed(2*time.Minute + 14*time.Second + 300*time.Millisecond); got != "2m14s" {
+		t.Errorf("expected 2m14s, got %q", got)
+	}
+}
+
+func TestRenderKanbanShowsElapsedTimeForInProgress(t *testing.T) {
+	started := time.Now().Add(-2 * time.Minute)
+	tasks := []task.Task{
+		{ID: "running", Name: "Build API", Status: task.InProgress, StartedAt: started},
+		{ID: "long-running", Name: "A task with a fairly long description that needs truncating", Status: task.InProgress, StartedAt: started},
+		{ID: "unstarted", Name: "Not yet picked up", Status: task.InProgress},
+		{ID: "waiting", Name: "Still to do", Status: task.Pending, StartedAt: started},
+	}
+
+	board := kanban.RenderKanban(tasks)
+	if !strings.Contains(board, "runnin Build API (2m") {
+		t.Errorf("expected the running task to show how long it has run, got:\n%s", board)
+	}
+	if !strings.Contains(board, "long-r A task with a fair... (2m") {
+		t.Errorf("expected a long title to be shortened to keep its elapsed time, got:\n%s", board)
+	}
+	if strings.Contains(board, "Not yet picked up (") {
+		t.Errorf("expected no elapsed time for a task without a start time")
+	}
+	if strings.Contains(board, "Still to do (") {
+		t.Errorf("expected elapsed time only on In Progress tasks")
+	}
+}
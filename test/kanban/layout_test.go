@@ -0,0 +1,63 @@
+package kanban_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"ludwig/internal/kanban"
+	"ludwig/internal/types/task"
+)
+
+func layoutTasks() []task.Task {
+	return []task.Task{
+		{ID: "1", Name: "A task with a fairly long description that needs truncating", Status: task.Pending},
+		{ID: "2", Name: "Short", Status: task.InProgress},
+		{ID: "3", Name: "Review me", Status: task.NeedsReview},
+		{ID: "4", Name: "Done", Status: task.Completed},
+		{ID: "5", Name: "Another pending task", Status: task.Pending},
+	}
+}
+
+func TestRenderKanbanToFitNeverExceedsWidth(t *testing.T) {
+	for _, width := range []int{60, 80, 120, 161, 200} {
+		board := kanban.RenderKanbanToFit(layoutTasks(), 0, width)
+		for i, line := range strings.Split(board, "\n") {
+			if w := lipgloss.Width(line); w > width {
+				t.Errorf("width %d: line %d is %d columns wide: %q", width, i, w, line)
+			}
+		}
+	}
+}
+
+func TestRenderKanbanToFitStacksOnNarrowTerminals(t *testing.T) {
+	board := kanban.RenderKanbanToFit(layoutTasks(), 0, 80)
+
+	// Stacked columns each get their own header, so titles appear on separate lines
+	for _, title := range []string{"To Do", "In Progress", "In Review", "Completed"} {
+		if !strings.Contains(board, title) {
+			t.Errorf("expected stacked board to contain %q", title)
+		}
+	}
+	for _, line := range strings.Split(board, "\n") {
+		if strings.Contains(line, "To Do") && strings.Contains(line, "In Progress") {
+			t.Errorf("expected columns to be stacked at 80 columns, got side by side: %q", line)
+		}
+	}
+	if !strings.Contains(board, "2 Short") {
+		t.Errorf("expected short IDs to be shown when stacked")
+	}
+}
+
+func TestRenderKanbanToFitSideBySideOnWideTerminals(t *testing.T) {
+	board := kanban.RenderKanbanToFit(layoutTasks(), 0, 200)
+
+	if board != kanban.RenderKanbanWithLimit(layoutTasks(), 0) {
+		t.Errorf("expected a wide terminal to render the full-width board")
+	}
+	titleLine := strings.Split(board, "\n")[1]
+	if !strings.Contains(titleLine, "To Do") || !strings.Contains(titleLine, "Completed") {
+		t.Errorf("expected all column titles on one line, got %q", titleLine)
+	}
+}
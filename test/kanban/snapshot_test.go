@@ -0,0 +1,83 @@
+package kanban_test
+
+import (
+	"io"
+	"os"
+	"strings"
+	"testing"
+
+	"ludwig/internal/kanban"
+	"ludwig/internal/types/task"
+)
+
+// captureStdout returns everything written to os.Stdout while fn runs
+func captureStdout(t *testing.T, fn func()) string {
+	t.Helper()
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatalf("failed to create pipe: %v", err)
+	}
+	stdout := os.Stdout
+	os.Stdout = w
+	defer func() { os.Stdout = stdout }()
+
+	fn()
+	w.Close()
+	out, err := io.ReadAll(r)
+	if err != nil {
+		t.Fatalf("failed to read captured output: %v", err)
+	}
+	return string(out)
+}
+
+func snapshotTasks() []task.Task {
+	return []task.Task{
+		{ID: "aaaaaa-1", Name: "Write docs", Status: task.Pending},
+		{ID: "bbbbbb-2", Name: "Fix login", Status: task.InProgress},
+		{ID: "cccccc-3", Name: "Pick a database", Status: task.NeedsReview},
+		{ID: "dddddd-4", Name: "Set up CI", Status: task.Completed},
+	}
+}
+
+func TestSnapshotPrintsEveryColumnAsPlainText(t *testing.T) {
+	out := captureStdout(t, func() {
+		kanban.WriteSnapshot(os.Stdout, snapshotTasks(), 0, 0, false)
+	})
+
+	for _, header := range []string{"To Do", "In Progress", "In Review", "Completed"} {
+		if !strings.Contains(out, header) {
+			t.Errorf("expected %q header in snapshot:\n%s", header, out)
+		}
+	}
+	for _, name := range []string{"aaaaaa Write docs", "dddddd Set up CI"} {
+		if !strings.Contains(out, name) {
+			t.Errorf("expected %q in snapshot:\n%s", name, out)
+		}
+	}
+	if strings.Contains(out, "\x1b") {
+		t.Errorf("expected no escape codes without color, got %q", out)
+	}
+}
+
+func TestSnapshotIsStable(t *testing.T) {
+	first := captureStdout(t, func() { kanban.WriteSnapshot(os.Stdout, snapshotTasks(), 0, 0, false) })
+	second := captureStdout(t, func() { kanban.WriteSnapshot(os.Stdout, snapshotTasks(), 0, 0, false) })
+	if first != second {
+		t.Errorf("expected identical snapshots for the same tasks")
+	}
+}
+
+func TestSnapshotKeepsColorForTerminals(t *testing.T) {
+	out := captureStdout(t, func() {
+		kanban.WriteSnapshot(os.Stdout, snapshotTasks(), 0, 200, true)
+	})
+	if !strings.Contains(out, "\x1b[") {
+		t.Errorf("expected colored output for a terminal")
+	}
+	// Alt-screen and cursor control codes belong to the TUI, never a snapshot
+	for _, code := range []string{"\x1b[?1049h", "\x1b[2J", "\x1b[H"} {
+		if strings.Contains(out, code) {
+			t.Errorf("unexpected screen control code %q in snapshot", code)
+		}
+	}
+}
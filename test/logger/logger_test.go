@@ -0,0 +1,80 @@
+package logger_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"ludwig/internal/logger"
+)
+
+func TestRecentReturnsNewestEntriesOldestFirst(t *testing.T) {
+	logger.Reset()
+	defer logger.Reset()
+
+	for i := 1; i <= 5; i++ {
+		logger.Infof("entry %d", i)
+	}
+
+	recent := logger.Recent(3)
+	if len(recent) != 3 {
+		t.Fatalf("expected 3 entries, got %d", len(recent))
+	}
+	for i, expected := range []string{"entry 3", "entry 4", "entry 5"} {
+		if recent[i].Message != expected {
+			t.Errorf("entry %d: expected %q, got %q", i, expected, recent[i].Message)
+		}
+	}
+	if all := logger.Recent(0); len(all) != 5 {
+		t.Errorf("expected Recent(0) to return all 5 entries, got %d", len(all))
+	}
+}
+
+func TestLevelFiltering(t *testing.T) {
+	logger.Reset()
+	defer logger.Reset()
+
+	logger.Debugf("hidden by default")
+	logger.Warnf("shown")
+	if recent := logger.Recent(0); len(recent) != 1 || recent[0].Level != logger.LevelWarn {
+		t.Fatalf("expected only the warning to be recorded, got %v", recent)
+	}
+
+	logger.SetLevel(logger.LevelDebug)
+	logger.Debugf("now visible")
+	if logger.LastSeq() != 2 {
+		t.Errorf("expected debug entry to be recorded after lowering the level, last seq %d", logger.LastSeq())
+	}
+}
+
+func TestBufferDropsOldestEntries(t *testing.T) {
+	logger.Reset()
+	defer logger.Reset()
+
+	total := logger.BUFFER_SIZE + 10
+	for i := 0; i < total; i++ {
+		logger.Infof("entry %d", i)
+	}
+
+	all := logger.Recent(0)
+	if len(all) != logger.BUFFER_SIZE {
+		t.Fatalf("expected buffer to hold %d entries, got %d", logger.BUFFER_SIZE, len(all))
+	}
+	if all[0].Message != "entry 10" {
+		t.Errorf("expected oldest kept entry to be entry 10, got %q", all[0].Message)
+	}
+	if last := all[len(all)-1]; last.Message != fmt.Sprintf("entry %d", total-1) || last.Seq != uint64(total) {
+		t.Errorf("unexpected newest entry: %+v", last)
+	}
+}
+
+func TestEntryString(t *testing.T) {
+	logger.Reset()
+	defer logger.Reset()
+
+	logger.Errorf("task %s broke", "abc123")
+	line := logger.Recent(1)[0].String()
+	if !strings.Contains(line, "ERROR") || !strings.Contains(line, "task abc123 broke") {
+		t.Errorf("unexpected formatted entry: %q", line)
+	}
+}
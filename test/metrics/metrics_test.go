@@ -0,0 +1,48 @@
+package metrics_test
+
+import (
+	"strings"
+	"testing"
+
+	"ludwig/internal/metrics"
+)
+
+func TestCounterAndHistogramExposition(t *testing.T) {
+	counter := metrics.NewCounter("test_events_total", "Events seen.")
+	histogram := metrics.NewHistogram("test_latency_seconds", "Latency.", []float64{1, 0.1})
+
+	counter.Inc()
+	counter.Inc()
+	histogram.Observe(0.05)
+	histogram.Observe(0.5)
+	histogram.Observe(5)
+
+	var out strings.Builder
+	metrics.WriteText(&out)
+	text := out.String()
+
+	for _, want := range []string{
+		"# TYPE test_events_total counter\n",
+		"test_events_total 2\n",
+		"# TYPE test_latency_seconds histogram\n",
+		"test_latency_seconds_bucket{le=\"0.1\"} 1\n",
+		"test_latency_seconds_bucket{le=\"1\"} 2\n",
+		"test_latency_seconds_bucket{le=\"+Inf\"} 3\n",
+		"test_latency_seconds_sum 5.55\n",
+		"test_latency_seconds_count 3\n",
+	} {
+		if !strings.Contains(text, want) {
+			t.Errorf("expected output to contain %q, got:\n%s", want, text)
+		}
+	}
+}
+
+func TestWriteGaugeVecSortsLabels(t *testing.T) {
+	var out strings.Builder
+	metrics.WriteGaugeVec(&out, "test_items", "Items.", "kind", map[string]float64{"b": 2, "a": 1})
+
+	want := "# HELP test_items Items.\n# TYPE test_items gauge\ntest_items{kind=\"a\"} 1\ntest_items{kind=\"b\"} 2\n"
+	if out.String() != want {
+		t.Errorf("got:\n%s\nwant:\n%s", out.String(), want)
+	}
+}
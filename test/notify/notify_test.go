@@ -0,0 +1,53 @@
+package notify_test
+
+import (
+	"testing"
+	"time"
+
+	"ludwig/internal/logger"
+	"ludwig/internal/notify"
+)
+
+// receive returns the next notice on ch, or false if none arrives soon
+func receive(ch <-chan notify.Notice) (notify.Notice, bool) {
+	select {
+	case notice := <-ch:
+		return notice, true
+	case <-time.After(100 * time.Millisecond):
+		return notify.Notice{}, false
+	}
+}
+
+func TestNoticesReachEverySubscriber(t *testing.T) {
+	first, stopFirst := notify.Subscribe()
+	defer stopFirst()
+	second, stopSecond := notify.Subscribe()
+	defer stopSecond()
+
+	notify.Errorf("provider %s unreachable", "ollama")
+	for _, ch := range []<-chan notify.Notice{first, second} {
+		notice, ok := receive(ch)
+		if !ok || notice.Text != "provider ollama unreachable" || notice.Level != logger.LevelError {
+			t.Errorf("expected the error notice, got %+v (received %v)", notice, ok)
+		}
+	}
+}
+
+func TestRepeatedNoticesAreDeduplicated(t *testing.T) {
+	ch, stop := notify.Subscribe()
+	defer stop()
+
+	notify.Warnf("could not list tasks: disk full")
+	notify.Warnf("could not list tasks: disk full")
+	notify.Warnf("could not list tasks: permission denied")
+
+	if notice, ok := receive(ch); !ok || notice.Text != "could not list tasks: disk full" {
+		t.Fatalf("expected the first notice, got %+v", notice)
+	}
+	if notice, ok := receive(ch); !ok || notice.Text != "could not list tasks: permission denied" {
+		t.Errorf("expected the repeat to be dropped and the different notice sent, got %+v", notice)
+	}
+	if notice, ok := receive(ch); ok {
+		t.Errorf("expected no more notices, got %+v", notice)
+	}
+}
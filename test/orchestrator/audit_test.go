@@ -0,0 +1,73 @@
+package orchestrator_test
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"ludwig/internal/orchestrator/clients"
+)
+
+func readAuditLog(t *testing.T, path string) []clients.AuditEntry {
+	t.Helper()
+	file, err := os.Open(path)
+	if err != nil {
+		t.Fatalf("failed to open audit log: %v", err)
+	}
+	defer file.Close()
+
+	var entries []clients.AuditEntry
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		var entry clients.AuditEntry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			t.Fatalf("audit line is not JSON: %q (%v)", scanner.Text(), err)
+		}
+		entries = append(entries, entry)
+	}
+	return entries
+}
+
+func TestAuditedClientAppendsOneLinePerCall(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "logs", clients.AUDIT_FILE)
+	inner := &fakeClient{steps: []func(string) (string, error){
+		func(string) (string, error) { return "hello", nil },
+		func(string) (string, error) { return "", errors.New("model overloaded") },
+	}}
+	client := clients.WithAudit(inner, clients.AuditFile(path))
+	client.TaskID = "task-1"
+
+	if _, err := client.SendPromptWithDir("first prompt", io.Discard, ""); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := client.SendPrompt("second", io.Discard); err == nil {
+		t.Fatal("expected the wrapped client's error to be returned")
+	}
+
+	entries := readAuditLog(t, path)
+	if len(entries) != 2 {
+		t.Fatalf("expected 2 audit lines, got %d", len(entries))
+	}
+
+	first := entries[0]
+	if first.TaskID != "task-1" || first.PromptLength != len("first prompt") || first.ResponseLength != len("hello") {
+		t.Errorf("unexpected first entry: %+v", first)
+	}
+	if first.Timestamp.IsZero() || first.Provider == "" || first.Error != "" {
+		t.Errorf("expected timestamp and provider without error, got %+v", first)
+	}
+	if entries[1].Error != "model overloaded" || entries[1].ResponseLength != 0 {
+		t.Errorf("expected the failure to be recorded, got %+v", entries[1])
+	}
+}
+
+func TestDescribeReportsProviderAndModel(t *testing.T) {
+	provider, model := clients.Describe(&clients.AuditedClient{Client: clients.NewOllamaClient("", "llama3")})
+	if provider != "ollama" || model != "llama3" {
+		t.Errorf("expected ollama/llama3, got %s/%s", provider, model)
+	}
+}
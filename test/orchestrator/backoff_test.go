@@ -0,0 +1,42 @@
+package orchestrator_test
+
+import (
+	"testing"
+	"time"
+
+	"ludwig/internal/orchestrator"
+)
+
+func TestPollBackoffGrowsAndResets(t *testing.T) {
+	backoff := orchestrator.NewPollBackoff()
+	backoff.Jitter = 0
+
+	var waits []time.Duration
+	for i := 0; i < 6; i++ {
+		waits = append(waits, backoff.Next())
+	}
+	want := []time.Duration{2 * time.Second, 4 * time.Second, 8 * time.Second, 16 * time.Second, 30 * time.Second, 30 * time.Second}
+	for i := range want {
+		if waits[i] != want[i] {
+			t.Fatalf("expected waits %v, got %v", want, waits)
+		}
+	}
+
+	// A task turning up puts the loop straight back to the base interval
+	backoff.Reset()
+	if wait := backoff.Next(); wait != orchestrator.POLL_INTERVAL {
+		t.Errorf("expected %v after a reset, got %v", orchestrator.POLL_INTERVAL, wait)
+	}
+}
+
+func TestPollBackoffJitterStaysInBounds(t *testing.T) {
+	backoff := orchestrator.NewPollBackoff()
+	for _, r := range []float64{0, 0.5, 0.999} {
+		backoff.Reset()
+		backoff.Rand = func() float64 { return r }
+		wait := backoff.Next()
+		if wait < 1800*time.Millisecond || wait > 2200*time.Millisecond {
+			t.Errorf("expected 2s ±10%%, got %v for rand %v", wait, r)
+		}
+	}
+}
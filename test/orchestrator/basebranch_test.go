@@ -0,0 +1,110 @@
+package orchestrator_test
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"ludwig/internal/orchestrator"
+	"ludwig/internal/orchestrator/clients"
+	"ludwig/internal/types/task"
+)
+
+func git(t *testing.T, dir string, args ...string) {
+	t.Helper()
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	if out, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("git %v failed: %v: %s", args, err, out)
+	}
+}
+
+// commitOnBranch creates branch from main with one file committed on it
+func commitOnBranch(t *testing.T, repo, branch, file string) {
+	t.Helper()
+	git(t, repo, "checkout", "-q", "-b", branch)
+	if err := os.WriteFile(filepath.Join(repo, file), []byte("package prereq\n"), 0644); err != nil {
+		t.Fatalf("failed to write file: %v", err)
+	}
+	git(t, repo, "add", ".")
+	git(t, repo, "commit", "-q", "-m", "prerequisite work")
+	git(t, repo, "checkout", "-q", "main")
+}
+
+func TestCreateWorktreeFromBaseBranch(t *testing.T) {
+	repo := initTempRepo(t)
+	commitOnBranch(t, repo, "ludwig/prereq", "prereq.go")
+
+	worktreePath, err := orchestrator.CreateWorktree("ludwig/dependent", "dependent-task", "ludwig/prereq")
+	if err != nil {
+		t.Fatalf("failed to create worktree: %v", err)
+	}
+	defer orchestrator.RemoveWorktree(worktreePath)
+
+	if _, err := os.Stat(filepath.Join(worktreePath, "prereq.go")); err != nil {
+		t.Errorf("expected the worktree to start from the base branch's work: %v", err)
+	}
+	if has, err := orchestrator.BranchHasCommits(worktreePath, "ludwig/prereq"); err != nil || has {
+		t.Errorf("expected no commits of its own relative to the base yet, got %v (err %v)", has, err)
+	}
+
+	if err := os.WriteFile(filepath.Join(worktreePath, "dependent.go"), []byte("package dependent\n"), 0644); err != nil {
+		t.Fatalf("failed to write file: %v", err)
+	}
+	diff, err := orchestrator.WorktreeDiff(worktreePath, "ludwig/prereq")
+	if err != nil {
+		t.Fatalf("failed to diff: %v", err)
+	}
+	if !strings.Contains(diff, "dependent.go") || strings.Contains(diff, "prereq.go") {
+		t.Errorf("expected the diff to cover only the task's own changes, got:\n%s", diff)
+	}
+}
+
+func TestCreateWorktreeRejectsMissingBaseBranch(t *testing.T) {
+	initTempRepo(t)
+
+	_, err := orchestrator.CreateWorktree("ludwig/dependent", "dependent-task", "no-such-branch")
+	if !errors.Is(err, orchestrator.ErrBaseBranchNotFound) {
+		t.Errorf("expected ErrBaseBranchNotFound, got %v", err)
+	}
+}
+
+func TestTaskWithMissingBaseBranchFails(t *testing.T) {
+	initTempRepo(t)
+	tk := &task.Task{ID: "based-task", Name: "Build on the parser", Status: task.Pending, BaseBranch: "ludwig/gone"}
+	store := newStoreWithTask(t, tk)
+	client := clients.NewMockClient()
+
+	orchestrator.ProcessTask(store, client, nil, tk)
+
+	got, _ := store.GetTask(tk.ID)
+	if got.Status != task.Failed || !strings.Contains(got.FailureReason, "ludwig/gone") {
+		t.Errorf("expected the task to fail naming the branch, got %v (%q)", got.Status, got.FailureReason)
+	}
+	if len(client.Prompts()) != 0 {
+		t.Errorf("expected the AI not to be called")
+	}
+}
+
+func TestTaskCompletesOnBaseBranch(t *testing.T) {
+	repo := initTempRepo(t)
+	commitOnBranch(t, repo, "ludwig/prereq", "prereq.go")
+	tk := &task.Task{ID: "based-task", Name: "Build on the parser", Status: task.Pending, BaseBranch: "ludwig/prereq"}
+	store := newStoreWithTask(t, tk)
+	client := clients.NewMockClient(clients.MockResponse{Files: map[string]string{"dependent.go": "package dependent\n"}})
+
+	orchestrator.ProcessTask(store, client, nil, tk)
+
+	got, _ := store.GetTask(tk.ID)
+	if got.Status != task.Completed {
+		t.Fatalf("expected the task to complete, got %v (%q)", got.Status, got.FailureReason)
+	}
+	cmd := exec.Command("git", "merge-base", "--is-ancestor", "ludwig/prereq", got.BranchName)
+	cmd.Dir = repo
+	if err := cmd.Run(); err != nil {
+		t.Errorf("expected %s to build on ludwig/prereq: %v", got.BranchName, err)
+	}
+}
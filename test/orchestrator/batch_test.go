@@ -0,0 +1,124 @@
+package orchestrator_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"ludwig/internal/orchestrator"
+	"ludwig/internal/orchestrator/clients"
+	"ludwig/internal/types/task"
+)
+
+func reviewOf(question string) *task.ReviewRequest {
+	return &task.ReviewRequest{Question: question, Options: []task.ReviewOption{{ID: "a", Label: "Option A"}, {ID: "b", Label: "Option B"}}}
+}
+
+func TestRunBatchDrainsQueue(t *testing.T) {
+	initTempRepo(t)
+	store := newStoreWithTask(t, &task.Task{ID: "first", Name: "First task", Status: task.Pending})
+	newStoreWithTask(t, &task.Task{ID: "second", Name: "Second task", Status: task.Pending})
+	newStoreWithTask(t, &task.Task{ID: "third", Name: "Third task", Status: task.Pending})
+	newStoreWithTask(t, &task.Task{ID: "done", Name: "Already done", Status: task.Completed})
+	client := clients.NewMockClient(withChange("one"), withChange("two"), withChange("three"))
+
+	result, err := orchestrator.RunBatch(store, client, orchestrator.BatchOptions{})
+	if err != nil {
+		t.Fatalf("expected the batch to run, got %v", err)
+	}
+	if len(result.Completed) != 3 || len(result.Failed) != 0 || len(result.Remaining) != 0 {
+		t.Fatalf("expected the 3 queued tasks to complete, got %d completed, %d failed, %d remaining",
+			len(result.Completed), len(result.Failed), len(result.Remaining))
+	}
+	if result.ExitCode() != 0 {
+		t.Errorf("expected exit code 0, got %d", result.ExitCode())
+	}
+	var summary strings.Builder
+	result.WriteSummary(&summary)
+	if !strings.Contains(summary.String(), "3 task(s): 3 completed, 0 failed, 0 remaining") {
+		t.Errorf("expected the totals in the summary, got %q", summary.String())
+	}
+}
+
+func TestRunBatchFailsTaskThatKeepsErroring(t *testing.T) {
+	initTempRepo(t)
+	store := newStoreWithTask(t, &task.Task{ID: "broken", Name: "Broken task", Status: task.Pending})
+	client := clients.NewMockClient() // Every prompt fails with ErrMockExhausted
+
+	result, err := orchestrator.RunBatch(store, client, orchestrator.BatchOptions{MaxFailures: 2})
+	if err != nil {
+		t.Fatalf("expected the batch to run, got %v", err)
+	}
+	if len(result.Failed) != 1 || result.ExitCode() != 1 {
+		t.Fatalf("expected the task to fail and exit code 1, got %d failed, exit code %d", len(result.Failed), result.ExitCode())
+	}
+	if len(client.Prompts()) != 2 {
+		t.Errorf("expected 2 runs before giving up, got %d", len(client.Prompts()))
+	}
+	var summary strings.Builder
+	result.WriteSummary(&summary)
+	if !strings.Contains(summary.String(), "Failed     broken") {
+		t.Errorf("expected the failed task in the summary, got %q", summary.String())
+	}
+}
+
+func TestRunBatchFailsReviewByDefault(t *testing.T) {
+	initTempRepo(t)
+	store := newStoreWithTask(t, &task.Task{ID: "asks", Name: "Asks a question", Status: task.Pending})
+	client := clients.NewMockClient(clients.MockResponse{Text: "thinking", Review: reviewOf("Which approach?")})
+
+	result, err := orchestrator.RunBatch(store, client, orchestrator.BatchOptions{})
+	if err != nil {
+		t.Fatalf("expected the batch to run, got %v", err)
+	}
+	if len(result.Failed) != 1 || result.ExitCode() != 1 {
+		t.Fatalf("expected the task to fail on review, got %d failed, exit code %d", len(result.Failed), result.ExitCode())
+	}
+	if reason := result.Failed[0].FailureReason; !strings.Contains(reason, "Which approach?") {
+		t.Errorf("expected the question in the failure reason, got %q", reason)
+	}
+}
+
+func TestRunBatchAnswersReviewWithFirstOption(t *testing.T) {
+	initTempRepo(t)
+	store := newStoreWithTask(t, &task.Task{ID: "asks", Name: "Asks a question", Status: task.Pending})
+	client := clients.NewMockClient(
+		clients.MockResponse{Text: "thinking", Review: reviewOf("Which approach?")},
+		withChange("went with A"),
+	)
+
+	result, err := orchestrator.RunBatch(store, client, orchestrator.BatchOptions{ReviewPolicy: orchestrator.REVIEW_POLICY_FIRST})
+	if err != nil {
+		t.Fatalf("expected the batch to run, got %v", err)
+	}
+	if len(result.Completed) != 1 || result.ExitCode() != 0 {
+		t.Fatalf("expected the task to complete after its review was answered, got %d completed, exit code %d", len(result.Completed), result.ExitCode())
+	}
+	if prompts := client.Prompts(); len(prompts) != 2 || !strings.Contains(prompts[1], "Option A") {
+		t.Errorf("expected the resumed prompt to carry the first option, got %q", prompts)
+	}
+}
+
+func TestRunBatchRejectsUnknownReviewPolicy(t *testing.T) {
+	if _, err := orchestrator.RunBatch(brokenStore{}, clients.NewMockClient(), orchestrator.BatchOptions{ReviewPolicy: "ask"}); err == nil {
+		t.Error("expected an unknown review policy to be rejected")
+	}
+}
+
+func TestRunBatchStopsAtMaxRuntime(t *testing.T) {
+	initTempRepo(t)
+	store := newStoreWithTask(t, &task.Task{ID: "slow", Name: "Slow task", Status: task.Pending})
+	client := clients.NewMockClient(clients.MockResponse{Text: "working", Hang: true})
+
+	result, err := orchestrator.RunBatch(store, client, orchestrator.BatchOptions{MaxRuntime: 200 * time.Millisecond})
+	if err != nil {
+		t.Fatalf("expected the batch to run, got %v", err)
+	}
+	if !result.TimedOut || len(result.Remaining) != 1 || result.ExitCode() != 1 {
+		t.Fatalf("expected the task to be left to run again, got timedOut=%v remaining=%d exit code %d",
+			result.TimedOut, len(result.Remaining), result.ExitCode())
+	}
+	if got, _ := store.GetTask("slow"); got.Status != task.Pending {
+		t.Errorf("expected the interrupted task back in Pending, got %v", got.Status)
+	}
+}
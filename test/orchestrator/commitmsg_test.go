@@ -0,0 +1,68 @@
+package orchestrator_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"ludwig/internal/config"
+	"ludwig/internal/orchestrator"
+	"ludwig/internal/orchestrator/clients"
+	"ludwig/internal/types/task"
+)
+
+func runWithConfig(t *testing.T, cfg *config.Config, client clients.AIClient) (string, *task.Task) {
+	t.Helper()
+	repo := initTempRepo(t)
+	tk := &task.Task{ID: "template-task", Name: "Add greeting\n\nSay hello", Status: task.Pending}
+	store := newStoreWithTask(t, tk)
+
+	orchestrator.ProcessTask(store, client, cfg, tk)
+
+	got, _ := store.GetTask(tk.ID)
+	if got.Status != task.Completed {
+		t.Fatalf("expected the task to complete, got %v (%q)", got.Status, got.FailureReason)
+	}
+	return repo, got
+}
+
+func TestCustomCommitMessageTemplate(t *testing.T) {
+	cfg := &config.Config{CommitMessageTemplate: "feat: {{.Title}}\n\nBranch {{.Branch}} on {{.Date}}\nRef: {{.TaskID}}"}
+	client := clients.NewMockClient(clients.MockResponse{Files: map[string]string{"greeting.go": "package main\n"}})
+
+	repo, got := runWithConfig(t, cfg, client)
+
+	want := "feat: Add greeting\n\nBranch " + got.BranchName + " on " + time.Now().Format("2006-01-02") + "\nRef: template-task"
+	if message := gitOutput(t, repo, "log", "-1", "--format=%B", got.BranchName); message != want {
+		t.Errorf("expected commit message %q, got %q", want, message)
+	}
+}
+
+func TestCustomSquashMessageTemplate(t *testing.T) {
+	cfg := &config.Config{SquashCommits: true, SquashMessageTemplate: "{{.Title}} [{{.TaskID}}]\n\n{{.TaskName}}"}
+
+	repo, got := runWithConfig(t, cfg, &fakeClient{steps: []func(string) (string, error){noisyCommits(t)}})
+
+	if message := gitOutput(t, repo, "log", "-1", "--format=%B", got.BranchName); message != "Add greeting [template-task]\n\nAdd greeting\n\nSay hello" {
+		t.Errorf("unexpected squash message %q", message)
+	}
+}
+
+func TestDefaultCommitMessageUnchanged(t *testing.T) {
+	tk := &task.Task{ID: "abc", Name: "Anything"}
+	message, err := orchestrator.RenderCommitMessage(orchestrator.DEFAULT_COMMIT_TEMPLATE, tk)
+	if err != nil || message != "Task completed: abc\n\nAuto-committed any uncommitted changes to preserve work." {
+		t.Errorf("expected the original auto-commit message, got %q (err %v)", message, err)
+	}
+}
+
+func TestBrokenTemplateFallsBackToDefault(t *testing.T) {
+	cfg := &config.Config{CommitMessageTemplate: "{{.Missing}}"}
+	client := clients.NewMockClient(clients.MockResponse{Files: map[string]string{"greeting.go": "package main\n"}})
+
+	repo, got := runWithConfig(t, cfg, client)
+
+	if message := gitOutput(t, repo, "log", "-1", "--format=%B", got.BranchName); !strings.HasPrefix(message, "Task completed: template-task") {
+		t.Errorf("expected the default message, got %q", message)
+	}
+}
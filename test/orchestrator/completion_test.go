@@ -0,0 +1,56 @@
+package orchestrator_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"ludwig/internal/orchestrator"
+	"ludwig/internal/types/task"
+)
+
+func TestTaskWithEmptyBranchIsMarkedFailed(t *testing.T) {
+	initTempRepo(t)
+	tk := &task.Task{ID: "noop-task", Name: "Do nothing useful", Status: task.Pending}
+	store := newStoreWithTask(t, tk)
+
+	// Succeeds without touching the worktree
+	client := &fakeClient{}
+	orchestrator.ProcessTask(store, client, nil, tk)
+
+	stored, err := store.GetTask(tk.ID)
+	if err != nil {
+		t.Fatalf("failed to reload task: %v", err)
+	}
+	if stored.Status != task.Failed {
+		t.Errorf("expected task with an empty branch to be Failed, got %s", task.StatusString(*stored))
+	}
+	if stored.FailureReason != "no changes produced" {
+		t.Errorf("expected failure reason to explain the empty branch, got %q", stored.FailureReason)
+	}
+	if stored.WorktreePath != "" {
+		t.Errorf("expected worktree to be cleaned up, got %q", stored.WorktreePath)
+	}
+}
+
+func TestTaskWithChangesIsMarkedCompleted(t *testing.T) {
+	initTempRepo(t)
+	tk := &task.Task{ID: "real-task", Name: "Write a file", Status: task.Pending}
+	store := newStoreWithTask(t, tk)
+
+	// Leaves uncommitted work, which is auto-committed before the check
+	client := &fakeClient{steps: []func(string) (string, error){
+		func(workDir string) (string, error) {
+			return "done", os.WriteFile(filepath.Join(workDir, "notes.txt"), []byte("hello\n"), 0644)
+		},
+	}}
+	orchestrator.ProcessTask(store, client, nil, tk)
+
+	stored, err := store.GetTask(tk.ID)
+	if err != nil {
+		t.Fatalf("failed to reload task: %v", err)
+	}
+	if stored.Status != task.Completed {
+		t.Errorf("expected task with changes to be Completed, got %s (%s)", task.StatusString(*stored), stored.FailureReason)
+	}
+}
@@ -0,0 +1,36 @@
+package orchestrator_test
+
+import (
+	"strings"
+	"testing"
+
+	"ludwig/internal/orchestrator"
+	"ludwig/internal/orchestrator/clients"
+	"ludwig/internal/types/task"
+)
+
+func TestReviewCapturesDiffStat(t *testing.T) {
+	initTempRepo(t)
+	tk := &task.Task{ID: "review-stat-task", Name: "Add a parser", Status: task.Pending}
+	store := newStoreWithTask(t, tk)
+	client := clients.NewMockClient(clients.MockResponse{
+		Text:  "✓ Done: wrote the lexer",
+		Files: map[string]string{"lexer.go": "package parser\n\nfunc lex() {}\n"},
+		Review: &task.ReviewRequest{
+			Question: "Recursive descent or a generator?",
+			Options:  []task.ReviewOption{{ID: "rd", Label: "Recursive descent"}, {ID: "gen", Label: "Generator"}},
+		},
+	})
+
+	orchestrator.ProcessTask(store, client, nil, tk)
+
+	got, _ := store.GetTask(tk.ID)
+	if got.Status != task.NeedsReview || got.Review == nil {
+		t.Fatalf("expected the task to wait for review, got %v", got.Status)
+	}
+	for _, want := range []string{"lexer.go", "1 file changed", "3 insertions"} {
+		if !strings.Contains(got.Review.DiffStat, want) {
+			t.Errorf("expected diff stat to contain %q, got %q", want, got.Review.DiffStat)
+		}
+	}
+}
@@ -0,0 +1,86 @@
+package orchestrator_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"ludwig/internal/config"
+	"ludwig/internal/orchestrator"
+	"ludwig/internal/orchestrator/clients"
+	"ludwig/internal/types/task"
+)
+
+func TestDiscardFailedWorkResetsErroredRun(t *testing.T) {
+	repo := initTempRepo(t)
+	tk := &task.Task{ID: "errored-task", Name: "Half-finish a feature", Status: task.Pending}
+	store := newStoreWithTask(t, tk)
+
+	client := clients.NewMockClient(clients.MockResponse{
+		Text:  "Started on it",
+		Files: map[string]string{"half.go": "package main\n"},
+		Err:   errors.New("connection reset"),
+	})
+	orchestrator.ProcessTask(store, client, &config.Config{DiscardFailedWork: true}, tk)
+
+	got, _ := store.GetTask(tk.ID)
+	if got.Status != task.Pending || got.Failures != 1 {
+		t.Fatalf("expected the errored run to be retried, got %v with %d failures", got.Status, got.Failures)
+	}
+	if count := gitOutput(t, repo, "rev-list", "--count", "main.."+got.BranchName); count != "0" {
+		t.Errorf("expected no commits on the branch, got %s", count)
+	}
+	if status := gitOutput(t, got.WorktreePath, "status", "--porcelain"); status != "" {
+		t.Errorf("expected a clean worktree for the retry, got %q", status)
+	}
+}
+
+func TestErroredRunKeepsChangesByDefault(t *testing.T) {
+	initTempRepo(t)
+	tk := &task.Task{ID: "kept-task", Name: "Half-finish a feature", Status: task.Pending}
+	store := newStoreWithTask(t, tk)
+
+	client := clients.NewMockClient(clients.MockResponse{
+		Files: map[string]string{"half.go": "package main\n"},
+		Err:   errors.New("connection reset"),
+	})
+	orchestrator.ProcessTask(store, client, nil, tk)
+
+	got, _ := store.GetTask(tk.ID)
+	if _, err := os.Stat(filepath.Join(got.WorktreePath, "half.go")); err != nil {
+		t.Errorf("expected the partial work to be kept for the retry: %v", err)
+	}
+}
+
+func TestDiscardWorkDeletesBranch(t *testing.T) {
+	repo := initTempRepo(t)
+	tk := &task.Task{ID: "killed-task", Name: "Go the wrong way", Status: task.Pending}
+	store := newStoreWithTask(t, tk)
+
+	done := startHanging(t, store, nil, tk)
+	if err := orchestrator.DiscardWork(store, tk); !errors.Is(err, orchestrator.ErrTaskRunning) {
+		t.Errorf("expected a running task to be refused, got %v", err)
+	}
+	if err := orchestrator.Kill(tk.ID); err != nil {
+		t.Fatalf("failed to kill task: %v", err)
+	}
+	waitFor(t, done)
+
+	killed, _ := store.GetTask(tk.ID)
+	branch, worktree := killed.BranchName, killed.WorktreePath
+	if err := orchestrator.DiscardWork(store, killed); err != nil {
+		t.Fatalf("failed to discard work: %v", err)
+	}
+
+	if branches := gitOutput(t, repo, "branch", "--list", branch); branches != "" {
+		t.Errorf("expected branch %s to be deleted, got %q", branch, branches)
+	}
+	if _, err := os.Stat(worktree); !os.IsNotExist(err) {
+		t.Errorf("expected the worktree to be removed, got %v", err)
+	}
+	got, _ := store.GetTask(tk.ID)
+	if got.BranchName != "" || got.WorktreePath != "" || got.Status != task.Failed {
+		t.Errorf("expected a Failed task with no branch or worktree, got %+v", got)
+	}
+}
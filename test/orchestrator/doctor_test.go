@@ -0,0 +1,110 @@
+package orchestrator_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"ludwig/internal/config"
+	"ludwig/internal/orchestrator"
+)
+
+// doctorCheck returns the check whose name starts with name
+func doctorCheck(t *testing.T, checks []orchestrator.PreflightCheck, name string) orchestrator.PreflightCheck {
+	t.Helper()
+	for _, check := range checks {
+		if strings.HasPrefix(check.Name, name) {
+			return check
+		}
+	}
+	t.Fatalf("expected a %q check, got %+v", name, checks)
+	return orchestrator.PreflightCheck{}
+}
+
+func TestDoctorPassesInHealthyRepo(t *testing.T) {
+	initTempRepo(t)
+
+	checks := orchestrator.Doctor(config.LoadConfig())
+
+	for _, name := range []string{"git", "git repository", "config", ".ludwig directory", "tasks file"} {
+		if check := doctorCheck(t, checks, name); !check.OK {
+			t.Errorf("expected %s to pass, got %+v", name, check)
+		}
+	}
+}
+
+func TestDoctorReportsMissingGit(t *testing.T) {
+	initTempRepo(t)
+	t.Setenv("PATH", t.TempDir())
+
+	checks := orchestrator.Doctor(config.LoadConfig())
+
+	for _, name := range []string{"git", "git repository"} {
+		if check := doctorCheck(t, checks, name); check.OK || check.Guidance == "" {
+			t.Errorf("expected %s to fail with guidance, got %+v", name, check)
+		}
+	}
+	var report strings.Builder
+	if failed := orchestrator.WriteDoctorReport(&report, checks); failed < 2 {
+		t.Errorf("expected at least 2 failures, got %d", failed)
+	}
+	if !strings.Contains(report.String(), "FAIL  git\n      Install git") {
+		t.Errorf("expected the failure and its fix in the report, got %q", report.String())
+	}
+}
+
+func TestDoctorReportsUnwritableStorageDir(t *testing.T) {
+	repo := initTempRepo(t)
+	// A file where the storage directory's parent should be
+	if err := os.WriteFile(filepath.Join(repo, "blocked"), []byte("not a dir"), 0644); err != nil {
+		t.Fatalf("failed to write file: %v", err)
+	}
+	if err := config.SaveConfig(&config.Config{StorageDir: "blocked/data"}); err != nil {
+		t.Fatalf("failed to save config: %v", err)
+	}
+
+	checks := orchestrator.Doctor(config.LoadConfig())
+
+	if check := doctorCheck(t, checks, "storage directory"); check.OK || !strings.Contains(check.Guidance, "blocked/data") {
+		t.Errorf("expected the storage directory to fail, got %+v", check)
+	}
+	if check := doctorCheck(t, checks, ".ludwig directory"); !check.OK {
+		t.Errorf("expected .ludwig to still pass, got %+v", check)
+	}
+}
+
+// writeLudwigFile writes a file into the repo's .ludwig directory
+func writeLudwigFile(t *testing.T, repo, name, content string) string {
+	t.Helper()
+	path := filepath.Join(repo, ".ludwig", name)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatalf("failed to create .ludwig: %v", err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write %s: %v", name, err)
+	}
+	return path
+}
+
+func TestDoctorReportsBadConfig(t *testing.T) {
+	repo := initTempRepo(t)
+	writeLudwigFile(t, repo, "config.json", "{not json")
+
+	if check := doctorCheck(t, orchestrator.Doctor(config.LoadConfig()), "config"); check.OK || !strings.Contains(check.Guidance, "config.json") {
+		t.Errorf("expected the config to fail, got %+v", check)
+	}
+}
+
+func TestDoctorReportsCorruptTaskFile(t *testing.T) {
+	repo := initTempRepo(t)
+	path := writeLudwigFile(t, repo, "tasks.json", "[broken")
+
+	check := doctorCheck(t, orchestrator.Doctor(config.LoadConfig()), "tasks file")
+	if check.OK || !strings.Contains(check.Guidance, "corrupted") || !strings.Contains(check.Guidance, "restore-backup") {
+		t.Errorf("expected tasks.json to fail as corrupted, got %+v", check)
+	}
+	if _, err := os.Stat(path); err != nil {
+		t.Errorf("expected the doctor to leave tasks.json where it is, got %v", err)
+	}
+}
@@ -0,0 +1,96 @@
+package orchestrator_test
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+	"testing"
+
+	"ludwig/internal/config"
+	"ludwig/internal/logger"
+	"ludwig/internal/orchestrator"
+	"ludwig/internal/orchestrator/clients"
+	"ludwig/internal/storage"
+	"ludwig/internal/types/task"
+)
+
+const fakeToken = "read-only-token-8c1f"
+
+func TestTaskEnv(t *testing.T) {
+	t.Setenv("LUDWIG_TEST_TOKEN", fakeToken)
+	cfg := &config.Config{Env: map[string]string{
+		"GH_TOKEN": "$LUDWIG_TEST_TOKEN",
+		"CI":       "true",
+		"MISSING":  "$LUDWIG_TEST_UNSET",
+	}}
+
+	env, secrets := orchestrator.TaskEnv(cfg)
+
+	if want := []string{"CI=true", "GH_TOKEN=" + fakeToken}; !slices.Equal(env, want) {
+		t.Errorf("expected %q, got %q", want, env)
+	}
+	if !slices.Equal(secrets, []string{fakeToken}) {
+		t.Errorf("expected only the host value to be a secret, got %q", secrets)
+	}
+	if env, secrets := orchestrator.TaskEnv(nil); env != nil || secrets != nil {
+		t.Errorf("expected nothing without a config, got %q and %q", env, secrets)
+	}
+}
+
+// envRecorder records the environment each prompt was sent with
+type envRecorder struct {
+	fakeClient
+	envs [][]string
+}
+
+func (c *envRecorder) SendPromptWithContext(ctx context.Context, prompt string, writer io.Writer, workDir string) (string, error) {
+	c.envs = append(c.envs, clients.EnvFrom(ctx))
+	return c.SendPromptWithDir(prompt, writer, workDir)
+}
+
+func TestDefaultEnvAppliedAndKeptOutOfLogs(t *testing.T) {
+	initTempRepo(t)
+	logger.Reset()
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("LUDWIG_TEST_TOKEN", fakeToken)
+	if err := config.SaveConfig(&config.Config{AuditLog: true, Env: map[string]string{"GH_TOKEN": "$LUDWIG_TEST_TOKEN"}}); err != nil {
+		t.Fatalf("failed to save config: %v", err)
+	}
+	store := newStoreWithTask(t, &task.Task{ID: "env", Name: "Use the token", Status: task.Pending})
+	// Like a CLI that crashes and dumps its environment
+	client := &envRecorder{fakeClient: fakeClient{steps: []func(string) (string, error){
+		failing(fmt.Errorf("crashed with GH_TOKEN=%s set", fakeToken)),
+	}}}
+
+	if processed, err := orchestrator.RunOnce(store, client); !processed || err != nil {
+		t.Fatalf("expected the task to run, got processed=%v err=%v", processed, err)
+	}
+
+	if len(client.envs) != 1 || !slices.Contains(client.envs[0], "GH_TOKEN="+fakeToken) {
+		t.Errorf("expected the prompt to carry the default env, got %q", client.envs)
+	}
+	path, err := clients.DefaultAuditPath()
+	if err != nil {
+		t.Fatalf("failed to find the audit log: %v", err)
+	}
+	logged, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("expected an audit log line, got %v", err)
+	}
+	if strings.Contains(string(logged), fakeToken) || !strings.Contains(string(logged), storage.REDACTED) {
+		t.Errorf("expected the token to be masked in the audit log, got %s", logged)
+	}
+	warned := false
+	for _, entry := range logger.Recent(0) {
+		if strings.Contains(entry.Message, fakeToken) {
+			t.Errorf("expected the token to be masked in ludwig.log, got %q", entry.Message)
+		}
+		warned = warned || strings.Contains(entry.Message, "GH_TOKEN="+storage.REDACTED)
+	}
+	if !warned {
+		t.Error("expected the failed run to be logged with the token masked")
+	}
+}
@@ -0,0 +1,53 @@
+package orchestrator_test
+
+import (
+	"testing"
+
+	"ludwig/internal/orchestrator"
+	"ludwig/internal/types/task"
+)
+
+func TestSubscribersReceiveTransitions(t *testing.T) {
+	t.Chdir(t.TempDir())
+	reviewed := &task.Task{
+		ID:             "evented",
+		Name:           "Evented",
+		Status:         task.NeedsReview,
+		Review:         &task.ReviewRequest{Question: "Proceed?", Options: []task.ReviewOption{{ID: "1", Label: "Yes"}}},
+		ReviewResponse: &task.ReviewResponse{ChosenOptionID: "1", ChosenLabel: "Yes"},
+	}
+	store := newStoreWithTask(t, reviewed)
+
+	events, unsubscribe := orchestrator.Subscribe()
+	orchestrator.ProcessTask(store, &fakeClient{}, nil, reviewed)
+	unsubscribe()
+
+	var types []orchestrator.EventType
+	for event := range events {
+		types = append(types, event.Type)
+	}
+	if len(types) != 2 || types[0] != orchestrator.TaskStarted || types[1] != orchestrator.TaskCompleted {
+		t.Errorf("expected started then completed, got %v", types)
+	}
+}
+
+func TestUnsubscribeStopsDelivery(t *testing.T) {
+	t.Chdir(t.TempDir())
+	pending := &task.Task{
+		ID:             "quiet",
+		Name:           "Quiet",
+		Status:         task.NeedsReview,
+		Review:         &task.ReviewRequest{Question: "Proceed?", Options: []task.ReviewOption{{ID: "1", Label: "Yes"}}},
+		ReviewResponse: &task.ReviewResponse{ChosenOptionID: "1", ChosenLabel: "Yes"},
+	}
+	store := newStoreWithTask(t, pending)
+
+	events, unsubscribe := orchestrator.Subscribe()
+	unsubscribe()
+	unsubscribe() // Safe to call twice
+
+	orchestrator.ProcessTask(store, &fakeClient{}, nil, pending)
+	if _, ok := <-events; ok {
+		t.Error("expected no events after unsubscribing")
+	}
+}
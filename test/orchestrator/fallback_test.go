@@ -0,0 +1,88 @@
+package orchestrator_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"os/exec"
+	"strings"
+	"testing"
+
+	"ludwig/internal/orchestrator/clients"
+)
+
+func failing(err error) func(string) (string, error) {
+	return func(string) (string, error) { return "", err }
+}
+
+func TestFallbackUsesNextProviderWhenOneFails(t *testing.T) {
+	crashed := &fakeClient{steps: []func(string) (string, error){
+		failing(fmt.Errorf("gemini command exited with error: %w", &exec.ExitError{})),
+	}}
+	down := &fakeClient{steps: []func(string) (string, error){
+		failing(fmt.Errorf("failed to connect to Ollama: %w", &net.OpError{Op: "dial", Err: errors.New("connection refused")})),
+	}}
+	working := &fakeClient{}
+	client := clients.WithFallback(
+		clients.Provider{Name: "gemini", Client: crashed},
+		clients.Provider{Name: "ollama", Client: down},
+		clients.Provider{Name: "copilot", Client: working},
+	)
+
+	var out bytes.Buffer
+	response, err := client.SendPromptWithDir("do the task", &out, "")
+	if err != nil || response != "done" {
+		t.Fatalf("expected the last provider to answer, got %q (%v)", response, err)
+	}
+	for name, c := range map[string]*fakeClient{"gemini": crashed, "ollama": down, "copilot": working} {
+		if len(c.prompts) != 1 || c.prompts[0] != "do the task" {
+			t.Errorf("expected %s to get the prompt once, got %q", name, c.prompts)
+		}
+	}
+	if !strings.Contains(out.String(), "Falling back to ollama") || !strings.Contains(out.String(), "Falling back to copilot") {
+		t.Errorf("expected each fallback to be noted in the response, got %q", out.String())
+	}
+}
+
+func TestFallbackReportsEveryFailureWhenExhausted(t *testing.T) {
+	client := clients.WithFallback(
+		clients.Provider{Name: "gemini", Client: &fakeClient{steps: []func(string) (string, error){
+			failing(fmt.Errorf("%w: all models exhausted", clients.ErrProviderUnavailable)),
+		}}},
+		clients.Provider{Name: "ollama", Client: &fakeClient{steps: []func(string) (string, error){
+			failing(errors.New("ollama returned status 503: overloaded")),
+		}}},
+	)
+
+	_, err := client.SendPromptWithDir("do the task", nil, "")
+	if !errors.Is(err, clients.ErrAllProvidersFailed) {
+		t.Fatalf("expected ErrAllProvidersFailed, got %v", err)
+	}
+	for _, want := range []string{"gemini: provider unavailable", "ollama: ollama returned status 503"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("expected %q in %q", want, err.Error())
+		}
+	}
+}
+
+func TestFallbackStopsOnTaskErrors(t *testing.T) {
+	for _, err := range []error{
+		context.Canceled,
+		errors.New("429 Too Many Requests"),
+		errors.New("failed to write response chunk: response too large"),
+	} {
+		secondary := &fakeClient{}
+		client := clients.WithFallback(
+			clients.Provider{Name: "gemini", Client: &fakeClient{steps: []func(string) (string, error){failing(err)}}},
+			clients.Provider{Name: "ollama", Client: secondary},
+		)
+		if _, got := client.SendPromptWithDir("do the task", nil, ""); got != err {
+			t.Errorf("%v: expected the error to be returned as it is, got %v", err, got)
+		}
+		if len(secondary.prompts) != 0 {
+			t.Errorf("%v: expected no fallback, but the next provider was tried", err)
+		}
+	}
+}
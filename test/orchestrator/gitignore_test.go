@@ -0,0 +1,83 @@
+package orchestrator_test
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"ludwig/internal/config"
+	"ludwig/internal/orchestrator"
+)
+
+func TestIgnoreLudwigFilesCreatesGitignore(t *testing.T) {
+	repo := initTempRepo(t)
+
+	added, err := orchestrator.IgnoreLudwigFiles(nil)
+	if err != nil {
+		t.Fatalf("failed to update .gitignore: %v", err)
+	}
+	if !slices.Equal(added, []string{".ludwig/", ".worktrees/"}) {
+		t.Errorf("expected both entries to be added, got %v", added)
+	}
+	content, _ := os.ReadFile(filepath.Join(repo, ".gitignore"))
+	if want := "# Ludwig\n.ludwig/\n.worktrees/\n"; string(content) != want {
+		t.Errorf("expected %q, got %q", want, content)
+	}
+
+	// Running again adds nothing
+	if added, err := orchestrator.IgnoreLudwigFiles(nil); err != nil || len(added) != 0 {
+		t.Errorf("expected no changes the second time, got %v (%v)", added, err)
+	}
+	if again, _ := os.ReadFile(filepath.Join(repo, ".gitignore")); string(again) != string(content) {
+		t.Errorf("expected the file to be unchanged, got %q", again)
+	}
+}
+
+func TestIgnoreLudwigFilesKeepsExistingGitignore(t *testing.T) {
+	repo := initTempRepo(t)
+	path := filepath.Join(repo, ".gitignore")
+	// No trailing newline, and .worktrees already ignored in another form
+	if err := os.WriteFile(path, []byte("node_modules/\n/.worktrees\n*.log"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	added, err := orchestrator.IgnoreLudwigFiles(nil)
+	if err != nil {
+		t.Fatalf("failed to update .gitignore: %v", err)
+	}
+	if !slices.Equal(added, []string{".ludwig/"}) {
+		t.Errorf("expected only .ludwig/ to be added, got %v", added)
+	}
+	content, _ := os.ReadFile(path)
+	if want := "node_modules/\n/.worktrees\n*.log\n\n# Ludwig\n.ludwig/\n"; string(content) != want {
+		t.Errorf("expected %q, got %q", want, content)
+	}
+}
+
+func TestIgnoreLudwigFilesSkipsWorktreesOutsideRepo(t *testing.T) {
+	repo := initTempRepo(t)
+
+	added, err := orchestrator.IgnoreLudwigFiles(&config.Config{WorktreeDir: t.TempDir()})
+	if err != nil || !slices.Equal(added, []string{".ludwig/"}) {
+		t.Errorf("expected only .ludwig/ to be added, got %v (%v)", added, err)
+	}
+	if added, _ := orchestrator.IgnoreLudwigFiles(&config.Config{WorktreeDir: "build/trees"}); !slices.Equal(added, []string{"build/trees/"}) {
+		t.Errorf("expected a worktree directory inside the repo to be added, got %v", added)
+	}
+	if _, err := os.Stat(filepath.Join(repo, ".gitignore")); err != nil {
+		t.Errorf("expected a .gitignore: %v", err)
+	}
+}
+
+func TestIgnoreLudwigFilesOutsideRepo(t *testing.T) {
+	dir := t.TempDir()
+	t.Chdir(dir)
+
+	if added, err := orchestrator.IgnoreLudwigFiles(nil); err != nil || len(added) != 0 {
+		t.Errorf("expected nothing to happen outside a git repo, got %v (%v)", added, err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, ".gitignore")); !os.IsNotExist(err) {
+		t.Errorf("expected no .gitignore to be created, got %v", err)
+	}
+}
@@ -0,0 +1,75 @@
+package orchestrator_test
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"ludwig/internal/config"
+	"ludwig/internal/orchestrator"
+)
+
+func TestIdleTimeoutStopsOrchestrator(t *testing.T) {
+	t.Chdir(t.TempDir())
+	if err := config.SaveConfig(&config.Config{IdleTimeout: "50ms"}); err != nil {
+		t.Fatalf("failed to save config: %v", err)
+	}
+	events, unsubscribe := orchestrator.Subscribe()
+	defer unsubscribe()
+
+	if err := orchestrator.Start(); err != nil {
+		t.Skipf("orchestrator unavailable: %v", err)
+	}
+	defer orchestrator.Stop()
+
+	select {
+	case event := <-events:
+		if event.Type != orchestrator.OrchestratorIdleStop || event.Reason != "idle for 50ms" {
+			t.Errorf("expected an idle stop event, got %+v", event)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("expected the idle orchestrator to stop itself")
+	}
+	if orchestrator.IsRunning() {
+		t.Error("expected the orchestrator to report it is stopped")
+	}
+
+	// It can be started again afterwards
+	if err := orchestrator.Start(); err != nil || !orchestrator.IsRunning() {
+		t.Errorf("expected a restart after an idle stop, got %v", err)
+	}
+}
+
+func TestNoIdleTimeoutKeepsPolling(t *testing.T) {
+	t.Chdir(t.TempDir())
+	if err := orchestrator.Start(); err != nil {
+		t.Skipf("orchestrator unavailable: %v", err)
+	}
+	defer orchestrator.Stop()
+
+	time.Sleep(100 * time.Millisecond)
+	if !orchestrator.IsRunning() {
+		t.Error("expected the orchestrator to keep running without an idle timeout")
+	}
+}
+
+func TestStopIsSafeToRepeat(t *testing.T) {
+	t.Chdir(t.TempDir())
+	if err := orchestrator.Start(); err != nil {
+		t.Skipf("orchestrator unavailable: %v", err)
+	}
+
+	// A stop abandoned in the TUI can still be waiting when the next one arrives
+	var wg sync.WaitGroup
+	for range 3 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			orchestrator.Stop()
+		}()
+	}
+	wg.Wait()
+	if orchestrator.IsRunning() {
+		t.Error("expected the orchestrator to be stopped")
+	}
+}
@@ -0,0 +1,138 @@
+package orchestrator_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"ludwig/internal/config"
+	"ludwig/internal/orchestrator"
+	"ludwig/internal/orchestrator/clients"
+	"ludwig/internal/storage"
+	"ludwig/internal/types/task"
+)
+
+// startHanging runs tk in the background with a mock AI that edits a file and then
+// hangs, returning once the prompt has been sent. The channel closes when the run ends.
+func startHanging(t *testing.T, store storage.TaskStorage, cfg *config.Config, tk *task.Task) chan struct{} {
+	t.Helper()
+	client := clients.NewMockClient(clients.MockResponse{
+		Text:  "Rewriting everything...",
+		Files: map[string]string{tk.ID + ".go": "package main\n"},
+		Hang:  true,
+	})
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		orchestrator.ProcessTask(store, client, cfg, tk)
+	}()
+
+	deadline := time.Now().Add(5 * time.Second)
+	for len(client.Prompts()) == 0 {
+		if time.Now().After(deadline) {
+			t.Fatalf("task %s never sent its prompt", tk.ID)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	return done
+}
+
+func waitFor(t *testing.T, done chan struct{}) {
+	t.Helper()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for the task to stop")
+	}
+}
+
+func TestKillAbortsOnlyThatTask(t *testing.T) {
+	repo := initTempRepo(t)
+	stuck := &task.Task{ID: "stuck-task", Name: "Rewrite everything", Status: task.Pending}
+	store := newStoreWithTask(t, stuck)
+	other := &task.Task{ID: "other-task", Name: "Fix the typo", Status: task.Pending}
+	if err := store.AddTask(other); err != nil {
+		t.Fatalf("failed to add task: %v", err)
+	}
+
+	stuckDone := startHanging(t, store, nil, stuck)
+	otherDone := startHanging(t, store, nil, other)
+
+	if err := orchestrator.Kill(stuck.ID); err != nil {
+		t.Fatalf("expected the running task to be killable, got %v", err)
+	}
+	waitFor(t, stuckDone)
+
+	got, _ := store.GetTask(stuck.ID)
+	if got.Status != task.Failed || got.FailureReason != "killed" {
+		t.Errorf("expected the killed task to be Failed, got %v (%q)", got.Status, got.FailureReason)
+	}
+	// By default the killed task's edits are committed to its branch
+	if files := gitOutput(t, repo, "ls-tree", "--name-only", got.BranchName); files != "stuck-task.go" {
+		t.Errorf("expected the uncommitted change to be committed, got %q", files)
+	}
+
+	select {
+	case <-otherDone:
+		t.Fatal("killing one task stopped another")
+	default:
+	}
+	if running, _ := store.GetTask(other.ID); running.Status != task.InProgress {
+		t.Errorf("expected the other task to keep running, got %v", running.Status)
+	}
+	if err := orchestrator.Kill(other.ID); err != nil {
+		t.Fatalf("failed to kill the other task: %v", err)
+	}
+	waitFor(t, otherDone)
+}
+
+func TestKillCanDiscardChanges(t *testing.T) {
+	repo := initTempRepo(t)
+	tk := &task.Task{ID: "discard-task", Name: "Go the wrong way", Status: task.Pending}
+	store := newStoreWithTask(t, tk)
+
+	done := startHanging(t, store, &config.Config{DiscardFailedWork: true}, tk)
+	if err := orchestrator.Kill(tk.ID); err != nil {
+		t.Fatalf("expected the running task to be killable, got %v", err)
+	}
+	waitFor(t, done)
+
+	got, _ := store.GetTask(tk.ID)
+	if got.Status != task.Failed {
+		t.Errorf("expected the killed task to be Failed, got %v", got.Status)
+	}
+	if count := gitOutput(t, repo, "rev-list", "--count", "main.."+got.BranchName); count != "0" {
+		t.Errorf("expected nothing committed, got %s commits", count)
+	}
+	if _, err := os.Stat(filepath.Join(got.WorktreePath, "discard-task.go")); !os.IsNotExist(err) {
+		t.Errorf("expected the change to be discarded from the worktree, got %v", err)
+	}
+}
+
+func TestKillIdleTask(t *testing.T) {
+	if err := orchestrator.Kill("not-running"); !errors.Is(err, orchestrator.ErrTaskNotRunning) {
+		t.Errorf("expected ErrTaskNotRunning, got %v", err)
+	}
+}
+
+func TestOversizedResponseStopsTask(t *testing.T) {
+	initTempRepo(t)
+	tk := &task.Task{ID: "runaway-task", Name: "Print everything", Status: task.Pending}
+	store := newStoreWithTask(t, tk)
+
+	client := clients.NewMockClient(clients.MockResponse{Text: strings.Repeat("spam\n", 100), Hang: true})
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		orchestrator.ProcessTask(store, client, &config.Config{MaxResponseBytes: 64}, tk)
+	}()
+	waitFor(t, done)
+
+	got, _ := store.GetTask(tk.ID)
+	if got.Status != task.Failed || got.FailureReason != "response exceeded 64 bytes" {
+		t.Errorf("expected the runaway task to be stopped, got %v (%q)", got.Status, got.FailureReason)
+	}
+}
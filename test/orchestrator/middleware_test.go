@@ -0,0 +1,124 @@
+package orchestrator_test
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"ludwig/internal/config"
+	"ludwig/internal/orchestrator"
+	"ludwig/internal/orchestrator/clients"
+)
+
+func rateLimited(partial string) func(string) (string, error) {
+	return func(string) (string, error) { return partial, errors.New("429 Too Many Requests") }
+}
+
+func TestWithRetryRetriesRateLimitsWithPartialWork(t *testing.T) {
+	inner := &fakeClient{steps: []func(string) (string, error){
+		rateLimited("step one done"),
+		func(string) (string, error) { return "finished", nil },
+	}}
+	var slept []time.Duration
+	client := clients.WithRetry(inner)
+	client.Sleep = func(d time.Duration) { slept = append(slept, d) }
+
+	var out bytes.Buffer
+	response, err := client.SendPromptWithDir("do the task", &out, "")
+	if err != nil || response != "finished" {
+		t.Fatalf("expected the retry to succeed, got %q (err %v)", response, err)
+	}
+	if len(inner.prompts) != 2 || !strings.Contains(inner.prompts[1], "step one done") {
+		t.Errorf("expected the retry prompt to carry the partial work, got %q", inner.prompts)
+	}
+	if !slices.Equal(slept, []time.Duration{clients.DEFAULT_RETRY_DELAY}) {
+		t.Errorf("expected one backoff of %v, got %v", clients.DEFAULT_RETRY_DELAY, slept)
+	}
+	if !strings.Contains(out.String(), "Rate limited") {
+		t.Errorf("expected the retry to be announced in the response stream, got %q", out.String())
+	}
+}
+
+func TestWithRetryGivesUpAndSkipsOtherErrors(t *testing.T) {
+	inner := &fakeClient{steps: []func(string) (string, error){
+		rateLimited(""), rateLimited(""), rateLimited(""),
+	}}
+	client := clients.WithRetry(inner)
+	client.MaxRetries = 2
+	client.Sleep = func(time.Duration) {}
+
+	if _, err := client.SendPrompt("prompt", io.Discard); err == nil || !strings.Contains(err.Error(), "after 2 retries") {
+		t.Errorf("expected to give up after 2 retries, got %v", err)
+	}
+
+	inner = &fakeClient{steps: []func(string) (string, error){
+		func(string) (string, error) { return "", errors.New("gemini not installed") },
+	}}
+	client = clients.WithRetry(inner)
+	client.Sleep = func(time.Duration) { t.Error("did not expect a retry") }
+	if _, err := client.SendPrompt("prompt", io.Discard); err == nil || len(inner.prompts) != 1 {
+		t.Errorf("expected a single failed attempt, got %d (err %v)", len(inner.prompts), err)
+	}
+}
+
+func TestWithRateLimitDelegates(t *testing.T) {
+	inner := &fakeClient{}
+	client := clients.WithRateLimit(inner, 60)
+
+	response, err := client.SendPromptWithDir("prompt", io.Discard, "/work")
+	if err != nil || response != "done" || len(inner.prompts) != 1 {
+		t.Errorf("expected the prompt to reach the wrapped client, got %q (err %v)", response, err)
+	}
+}
+
+func TestWithAuditWritesToWriter(t *testing.T) {
+	var log bytes.Buffer
+	client := clients.WithAudit(clients.WithRetry(&fakeClient{}), &log)
+
+	if _, err := client.SendPrompt("prompt", io.Discard); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(log.String()), "\n")
+	if len(lines) != 1 || !strings.Contains(lines[0], `"promptLength":6`) {
+		t.Errorf("expected one audit line, got %q", log.String())
+	}
+}
+
+func TestRetryBackoffFromConfig(t *testing.T) {
+	chain, ok := orchestrator.NewClientChain(&config.Config{MaxRetries: 1, RetryDelay: "5s"}).(*clients.RetryClient)
+	if !ok {
+		t.Fatal("expected retries to wrap the client chain")
+	}
+	inner := &fakeClient{steps: []func(string) (string, error){
+		rateLimited(""), rateLimited(""), rateLimited(""),
+	}}
+	chain.Client = inner
+	var slept []time.Duration
+	chain.Sleep = func(d time.Duration) { slept = append(slept, d) }
+
+	if _, err := chain.SendPrompt("prompt", io.Discard); err == nil || !strings.Contains(err.Error(), "after 1 retries") {
+		t.Errorf("expected to give up after 1 retry, got %v", err)
+	}
+	if len(inner.prompts) != 2 || !slices.Equal(slept, []time.Duration{5 * time.Second}) {
+		t.Errorf("expected one backoff of 5s between 2 attempts, got %v and %d attempts", slept, len(inner.prompts))
+	}
+
+	for _, tt := range []struct {
+		cfg     *config.Config
+		retries int
+		delay   time.Duration
+	}{
+		{nil, clients.DEFAULT_MAX_RETRIES, clients.DEFAULT_RETRY_DELAY},
+		{&config.Config{MaxRetries: -1}, 0, clients.DEFAULT_RETRY_DELAY},
+		{&config.Config{RetryDelay: "soon"}, clients.DEFAULT_MAX_RETRIES, clients.DEFAULT_RETRY_DELAY},
+	} {
+		chain := orchestrator.NewClientChain(tt.cfg).(*clients.RetryClient)
+		if chain.MaxRetries != tt.retries || chain.BaseDelay != tt.delay {
+			t.Errorf("config %+v: expected %d retries from %v, got %d from %v", tt.cfg, tt.retries, tt.delay, chain.MaxRetries, chain.BaseDelay)
+		}
+	}
+}
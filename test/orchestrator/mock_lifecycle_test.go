@@ -0,0 +1,88 @@
+package orchestrator_test
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"ludwig/internal/orchestrator"
+	"ludwig/internal/orchestrator/clients"
+	"ludwig/internal/storage"
+	"ludwig/internal/types/task"
+)
+
+func TestMockClientDrivesReviewToCompletion(t *testing.T) {
+	initTempRepo(t)
+	store, err := storage.NewFileTaskStorage()
+	if err != nil {
+		t.Fatalf("failed to create storage: %v", err)
+	}
+	tk := &task.Task{ID: "mock-task", Name: "Add a config loader", Status: task.Pending}
+	if err := store.AddTask(tk); err != nil {
+		t.Fatalf("failed to add task: %v", err)
+	}
+
+	client := clients.NewMockClient(
+		clients.MockResponse{
+			Text:  "Scaffolded the loader.",
+			Files: map[string]string{"config.go": "package main\n"},
+			Review: &task.ReviewRequest{
+				Question: "Which format should the config use?",
+				Context:  "Both are easy to parse",
+				Options:  []task.ReviewOption{{ID: "json", Label: "JSON"}, {ID: "yaml", Label: "YAML"}},
+			},
+		},
+		clients.MockResponse{
+			Text:  "Switched to JSON.",
+			Files: map[string]string{"config.json": "{}\n"},
+		},
+	)
+
+	orchestrator.ProcessTask(store, client, nil, tk)
+
+	stored, err := store.GetTask(tk.ID)
+	if err != nil {
+		t.Fatalf("failed to reload task: %v", err)
+	}
+	if stored.Status != task.NeedsReview || stored.Review == nil {
+		t.Fatalf("expected the scripted review to pause the task, got %v", stored.Status)
+	}
+	if stored.Review.Question != "Which format should the config use?" || len(stored.Review.Options) != 2 {
+		t.Errorf("expected the review to be parsed from the response, got %+v", stored.Review)
+	}
+	if stored.WorkInProgress != "Scaffolded the loader." {
+		t.Errorf("expected the text before the review block as work in progress, got %q", stored.WorkInProgress)
+	}
+
+	// Answer the review the way the TUI does
+	stored.ReviewResponse = &task.ReviewResponse{ChosenOptionID: "json", ChosenLabel: "JSON"}
+	if err := store.UpdateTask(stored); err != nil {
+		t.Fatalf("failed to save review response: %v", err)
+	}
+	orchestrator.ProcessTask(store, client, nil, stored)
+
+	final, _ := store.GetTask(tk.ID)
+	if final.Status != task.Completed {
+		t.Fatalf("expected the resumed task to complete, got %v (%s)", final.Status, final.FailureReason)
+	}
+	prompts := client.Prompts()
+	if len(prompts) != 2 || !strings.Contains(prompts[1], "JSON") {
+		t.Errorf("expected the resume prompt to carry the chosen option, got %q", prompts)
+	}
+	if client.Remaining() != 0 {
+		t.Errorf("expected every scripted response to be used, %d left", client.Remaining())
+	}
+}
+
+func TestMockClientErrorsAndExhaustion(t *testing.T) {
+	client := clients.NewMockClient(clients.MockResponse{Text: "partial", Err: errors.New("boom")})
+
+	response, err := client.SendPrompt("first", io.Discard)
+	if response != "partial" || err == nil || err.Error() != "boom" {
+		t.Errorf("expected the scripted text and error, got %q (err %v)", response, err)
+	}
+	if _, err := client.SendPrompt("second", io.Discard); !errors.Is(err, clients.ErrMockExhausted) {
+		t.Errorf("expected ErrMockExhausted, got %v", err)
+	}
+}
@@ -0,0 +1,42 @@
+package orchestrator_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"ludwig/internal/orchestrator"
+	"ludwig/internal/orchestrator/clients"
+	"ludwig/internal/types/task"
+)
+
+func TestSubscribeOutputReceivesRunOutput(t *testing.T) {
+	initTempRepo(t)
+	store := newStoreWithTask(t, &task.Task{ID: "streamed", Name: "Write the docs", Status: task.Pending})
+	output, unsubscribe := orchestrator.SubscribeOutput("streamed")
+	defer unsubscribe()
+	other, unsubscribeOther := orchestrator.SubscribeOutput("other")
+	defer unsubscribeOther()
+
+	if _, err := orchestrator.RunOnce(store, clients.NewMockClient(withChange("docs written\n"))); err != nil {
+		t.Fatalf("expected the task to run, got %v", err)
+	}
+
+	// The response file is closed by now, so every chunk has been sent
+	var got strings.Builder
+	timeout := time.After(5 * time.Second)
+	for !strings.Contains(got.String(), "Completed:") {
+		select {
+		case chunk := <-output:
+			got.WriteString(chunk)
+		case <-timeout:
+			t.Fatalf("expected the output up to the footer, got %q", got.String())
+		}
+	}
+	if !strings.Contains(got.String(), "docs written\n") {
+		t.Errorf("expected the AI's output, got %q", got.String())
+	}
+	if len(other) != 0 {
+		t.Errorf("expected no output for another task, got %d chunks", len(other))
+	}
+}
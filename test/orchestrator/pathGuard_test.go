@@ -0,0 +1,75 @@
+package orchestrator_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"ludwig/internal/config"
+	"ludwig/internal/orchestrator"
+	"ludwig/internal/types/task"
+)
+
+// writeFiles is a fakeClient step that writes files relative to the task's work dir
+func writeFiles(files map[string]string) func(string) (string, error) {
+	return func(workDir string) (string, error) {
+		for name, contents := range files {
+			if err := os.WriteFile(filepath.Join(workDir, name), []byte(contents), 0644); err != nil {
+				return "", err
+			}
+		}
+		return "✓ Completed: wrote files", nil
+	}
+}
+
+func TestPathGuardFlagsChangesOutsideWorktree(t *testing.T) {
+	repo := initTempRepo(t)
+	if err := config.SaveConfig(&config.Config{PathGuard: true}); err != nil {
+		t.Fatalf("failed to save config: %v", err)
+	}
+	// Already there before the run, so not the AI's doing
+	if err := os.WriteFile(filepath.Join(repo, "notes.txt"), []byte("mine"), 0644); err != nil {
+		t.Fatalf("failed to write file: %v", err)
+	}
+	store := newStoreWithTask(t, &task.Task{ID: "escapee", Name: "Escape the sandbox", Status: task.Pending})
+	// The worktree is <repo>/.worktrees/<id>, so ../.. is the main repo
+	client := &fakeClient{steps: []func(string) (string, error){writeFiles(map[string]string{
+		"change.txt":        "inside",
+		"../../escaped.txt": "outside",
+	})}}
+
+	if processed, err := orchestrator.RunOnce(store, client); !processed || err != nil {
+		t.Fatalf("expected the task to run, got processed=%v err=%v", processed, err)
+	}
+
+	got, _ := store.GetTask("escapee")
+	if got.Status != task.NeedsReview || got.Review == nil {
+		t.Fatalf("expected the task to be put in review, got %v", got.Status)
+	}
+	if !strings.HasPrefix(got.Review.Question, orchestrator.ESCAPE_REVIEW_QUESTION) || !strings.Contains(got.Review.Question, "escaped.txt") {
+		t.Errorf("expected the question to name the escaped file, got %q", got.Review.Question)
+	}
+	if strings.Contains(got.Review.Question, "notes.txt") || strings.Contains(got.Review.Question, "change.txt") {
+		t.Errorf("expected only the AI's change outside the worktree to be flagged, got %q", got.Review.Question)
+	}
+	if got.WorktreePath == "" {
+		t.Error("expected the worktree to be kept for the resumed run")
+	}
+}
+
+func TestPathGuardLetsContainedRunsComplete(t *testing.T) {
+	initTempRepo(t)
+	if err := config.SaveConfig(&config.Config{PathGuard: true}); err != nil {
+		t.Fatalf("failed to save config: %v", err)
+	}
+	store := newStoreWithTask(t, &task.Task{ID: "contained", Name: "Stay in the sandbox", Status: task.Pending})
+	client := &fakeClient{steps: []func(string) (string, error){writeFiles(map[string]string{"change.txt": "inside"})}}
+
+	if processed, err := orchestrator.RunOnce(store, client); !processed || err != nil {
+		t.Fatalf("expected the task to run, got processed=%v err=%v", processed, err)
+	}
+	if got, _ := store.GetTask("contained"); got.Status != task.Completed {
+		t.Errorf("expected the task to complete, got %v", got.Status)
+	}
+}
@@ -0,0 +1,69 @@
+package orchestrator_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"ludwig/internal/orchestrator"
+)
+
+// fakePath replaces PATH with an empty directory holding only the named stub executables
+func fakePath(t *testing.T, executables ...string) {
+	t.Helper()
+	dir := t.TempDir()
+	for _, name := range executables {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte("#!/bin/sh\nexit 0\n"), 0755); err != nil {
+			t.Fatalf("failed to write stub %s: %v", name, err)
+		}
+	}
+	t.Setenv("PATH", dir)
+}
+
+func TestPreflightFailsWithoutGit(t *testing.T) {
+	t.Chdir(t.TempDir())
+	fakePath(t)
+
+	checks, err := orchestrator.Preflight(nil)
+	if !errors.Is(err, orchestrator.ErrGitMissing) {
+		t.Fatalf("expected ErrGitMissing, got %v", err)
+	}
+	if len(checks) == 0 || checks[0].Name != "git" || checks[0].OK || checks[0].Guidance == "" {
+		t.Errorf("expected a failed git check with guidance, got %+v", checks)
+	}
+
+	if err := orchestrator.Start(); !errors.Is(err, orchestrator.ErrGitMissing) {
+		t.Errorf("expected Start to refuse without git, got %v", err)
+	}
+	if orchestrator.IsRunning() {
+		orchestrator.Stop()
+		t.Error("expected the orchestrator not to be running")
+	}
+}
+
+func TestPreflightPassesWithGit(t *testing.T) {
+	fakePath(t, "git", "gemini")
+
+	checks, err := orchestrator.Preflight(nil)
+	if err != nil {
+		t.Fatalf("expected preflight to pass, got %v", err)
+	}
+	for _, check := range checks {
+		if !check.OK {
+			t.Errorf("expected %s to be found, got %+v", check.Name, check)
+		}
+	}
+}
+
+func TestPreflightOnlyWarnsAboutProvider(t *testing.T) {
+	fakePath(t, "git")
+
+	checks, err := orchestrator.Preflight(nil)
+	if err != nil {
+		t.Fatalf("expected a missing provider not to be fatal, got %v", err)
+	}
+	if len(checks) != 2 || checks[1].OK || checks[1].Required {
+		t.Errorf("expected an optional failed gemini check, got %+v", checks)
+	}
+}
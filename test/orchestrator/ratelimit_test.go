@@ -0,0 +1,78 @@
+package orchestrator_test
+
+import (
+	"io"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"ludwig/internal/orchestrator/clients"
+)
+
+// fakeClock is a stopped clock; sleeping is recorded instead of waited out
+type fakeClock struct {
+	mu     sync.Mutex
+	now    time.Time
+	sleeps []time.Duration
+}
+
+func (c *fakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *fakeClock) Sleep(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.sleeps = append(c.sleeps, d)
+}
+
+func TestRateLimitDelaysThirdRequest(t *testing.T) {
+	clock := &fakeClock{now: time.Now()}
+	limiter := clients.NewTokenBucket(2)
+	limiter.Now, limiter.Sleep = clock.Now, clock.Sleep
+
+	inner := &fakeClient{}
+	client := &clients.RateLimitedClient{Client: inner, Limiter: limiter}
+
+	// Three workers pick up tasks at the same moment
+	var wg sync.WaitGroup
+	var callMu sync.Mutex
+	for range 3 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			callMu.Lock()
+			defer callMu.Unlock()
+			client.SendPromptWithDir("prompt", io.Discard, "")
+		}()
+	}
+	wg.Wait()
+
+	if len(inner.prompts) != 3 {
+		t.Fatalf("expected all 3 prompts to be sent, got %d", len(inner.prompts))
+	}
+	if !slices.Equal(clock.sleeps, []time.Duration{30 * time.Second}) {
+		t.Errorf("expected only the third request to wait 30s, got %v", clock.sleeps)
+	}
+}
+
+func TestRateLimitRefillsOverTime(t *testing.T) {
+	clock := &fakeClock{now: time.Now()}
+	limiter := clients.NewTokenBucket(2)
+	limiter.Now, limiter.Sleep = clock.Now, clock.Sleep
+
+	limiter.Wait()
+	limiter.Wait()
+	clock.now = clock.now.Add(45 * time.Second)
+
+	// 45s refills 1.5 tokens, so the next two requests need no wait and then 15s
+	if wait := limiter.Wait(); wait != 0 {
+		t.Errorf("expected a refilled token, waited %v", wait)
+	}
+	if wait := limiter.Wait(); wait != 15*time.Second {
+		t.Errorf("expected a 15s wait for the partial token, got %v", wait)
+	}
+}
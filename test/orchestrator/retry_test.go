@@ -0,0 +1,115 @@
+package orchestrator_test
+
+import (
+	"errors"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"ludwig/internal/orchestrator"
+	"ludwig/internal/storage"
+	"ludwig/internal/types/task"
+)
+
+// fakeClient records every prompt and runs the next scripted step in the work dir
+type fakeClient struct {
+	prompts []string
+	steps   []func(workDir string) (string, error)
+}
+
+func (c *fakeClient) SendPrompt(prompt string, writer io.Writer) (string, error) {
+	return c.SendPromptWithDir(prompt, writer, "")
+}
+
+func (c *fakeClient) SendPromptWithDir(prompt string, writer io.Writer, workDir string) (string, error) {
+	c.prompts = append(c.prompts, prompt)
+	if len(c.steps) == 0 {
+		return "done", nil
+	}
+	step := c.steps[0]
+	c.steps = c.steps[1:]
+	return step(workDir)
+}
+
+// initTempRepo creates a git repo with a main branch in a temp dir and moves into it
+func initTempRepo(t *testing.T) string {
+	t.Helper()
+	dir := t.TempDir()
+	for _, args := range [][]string{
+		{"init", "-q", "-b", "main"},
+		{"config", "user.email", "test@example.com"},
+		{"config", "user.name", "Test"},
+		{"commit", "-q", "--allow-empty", "-m", "initial"},
+	} {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = dir
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Skipf("git unavailable: %v: %s", err, out)
+		}
+	}
+	t.Chdir(dir)
+	return dir
+}
+
+func newStoreWithTask(t *testing.T, tk *task.Task) *storage.FileTaskStorage {
+	t.Helper()
+	store, err := storage.NewFileTaskStorage()
+	if err != nil {
+		t.Fatalf("failed to create storage: %v", err)
+	}
+	if err := store.AddTask(tk); err != nil {
+		t.Fatalf("failed to add task: %v", err)
+	}
+	return store
+}
+
+func TestRetryPromptIncludesPreviousDiff(t *testing.T) {
+	initTempRepo(t)
+	tk := &task.Task{ID: "retry-task", Name: "Add greeting helper", Status: task.Pending}
+	store := newStoreWithTask(t, tk)
+
+	client := &fakeClient{steps: []func(string) (string, error){
+		func(workDir string) (string, error) {
+			// Write some code, then fail part way through
+			err := os.WriteFile(filepath.Join(workDir, "greeting.go"), []byte("package main\n\nfunc greet() string { return \"hi\" }\n"), 0644)
+			if err != nil {
+				return "", err
+			}
+			return "", errors.New("tests failed")
+		},
+	}}
+
+	orchestrator.ProcessTask(store, client, nil, tk)
+	if tk.Status != task.Pending || tk.Failures != 1 {
+		t.Fatalf("expected failed run to return task to Pending with 1 failure, got %v with %d", tk.Status, tk.Failures)
+	}
+	firstWorktree := tk.WorktreePath
+
+	orchestrator.ProcessTask(store, client, nil, tk)
+
+	if len(client.prompts) != 2 {
+		t.Fatalf("expected 2 prompts, got %d", len(client.prompts))
+	}
+	if strings.Contains(client.prompts[0], "PREVIOUS ATTEMPT DIFF") {
+		t.Errorf("expected the first run to use the plain task prompt")
+	}
+	retry := client.prompts[1]
+	if !strings.Contains(retry, "PREVIOUS ATTEMPT DIFF") || !strings.Contains(retry, `func greet() string { return "hi" }`) {
+		t.Errorf("expected retry prompt to embed the previous diff, got:\n%s", retry)
+	}
+	if tk.BranchName == "" || tk.Status != task.Completed {
+		t.Errorf("expected retried task to complete on its original branch, got status %v branch %q", tk.Status, tk.BranchName)
+	}
+	if _, err := os.Stat(firstWorktree); !os.IsNotExist(err) {
+		t.Errorf("expected the reused worktree to be cleaned up after completion")
+	}
+}
+
+func TestBuildRetryPromptWithoutDiff(t *testing.T) {
+	if orchestrator.BuildRetryPrompt("Task", "") != orchestrator.BuildTaskPrompt("Task") {
+		t.Errorf("expected an empty diff to fall back to the plain task prompt")
+	}
+}
@@ -0,0 +1,102 @@
+package orchestrator_test
+
+import (
+	"context"
+	"io"
+	"strings"
+	"testing"
+
+	"ludwig/internal/config"
+	"ludwig/internal/orchestrator"
+	"ludwig/internal/orchestrator/clients"
+	"ludwig/internal/types/task"
+)
+
+func TestSelectModel(t *testing.T) {
+	cfg := &config.Config{PowerfulModel: "gemini-2.5-pro", ComplexTaskLength: 40}
+	tests := []struct {
+		name string
+		cfg  *config.Config
+		task *task.Task
+		want string
+	}{
+		{"complex tag", cfg, &task.Task{Name: "Rework auth", Tags: []string{"backend", "complex"}}, "gemini-2.5-pro"},
+		{"hash tag, any case", cfg, &task.Task{Name: "Rework auth", Tags: []string{"#Complex"}}, "gemini-2.5-pro"},
+		{"long description", cfg, &task.Task{Name: strings.Repeat("Refactor the parser ", 3)}, "gemini-2.5-pro"},
+		{"simple task", cfg, &task.Task{Name: "Fix typo", Tags: []string{"docs"}}, ""},
+		{"explicit model wins", cfg, &task.Task{Name: "Rework auth", Tags: []string{"complex"}, Model: "gemini-2.5-flash"}, "gemini-2.5-flash"},
+		{"no powerful model", &config.Config{}, &task.Task{Name: "Rework auth", Tags: []string{"complex"}}, ""},
+		{"no config", nil, &task.Task{Name: "Rework auth", Model: "gpt-5-mini"}, "gpt-5-mini"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := orchestrator.SelectModel(tt.cfg, tt.task); got != tt.want {
+				t.Errorf("expected %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestComplexTaskRunsWithPowerfulModel(t *testing.T) {
+	initTempRepo(t)
+	if err := config.SaveConfig(&config.Config{PowerfulModel: "gemini-2.5-pro"}); err != nil {
+		t.Fatalf("failed to save config: %v", err)
+	}
+	store := newStoreWithTask(t, &task.Task{ID: "complex", Name: "Rework auth", Status: task.Pending, Tags: []string{"complex"}})
+	newStoreWithTask(t, &task.Task{ID: "simple", Name: "Fix typo", Status: task.Pending})
+	client := clients.NewMockClient(withChange("auth reworked"), withChange("typo fixed"))
+
+	for i := 0; i < 2; i++ {
+		if processed, err := orchestrator.RunOnce(store, client); !processed || err != nil {
+			t.Fatalf("expected a task to run, got processed=%v err=%v", processed, err)
+		}
+	}
+	models := map[string]string{}
+	for i, prompt := range client.Prompts() {
+		switch {
+		case strings.Contains(prompt, "Rework auth"):
+			models["complex"] = client.Models()[i]
+		case strings.Contains(prompt, "Fix typo"):
+			models["simple"] = client.Models()[i]
+		}
+	}
+	if models["complex"] != "gemini-2.5-pro" {
+		t.Errorf("expected the complex task to use the powerful model, got %q", models["complex"])
+	}
+	if models["simple"] != "" {
+		t.Errorf("expected the simple task to use the default model, got %q", models["simple"])
+	}
+}
+
+// modelRecorder records the model each prompt was sent with
+type modelRecorder struct {
+	fakeClient
+	models []string
+}
+
+func (c *modelRecorder) SendPromptWithContext(ctx context.Context, prompt string, writer io.Writer, workDir string) (string, error) {
+	c.models = append(c.models, clients.ModelFrom(ctx))
+	return c.SendPromptWithDir(prompt, writer, workDir)
+}
+
+func TestFallbackProvidersUseTheirOwnModel(t *testing.T) {
+	primary := &modelRecorder{fakeClient: fakeClient{steps: []func(string) (string, error){
+		failing(clients.ErrProviderUnavailable),
+	}}}
+	fallback := &modelRecorder{}
+	client := clients.WithFallback(
+		clients.Provider{Name: "gemini", Client: primary},
+		clients.Provider{Name: "ollama", Client: fallback},
+	)
+
+	ctx := clients.WithModel(context.Background(), "gemini-2.5-pro")
+	if _, err := client.SendPromptWithContext(ctx, "do the task", nil, ""); err != nil {
+		t.Fatalf("expected the fallback to answer, got %v", err)
+	}
+	if len(primary.models) != 1 || primary.models[0] != "gemini-2.5-pro" {
+		t.Errorf("expected the primary to get the routed model, got %q", primary.models)
+	}
+	if len(fallback.models) != 1 || fallback.models[0] != "" {
+		t.Errorf("expected the fallback to use its own model, got %q", fallback.models)
+	}
+}
@@ -0,0 +1,93 @@
+package orchestrator_test
+
+import (
+	"errors"
+	"testing"
+
+	"ludwig/internal/orchestrator"
+	"ludwig/internal/orchestrator/clients"
+	"ludwig/internal/storage"
+	"ludwig/internal/types/task"
+)
+
+// brokenStore fails to list, as a corrupt or unreadable store would
+type brokenStore struct{ storage.TaskStorage }
+
+func (brokenStore) ListTasks() ([]*task.Task, error) { return nil, errors.New("disk on fire") }
+
+func withChange(text string) clients.MockResponse {
+	return clients.MockResponse{Text: text, Files: map[string]string{"change.txt": text}}
+}
+
+func TestRunOnceNothingToDo(t *testing.T) {
+	initTempRepo(t)
+	store := newStoreWithTask(t, &task.Task{ID: "waiting", Name: "Waiting on review", Status: task.NeedsReview,
+		Review: &task.ReviewRequest{Question: "Which?"}})
+	newStoreWithTask(t, &task.Task{ID: "done", Name: "Already done", Status: task.Completed})
+	newStoreWithTask(t, &task.Task{ID: "archived", Name: "Archived", Status: task.Pending, Archived: true})
+	client := clients.NewMockClient()
+
+	processed, err := orchestrator.RunOnce(store, client)
+	if processed || err != nil {
+		t.Errorf("expected nothing to run, got processed=%v err=%v", processed, err)
+	}
+	if len(client.Prompts()) != 0 {
+		t.Errorf("expected no prompts, got %d", len(client.Prompts()))
+	}
+}
+
+func TestRunOnceCompletesPendingTask(t *testing.T) {
+	initTempRepo(t)
+	store := newStoreWithTask(t, &task.Task{ID: "pending", Name: "Write the docs", Status: task.Pending})
+
+	processed, err := orchestrator.RunOnce(store, clients.NewMockClient(withChange("docs written")))
+	if !processed || err != nil {
+		t.Fatalf("expected the pending task to run, got processed=%v err=%v", processed, err)
+	}
+	if got, _ := store.GetTask("pending"); got.Status != task.Completed {
+		t.Errorf("expected Completed, got %v", got.Status)
+	}
+
+	// Nothing is left, so the next step is a no-op
+	if processed, _ := orchestrator.RunOnce(store, clients.NewMockClient()); processed {
+		t.Error("expected no further work")
+	}
+}
+
+func TestRunOncePrefersAnsweredReviews(t *testing.T) {
+	initTempRepo(t)
+	store := newStoreWithTask(t, &task.Task{ID: "pending", Name: "New work", Status: task.Pending})
+	newStoreWithTask(t, &task.Task{ID: "answered", Name: "Answered review", Status: task.NeedsReview,
+		Review:         &task.ReviewRequest{Question: "Which?", Options: []task.ReviewOption{{ID: "a", Label: "Option A"}}},
+		ReviewResponse: &task.ReviewResponse{ChosenOptionID: "a", ChosenLabel: "Option A"}})
+	client := clients.NewMockClient(withChange("resumed"))
+
+	if processed, err := orchestrator.RunOnce(store, client); !processed || err != nil {
+		t.Fatalf("expected a task to run, got processed=%v err=%v", processed, err)
+	}
+	if got, _ := store.GetTask("answered"); got.Status != task.Completed {
+		t.Errorf("expected the answered review to be resumed first, got %v", got.Status)
+	}
+	if got, _ := store.GetTask("pending"); got.Status != task.Pending {
+		t.Errorf("expected the pending task to wait its turn, got %v", got.Status)
+	}
+}
+
+func TestRunOnceLeavesFailedRunPending(t *testing.T) {
+	initTempRepo(t)
+	store := newStoreWithTask(t, &task.Task{ID: "flaky", Name: "Flaky task", Status: task.Pending})
+
+	processed, err := orchestrator.RunOnce(store, clients.NewMockClient(clients.MockResponse{Err: errors.New("cli crashed")}))
+	if !processed || err != nil {
+		t.Fatalf("expected the run to be attempted, got processed=%v err=%v", processed, err)
+	}
+	if got, _ := store.GetTask("flaky"); got.Status != task.Pending || got.Failures != 1 {
+		t.Errorf("expected Pending with 1 failure, got %v with %d", got.Status, got.Failures)
+	}
+}
+
+func TestRunOnceReportsStorageErrors(t *testing.T) {
+	if _, err := orchestrator.RunOnce(brokenStore{}, clients.NewMockClient()); err == nil {
+		t.Error("expected the list error to be returned")
+	}
+}
@@ -0,0 +1,66 @@
+package orchestrator_test
+
+import (
+	"testing"
+	"time"
+
+	"ludwig/internal/orchestrator"
+	"ludwig/internal/types/task"
+)
+
+// mixedTasks returns a list in no particular order covering every scheduling case
+func mixedTasks() []*task.Task {
+	base := time.Date(2025, 1, 1, 9, 0, 0, 0, time.UTC)
+	answered := &task.ReviewResponse{ChosenLabel: "Yes"}
+	return []*task.Task{
+		{ID: "new-pending", Status: task.Pending, CreatedAt: base.Add(3 * time.Hour)},
+		{ID: "urgent-pending", Status: task.Pending, CreatedAt: base.Add(4 * time.Hour), Priority: 5},
+		{ID: "answered-review", Status: task.NeedsReview, CreatedAt: base.Add(2 * time.Hour), ReviewResponse: answered},
+		{ID: "old-pending", Status: task.Pending, CreatedAt: base.Add(time.Hour)},
+		{ID: "unanswered-review", Status: task.NeedsReview, CreatedAt: base},
+		{ID: "archived-pending", Status: task.Pending, CreatedAt: base, Archived: true, Priority: 9},
+		{ID: "in-progress", Status: task.InProgress, CreatedAt: base},
+	}
+}
+
+func scheduledIDs(t *testing.T, policy string) []string {
+	t.Helper()
+	schedule, err := orchestrator.SchedulerFor(policy)
+	if err != nil {
+		t.Fatalf("SchedulerFor(%q) failed: %v", policy, err)
+	}
+	var ids []string
+	for _, tk := range schedule(mixedTasks()) {
+		ids = append(ids, tk.ID)
+	}
+	return ids
+}
+
+func TestSchedulingPolicies(t *testing.T) {
+	cases := map[string][]string{
+		"":              {"answered-review", "old-pending", "new-pending", "urgent-pending"},
+		"review-first":  {"answered-review", "old-pending", "new-pending", "urgent-pending"},
+		"pending-first": {"old-pending", "new-pending", "urgent-pending", "answered-review"},
+		"fifo":          {"old-pending", "answered-review", "new-pending", "urgent-pending"},
+		"priority":      {"urgent-pending", "answered-review", "old-pending", "new-pending"},
+	}
+	for policy, want := range cases {
+		got := scheduledIDs(t, policy)
+		if len(got) != len(want) {
+			t.Errorf("policy %q: expected %v, got %v", policy, want, got)
+			continue
+		}
+		for i := range want {
+			if got[i] != want[i] {
+				t.Errorf("policy %q: expected %v, got %v", policy, want, got)
+				break
+			}
+		}
+	}
+}
+
+func TestSchedulerForRejectsUnknownPolicy(t *testing.T) {
+	if _, err := orchestrator.SchedulerFor("random"); err == nil {
+		t.Error("expected an unknown policy to be rejected")
+	}
+}
@@ -0,0 +1,93 @@
+package orchestrator_test
+
+import (
+	"testing"
+	"time"
+
+	"ludwig/internal/orchestrator"
+	"ludwig/internal/types/task"
+)
+
+func TestResetInterruptedRequeuesInProgressTasks(t *testing.T) {
+	initTempRepo(t)
+	fresh := &task.Task{ID: "fresh-run", Name: "Add a parser", Status: task.InProgress}
+	store := newStoreWithTask(t, fresh)
+	resumed := &task.Task{
+		ID:             "resumed-run",
+		Name:           "Pick a database",
+		Status:         task.InProgress,
+		Review:         &task.ReviewRequest{Question: "Postgres or SQLite?"},
+		ReviewResponse: &task.ReviewResponse{ChosenLabel: "SQLite"},
+	}
+	done := &task.Task{ID: "done-task", Name: "Fix the typo", Status: task.Completed}
+	for _, tk := range []*task.Task{resumed, done} {
+		if err := store.AddTask(tk); err != nil {
+			t.Fatalf("failed to add task: %v", err)
+		}
+	}
+
+	reset, err := orchestrator.ResetInterrupted(store)
+	if err != nil || reset != 2 {
+		t.Fatalf("expected 2 tasks to be reset, got %d (%v)", reset, err)
+	}
+	want := map[string]task.Status{
+		fresh.ID:   task.Pending,
+		resumed.ID: task.NeedsReview,
+		done.ID:    task.Completed,
+	}
+	for id, status := range want {
+		if got, _ := store.GetTask(id); got.Status != status {
+			t.Errorf("expected %s to be %v, got %v", id, status, got.Status)
+		}
+	}
+	if got, _ := store.GetTask(resumed.ID); got.ReviewResponse == nil {
+		t.Error("expected the review answer to be kept so the task resumes")
+	}
+}
+
+func TestShutdownRequeuesRunningTask(t *testing.T) {
+	repo := initTempRepo(t)
+	running := &task.Task{ID: "running-task", Name: "Rewrite everything", Status: task.Pending}
+	store := newStoreWithTask(t, running)
+	done := startHanging(t, store, nil, running)
+
+	start := time.Now()
+	orchestrator.Shutdown(store, 5*time.Second)
+	waitFor(t, done)
+	if elapsed := time.Since(start); elapsed > 4*time.Second {
+		t.Errorf("expected the hanging run to be cancelled rather than waited for, took %s", elapsed)
+	}
+
+	got, _ := store.GetTask(running.ID)
+	if got.Status != task.Pending || got.FailureReason != "" {
+		t.Errorf("expected the task back in Pending without a failure, got %v (%q)", got.Status, got.FailureReason)
+	}
+	if files := gitOutput(t, repo, "ls-tree", "--name-only", got.BranchName); files != "running-task.go" {
+		t.Errorf("expected the uncommitted change to be committed, got %q", files)
+	}
+}
+
+func TestStopRequeuesRunningTaskWithoutWaiting(t *testing.T) {
+	repo := initTempRepo(t)
+	running := &task.Task{ID: "running-task", Name: "Rewrite everything", Status: task.Pending}
+	store := newStoreWithTask(t, running)
+	done := startHanging(t, store, nil, running)
+	if err := orchestrator.Start(); err != nil {
+		t.Fatalf("failed to start the orchestrator: %v", err)
+	}
+
+	start := time.Now()
+	orchestrator.Stop()
+	waitFor(t, done)
+	if elapsed := time.Since(start); elapsed > 4*time.Second {
+		t.Errorf("expected the hanging run to be cancelled rather than waited for, took %s", elapsed)
+	}
+
+	got, _ := store.GetTask(running.ID)
+	if got.Status != task.Pending || got.FailureReason != "" {
+		t.Errorf("expected the task back in Pending without a failure, got %v (%q)", got.Status, got.FailureReason)
+	}
+	if files := gitOutput(t, repo, "ls-tree", "--name-only", got.BranchName); files != "running-task.go" {
+		t.Errorf("expected the uncommitted change to be committed, got %q", files)
+	}
+}
@@ -0,0 +1,101 @@
+package orchestrator_test
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"ludwig/internal/config"
+	"ludwig/internal/orchestrator"
+	"ludwig/internal/types/task"
+)
+
+func gitOutput(t *testing.T, dir string, args ...string) string {
+	t.Helper()
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	out, err := cmd.Output()
+	if err != nil {
+		t.Fatalf("git %v failed: %v", args, err)
+	}
+	return strings.TrimSpace(string(out))
+}
+
+// noisyCommits returns a client step that makes several small commits, like an AI would
+func noisyCommits(t *testing.T) func(string) (string, error) {
+	return func(workDir string) (string, error) {
+		for _, name := range []string{"a.go", "b.go", "c.go"} {
+			if err := os.WriteFile(filepath.Join(workDir, name), []byte("package x\n"), 0644); err != nil {
+				return "", err
+			}
+			git(t, workDir, "add", name)
+			git(t, workDir, "commit", "-q", "-m", "wip "+name)
+		}
+		return "✓ Committed: wip", nil
+	}
+}
+
+func runNoisyTask(t *testing.T, cfg *config.Config) (string, *task.Task) {
+	t.Helper()
+	repo := initTempRepo(t)
+	tk := &task.Task{ID: "squash-task", Name: "Add the x package\n\nThree files please", Status: task.Pending}
+	store := newStoreWithTask(t, tk)
+
+	orchestrator.ProcessTask(store, &fakeClient{steps: []func(string) (string, error){noisyCommits(t)}}, cfg, tk)
+
+	got, _ := store.GetTask(tk.ID)
+	if got.Status != task.Completed {
+		t.Fatalf("expected the task to complete, got %v (%q)", got.Status, got.FailureReason)
+	}
+	return repo, got
+}
+
+func TestSquashCommitsLeavesOneCommit(t *testing.T) {
+	repo, got := runNoisyTask(t, &config.Config{SquashCommits: true})
+
+	if count := gitOutput(t, repo, "rev-list", "--count", "main.."+got.BranchName); count != "1" {
+		t.Errorf("expected a single commit on the branch, got %s", count)
+	}
+	message := gitOutput(t, repo, "log", "-1", "--format=%B", got.BranchName)
+	if !strings.HasPrefix(message, "Add the x package\n\nThree files please") || !strings.Contains(message, "Task: squash-task") {
+		t.Errorf("expected a message built from the task, got %q", message)
+	}
+	if files := gitOutput(t, repo, "ls-tree", "--name-only", got.BranchName); !strings.Contains(files, "c.go") {
+		t.Errorf("expected the squashed commit to keep every change, got %q", files)
+	}
+}
+
+func TestCommitsKeptWithoutSquashOption(t *testing.T) {
+	repo, got := runNoisyTask(t, nil)
+
+	if count := gitOutput(t, repo, "rev-list", "--count", "main.."+got.BranchName); count != "3" {
+		t.Errorf("expected the AI's commits to be kept, got %s", count)
+	}
+}
+
+func TestSquashCommitsWithNoCommits(t *testing.T) {
+	initTempRepo(t)
+	worktreePath, err := orchestrator.CreateWorktree("ludwig/empty", "empty-task", "")
+	if err != nil {
+		t.Fatalf("failed to create worktree: %v", err)
+	}
+	defer orchestrator.RemoveWorktree(worktreePath)
+	before := gitOutput(t, worktreePath, "rev-parse", "HEAD")
+
+	if err := orchestrator.SquashCommits(worktreePath, "", "unused"); err != nil {
+		t.Errorf("expected nothing to do, got %v", err)
+	}
+	if after := gitOutput(t, worktreePath, "rev-parse", "HEAD"); after != before {
+		t.Errorf("expected HEAD to stay at %s, got %s", before, after)
+	}
+}
+
+func TestSquashMessage(t *testing.T) {
+	tk := &task.Task{ID: "id-1", Name: "Fix login", WorkInProgress: "✓ Done: form\n✓ Done: tests"}
+	want := "Fix login\n\nWork summary:\n✓ Done: form\n✓ Done: tests\n\nTask: id-1"
+	if got := orchestrator.SquashMessage(tk); got != want {
+		t.Errorf("expected %q, got %q", want, got)
+	}
+}
@@ -0,0 +1,97 @@
+package orchestrator_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"ludwig/internal/config"
+	"ludwig/internal/orchestrator"
+	"ludwig/internal/storage"
+	"ludwig/internal/types/task"
+)
+
+func TestGetStatusCountsMatchStorage(t *testing.T) {
+	t.Chdir(t.TempDir())
+	store, err := storage.NewFileTaskStorage()
+	if err != nil {
+		t.Fatalf("failed to create storage: %v", err)
+	}
+	for _, tk := range []*task.Task{
+		{ID: "p1", Status: task.Pending},
+		{ID: "p2", Status: task.Pending},
+		{ID: "r1", Status: task.NeedsReview},
+		{ID: "c1", Status: task.Completed},
+		{ID: "c2", Status: task.Completed, Archived: true},
+	} {
+		if err := store.AddTask(tk); err != nil {
+			t.Fatalf("failed to add task: %v", err)
+		}
+	}
+
+	report, err := orchestrator.GetStatus(store, nil)
+	if err != nil {
+		t.Fatalf("GetStatus returned error: %v", err)
+	}
+
+	expected := map[task.Status]int{task.Pending: 2, task.NeedsReview: 1, task.Completed: 1}
+	for status, count := range expected {
+		if report.Counts[status] != count {
+			t.Errorf("expected %d %s tasks, got %d", count, task.StatusString(task.Task{Status: status}), report.Counts[status])
+		}
+	}
+	if report.Running != orchestrator.IsRunning() {
+		t.Errorf("expected Running to match IsRunning()")
+	}
+	if len(report.ActiveTasks) != 0 {
+		t.Errorf("expected no active tasks while stopped, got %d", len(report.ActiveTasks))
+	}
+	if report.Provider != "gemini" {
+		t.Errorf("expected default provider gemini, got %q", report.Provider)
+	}
+}
+
+func TestGetStatusReflectsRunningOrchestrator(t *testing.T) {
+	t.Chdir(t.TempDir())
+	store, err := storage.NewFileTaskStorage()
+	if err != nil {
+		t.Fatalf("failed to create storage: %v", err)
+	}
+
+	orchestrator.Start()
+	report, err := orchestrator.GetStatus(store, nil)
+	orchestrator.Stop()
+	if err != nil {
+		t.Fatalf("GetStatus returned error: %v", err)
+	}
+	if !report.Running {
+		t.Errorf("expected status to report the orchestrator as running")
+	}
+}
+
+func TestGetStatusChecksProviderAvailability(t *testing.T) {
+	t.Chdir(t.TempDir())
+	store, err := storage.NewFileTaskStorage()
+	if err != nil {
+		t.Fatalf("failed to create storage: %v", err)
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	cfg := &config.Config{AIProvider: "ollama", OllamaBaseURL: server.URL, OllamaModel: "llama3"}
+
+	report, err := orchestrator.GetStatus(store, cfg)
+	if err != nil {
+		t.Fatalf("GetStatus returned error: %v", err)
+	}
+	if report.Provider != "ollama" || report.Model != "llama3" || !report.Available {
+		t.Errorf("expected reachable ollama/llama3, got %+v", report)
+	}
+
+	server.Close()
+	report, _ = orchestrator.GetStatus(store, cfg)
+	if report.Available {
+		t.Errorf("expected provider to be unavailable once the server is gone")
+	}
+}
@@ -0,0 +1,71 @@
+package orchestrator_test
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"ludwig/internal/orchestrator"
+	"ludwig/internal/orchestrator/clients"
+	"ludwig/internal/types/task"
+)
+
+// commitDir adds a tracked file under dir so worktrees check the directory out
+func commitDir(t *testing.T, repo, dir string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Join(repo, dir), 0755); err != nil {
+		t.Fatalf("failed to create %s: %v", dir, err)
+	}
+	if err := os.WriteFile(filepath.Join(repo, dir, "go.mod"), []byte("module api\n"), 0644); err != nil {
+		t.Fatalf("failed to write file: %v", err)
+	}
+	for _, args := range [][]string{{"add", "."}, {"commit", "-q", "-m", "add " + dir}} {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = repo
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v failed: %v: %s", args, err, out)
+		}
+	}
+}
+
+func TestScopedTaskRunsInSubPath(t *testing.T) {
+	repo := initTempRepo(t)
+	commitDir(t, repo, filepath.Join("services", "api"))
+	tk := &task.Task{ID: "scoped-task", Name: "Add health endpoint", Status: task.Pending, SubPath: filepath.Join("services", "api")}
+	store := newStoreWithTask(t, tk)
+	client := clients.NewMockClient(clients.MockResponse{Files: map[string]string{"health.go": "package api\n"}})
+
+	orchestrator.ProcessTask(store, client, nil, tk)
+
+	workDirs := client.WorkDirs()
+	if len(workDirs) != 1 {
+		t.Fatalf("expected one prompt, got %d", len(workDirs))
+	}
+	if !strings.HasSuffix(workDirs[0], filepath.Join("scoped-task", "services", "api")) {
+		t.Errorf("expected the AI to run in worktree/services/api, got %s", workDirs[0])
+	}
+	if !strings.Contains(client.Prompts()[0], "SCOPE: This task only concerns the services/api directory") {
+		t.Errorf("expected the prompt to note the scope")
+	}
+	if tk.Status != task.Completed {
+		t.Errorf("expected the scoped task to complete, got %v (%s)", tk.Status, tk.FailureReason)
+	}
+}
+
+func TestScopedTaskFailsWhenSubPathMissing(t *testing.T) {
+	initTempRepo(t)
+	tk := &task.Task{ID: "missing-scope", Name: "Fix the worker", Status: task.Pending, SubPath: "services/worker"}
+	store := newStoreWithTask(t, tk)
+	client := clients.NewMockClient()
+
+	orchestrator.ProcessTask(store, client, nil, tk)
+
+	if len(client.Prompts()) != 0 {
+		t.Error("expected no prompt to be sent for a missing sub path")
+	}
+	if tk.Status != task.Failed || !strings.Contains(tk.FailureReason, "services/worker") {
+		t.Errorf("expected the task to fail naming the sub path, got %v (%q)", tk.Status, tk.FailureReason)
+	}
+}
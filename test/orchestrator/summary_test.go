@@ -0,0 +1,70 @@
+package orchestrator_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"ludwig/internal/config"
+	"ludwig/internal/orchestrator"
+	"ludwig/internal/orchestrator/clients"
+	"ludwig/internal/types/task"
+)
+
+const finalReport = `Working on it.
+✓ Completed: Added the lexer
+✓ Completed: Added 3 lexer tests
+• Pending: Docs`
+
+func runSummarisedTask(t *testing.T, cfg *config.Config, responses ...clients.MockResponse) (*clients.MockClient, *task.Task) {
+	t.Helper()
+	initTempRepo(t)
+	tk := &task.Task{ID: "summary-task", Name: "Add a lexer", Status: task.Pending}
+	store := newStoreWithTask(t, tk)
+
+	client := clients.NewMockClient(responses...)
+	orchestrator.ProcessTask(store, client, cfg, tk)
+
+	got, _ := store.GetTask(tk.ID)
+	if got.Status != task.Completed {
+		t.Fatalf("expected the task to complete, got %v (%q)", got.Status, got.FailureReason)
+	}
+	return client, got
+}
+
+func TestSummaryParsedFromMarkers(t *testing.T) {
+	client, got := runSummarisedTask(t, nil, withChange(finalReport))
+
+	if got.Summary != "Added the lexer\nAdded 3 lexer tests" {
+		t.Errorf("expected the completed items as the summary, got %q", got.Summary)
+	}
+	if len(client.Prompts()) != 1 {
+		t.Errorf("expected no extra AI call, got %d prompts", len(client.Prompts()))
+	}
+}
+
+func TestAISummaryIsOptIn(t *testing.T) {
+	client, got := runSummarisedTask(t, &config.Config{AISummaries: true},
+		withChange(finalReport),
+		clients.MockResponse{Text: "Added a lexer with tests."},
+	)
+
+	if got.Summary != "Added a lexer with tests." {
+		t.Errorf("expected the AI's summary, got %q", got.Summary)
+	}
+	prompts := client.Prompts()
+	if len(prompts) != 2 || !strings.Contains(prompts[1], "Added 3 lexer tests") {
+		t.Errorf("expected a summary prompt carrying the report, got %q", prompts)
+	}
+}
+
+func TestAISummaryFallsBackToMarkers(t *testing.T) {
+	_, got := runSummarisedTask(t, &config.Config{AISummaries: true},
+		withChange(finalReport),
+		clients.MockResponse{Err: errors.New("quota exceeded")},
+	)
+
+	if got.Summary != "Added the lexer\nAdded 3 lexer tests" {
+		t.Errorf("expected a failed summary call to fall back to the markers, got %q", got.Summary)
+	}
+}
@@ -0,0 +1,91 @@
+package orchestrator_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"ludwig/internal/config"
+	"ludwig/internal/orchestrator"
+	"ludwig/internal/types/task"
+)
+
+// whyTasks are a queue of runnable tasks, oldest first, plus some that won't run
+func whyTasks() map[string]*task.Task {
+	start := time.Date(2026, 1, 1, 9, 0, 0, 0, time.UTC)
+	return map[string]*task.Task{
+		"oldest": {ID: "oldest", Name: "Oldest task", Status: task.Pending, CreatedAt: start},
+		"urgent": {ID: "urgent", Name: "Urgent task", Status: task.Pending, CreatedAt: start.Add(time.Hour), Priority: 5},
+		"answered": {ID: "answered", Name: "Answered review", Status: task.NeedsReview, CreatedAt: start.Add(2 * time.Hour),
+			Review:         &task.ReviewRequest{Question: "Which?", Options: []task.ReviewOption{{ID: "a", Label: "A"}}},
+			ReviewResponse: &task.ReviewResponse{ChosenOptionID: "a", ChosenLabel: "A"}},
+		"waiting": {ID: "waiting", Name: "Waiting on review", Status: task.NeedsReview, CreatedAt: start,
+			Review: &task.ReviewRequest{Question: "Use JSON or YAML?"}},
+		"archived": {ID: "archived", Name: "Archived task", Status: task.Pending, CreatedAt: start, Archived: true},
+		"done":     {ID: "done", Name: "Done task", Status: task.Completed, CreatedAt: start},
+	}
+}
+
+func why(t *testing.T, cfg *config.Config, id string, checks ...orchestrator.PreflightCheck) string {
+	t.Helper()
+	byID := whyTasks()
+	tasks := make([]*task.Task, 0, len(byID))
+	for _, tk := range byID {
+		tasks = append(tasks, tk)
+	}
+	return strings.Join(orchestrator.Why(cfg, tasks, byID[id], checks), "\n")
+}
+
+func TestWhyNextInLine(t *testing.T) {
+	got := why(t, nil, "answered")
+	if !strings.Contains(got, "next in line under the review-first") {
+		t.Errorf("expected the answered review to be next, got %q", got)
+	}
+}
+
+func TestWhyOrchestratorStopped(t *testing.T) {
+	got := why(t, nil, "answered")
+	if !strings.Contains(got, "orchestrator is stopped") {
+		t.Errorf("expected to be told nothing is picked up while stopped, got %q", got)
+	}
+}
+
+func TestWhyLowerPriority(t *testing.T) {
+	got := why(t, &config.Config{SchedulingPolicy: orchestrator.POLICY_PRIORITY}, "oldest")
+	if !strings.Contains(got, "2 task(s) are ahead of it under the priority") {
+		t.Fatalf("expected two tasks ahead, got %q", got)
+	}
+	if !strings.Contains(got, "Urgent task: higher priority (5, this task has 0)") {
+		t.Errorf("expected the urgent task to be ahead for its priority, got %q", got)
+	}
+	if !strings.Contains(got, "Answered review: answered reviews go before Pending tasks") {
+		t.Errorf("expected the answered review to be ahead, got %q", got)
+	}
+}
+
+func TestWhyOlderTaskAhead(t *testing.T) {
+	got := why(t, &config.Config{SchedulingPolicy: orchestrator.POLICY_FIFO}, "urgent")
+	if !strings.Contains(got, "1 task(s) are ahead") || !strings.Contains(got, "Oldest task: added earlier") {
+		t.Errorf("expected the older task to be ahead, got %q", got)
+	}
+}
+
+func TestWhyBlocked(t *testing.T) {
+	got := why(t, nil, "waiting")
+	if !strings.Contains(got, "blocked waiting for its review to be answered: Use JSON or YAML?") {
+		t.Errorf("expected the unanswered review to be the reason, got %q", got)
+	}
+	if got := why(t, nil, "archived"); !strings.Contains(got, "archived") {
+		t.Errorf("expected the archived task to be explained, got %q", got)
+	}
+	if got := why(t, nil, "done"); !strings.Contains(got, "Completed") {
+		t.Errorf("expected the completed task to be explained, got %q", got)
+	}
+}
+
+func TestWhyProviderUnavailable(t *testing.T) {
+	got := why(t, nil, "oldest", orchestrator.PreflightCheck{Name: "gemini CLI", OK: false, Guidance: "Install it."})
+	if !strings.Contains(got, "gemini CLI is not available, so runs will fail. Install it.") {
+		t.Errorf("expected the unavailable provider to be reported, got %q", got)
+	}
+}
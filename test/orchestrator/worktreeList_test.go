@@ -0,0 +1,105 @@
+package orchestrator_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"ludwig/internal/orchestrator"
+	"ludwig/internal/types/task"
+)
+
+func TestParseWorktreeList(t *testing.T) {
+	output := "worktree /repo\nHEAD 1111\nbranch refs/heads/main\n\n" +
+		"worktree /repo/.worktrees/abc\nHEAD 2222\nbranch refs/heads/ludwig/add-parser\n\n" +
+		"worktree /tmp/detached\nHEAD 3333\ndetached\n"
+
+	got := orchestrator.ParseWorktreeList(output)
+	want := []orchestrator.Worktree{
+		{Path: "/repo", Head: "1111", Branch: "main"},
+		{Path: "/repo/.worktrees/abc", Head: "2222", Branch: "ludwig/add-parser"},
+		{Path: "/tmp/detached", Head: "3333"},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("expected %d worktrees, got %+v", len(want), got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("worktree %d: expected %+v, got %+v", i, want[i], got[i])
+		}
+	}
+}
+
+func TestMatchWorktreesToTasks(t *testing.T) {
+	moved := &task.Task{ID: "moved-task", WorktreePath: "/elsewhere/moved-task"}
+	live := &task.Task{ID: "live-task"}
+	worktrees := []orchestrator.Worktree{
+		{Path: "/repo/.worktrees/live-task", Branch: "ludwig/live"},
+		{Path: "/repo/.worktrees/deleted-task", Branch: "ludwig/deleted"},
+		{Path: "/elsewhere/moved-task", Branch: "ludwig/moved"},
+		{Path: "/old/base/gone-task", Branch: "ludwig/gone"},
+		{Path: "/home/me/hotfix", Branch: "hotfix"},
+	}
+
+	infos := orchestrator.MatchWorktrees(worktrees, []*task.Task{moved, live}, "/repo/.worktrees")
+	want := []struct {
+		taskID   string
+		task     *task.Task
+		orphaned bool
+	}{
+		{"live-task", live, false},
+		{"deleted-task", nil, true},
+		{"moved-task", moved, false}, // Found by the path the task recorded
+		{"", nil, false},             // A checkout of a Ludwig branch outside the base is the user's
+		{"", nil, false},             // The user's own worktree
+	}
+	for i, w := range want {
+		info := infos[i]
+		if info.TaskID != w.taskID || info.Task != w.task || info.Orphaned() != w.orphaned {
+			t.Errorf("%s: expected task %q (orphaned %v), got %q (%v, orphaned %v)", info.Path, w.taskID, w.orphaned, info.TaskID, info.Task, info.Orphaned())
+		}
+	}
+}
+
+func TestListWorktreesLeavesOutMainWorktree(t *testing.T) {
+	initTempRepo(t)
+	path, err := orchestrator.CreateWorktree("ludwig/listed", "listed-task", "")
+	if err != nil {
+		t.Fatalf("failed to create worktree: %v", err)
+	}
+
+	worktrees, err := orchestrator.ListWorktrees()
+	if err != nil {
+		t.Fatalf("failed to list worktrees: %v", err)
+	}
+	if len(worktrees) != 1 || worktrees[0].Branch != "ludwig/listed" {
+		t.Fatalf("expected only the task's worktree, got %+v", worktrees)
+	}
+	// git may report the path with symlinks resolved
+	gotPath, _ := filepath.EvalSymlinks(worktrees[0].Path)
+	wantPath, _ := filepath.EvalSymlinks(path)
+	if gotPath != wantPath {
+		t.Errorf("expected %s, got %s", wantPath, gotPath)
+	}
+	if _, err := os.Stat(path); err != nil {
+		t.Errorf("expected the worktree on disk: %v", err)
+	}
+}
+
+func TestWorktreeDirty(t *testing.T) {
+	initTempRepo(t)
+	path, err := orchestrator.CreateWorktree("ludwig/dirty", "dirty-task", "")
+	if err != nil {
+		t.Fatalf("failed to create worktree: %v", err)
+	}
+
+	if dirty, err := orchestrator.WorktreeDirty(path); err != nil || dirty {
+		t.Fatalf("expected a fresh worktree to be clean, got dirty=%v err=%v", dirty, err)
+	}
+	if err := os.WriteFile(filepath.Join(path, "unsaved.txt"), []byte("edit"), 0644); err != nil {
+		t.Fatalf("failed to write file: %v", err)
+	}
+	if dirty, err := orchestrator.WorktreeDirty(path); err != nil || !dirty {
+		t.Errorf("expected an untracked file to make the worktree dirty, got dirty=%v err=%v", dirty, err)
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"strings"
 	"testing"
 
+	"ludwig/internal/config"
 	"ludwig/internal/orchestrator"
 )
 
@@ -36,7 +37,7 @@ func TestCreateWorktree(t *testing.T) {
 	}
 
 	taskID := "test-worktree-task"
-	worktreePath, err := orchestrator.CreateWorktree(branchName, taskID)
+	worktreePath, err := orchestrator.CreateWorktree(branchName, taskID, "")
 	if err != nil {
 		t.Fatalf("failed to create worktree: %v", err)
 	}
@@ -95,7 +96,7 @@ func TestRemoveWorktree(t *testing.T) {
 	}
 
 	taskID := "test-worktree-removal-task"
-	worktreePath, err := orchestrator.CreateWorktree(branchName, taskID)
+	worktreePath, err := orchestrator.CreateWorktree(branchName, taskID, "")
 	if err != nil {
 		t.Fatalf("failed to create worktree: %v", err)
 	}
@@ -143,7 +144,7 @@ func TestWorktreePathStructure(t *testing.T) {
 	}
 
 	taskID := "test-path-structure-task"
-	worktreePath, err := orchestrator.CreateWorktree(branchName, taskID)
+	worktreePath, err := orchestrator.CreateWorktree(branchName, taskID, "")
 	if err != nil {
 		t.Fatalf("failed to create worktree: %v", err)
 	}
@@ -198,7 +199,7 @@ func TestMultipleWorktrees(t *testing.T) {
 		}
 
 		taskID := "parallel-task-" + string(rune(i+'0'))
-		worktreePath, err := orchestrator.CreateWorktree(branchName, taskID)
+		worktreePath, err := orchestrator.CreateWorktree(branchName, taskID, "")
 		if err != nil {
 			t.Fatalf("failed to create worktree %d: %v", i, err)
 		}
@@ -230,3 +231,45 @@ func TestMultipleWorktrees(t *testing.T) {
 		}
 	}
 }
+
+func TestCreateWorktreeUnderConfiguredBase(t *testing.T) {
+	repo := initTempRepo(t)
+	base := filepath.Join(t.TempDir(), "worktrees")
+	if err := config.SaveConfig(&config.Config{WorktreeDir: base}); err != nil {
+		t.Fatalf("failed to save config: %v", err)
+	}
+
+	worktreePath, err := orchestrator.CreateWorktree("ludwig/elsewhere", "elsewhere-task", "")
+	if err != nil {
+		t.Fatalf("failed to create worktree: %v", err)
+	}
+	if want := filepath.Join(base, "elsewhere-task"); worktreePath != want {
+		t.Errorf("expected the worktree at %s, got %s", want, worktreePath)
+	}
+	if _, err := os.Stat(filepath.Join(worktreePath, ".git")); err != nil {
+		t.Errorf("expected a git worktree at %s: %v", worktreePath, err)
+	}
+	if _, err := os.Stat(filepath.Join(repo, orchestrator.DEFAULT_WORKTREE_DIR)); !os.IsNotExist(err) {
+		t.Errorf("expected nothing under the repo's %s, got %v", orchestrator.DEFAULT_WORKTREE_DIR, err)
+	}
+
+	if err := orchestrator.RemoveWorktree(worktreePath); err != nil {
+		t.Fatalf("failed to remove worktree: %v", err)
+	}
+	if _, err := os.Stat(worktreePath); !os.IsNotExist(err) {
+		t.Errorf("expected the worktree to be removed, got %v", err)
+	}
+	if out := gitOutput(t, repo, "worktree", "list", "--porcelain"); strings.Contains(out, "elsewhere-task") {
+		t.Errorf("expected git to have forgotten the worktree, got %q", out)
+	}
+}
+
+func TestWorktreeBaseResolvesRelativeToRepo(t *testing.T) {
+	repo := initTempRepo(t)
+	if got, want := orchestrator.WorktreeBase(nil), filepath.Join(repo, ".worktrees"); got != want {
+		t.Errorf("expected the default %s, got %s", want, got)
+	}
+	if got, want := orchestrator.WorktreeBase(&config.Config{WorktreeDir: "../trees"}), filepath.Join(filepath.Dir(repo), "trees"); got != want {
+		t.Errorf("expected %s, got %s", want, got)
+	}
+}
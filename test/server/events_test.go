@@ -0,0 +1,80 @@
+package server_test
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"ludwig/internal/orchestrator"
+	"ludwig/internal/types/task"
+)
+
+// doneClient answers every prompt immediately
+type doneClient struct{}
+
+func (doneClient) SendPrompt(prompt string, writer io.Writer) (string, error) {
+	return "done", nil
+}
+
+func (doneClient) SendPromptWithDir(prompt string, writer io.Writer, workDir string) (string, error) {
+	return "done", nil
+}
+
+func TestEventsStreamTaskTransitions(t *testing.T) {
+	store, handler := newTestServer(t)
+	reviewed := &task.Task{
+		ID:             "reviewed",
+		Name:           "Reviewed task",
+		Status:         task.NeedsReview,
+		Review:         &task.ReviewRequest{Question: "Proceed?", Options: []task.ReviewOption{{ID: "1", Label: "Yes"}}},
+		ReviewResponse: &task.ReviewResponse{ChosenOptionID: "1", ChosenLabel: "Yes"},
+	}
+	store.AddTask(reviewed)
+
+	ts := httptest.NewServer(handler)
+	defer ts.Close()
+	req, _ := http.NewRequest(http.MethodGet, ts.URL+"/events", nil)
+	req.Header.Set("Authorization", "Bearer "+testToken)
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("events request failed: %v", err)
+	}
+	defer resp.Body.Close()
+
+	// Resuming a reviewed task with no worktree runs straight through to Completed
+	go orchestrator.ProcessTask(store, doneClient{}, nil, reviewed)
+
+	received := make(chan orchestrator.Event)
+	go func() {
+		scanner := bufio.NewScanner(resp.Body)
+		for scanner.Scan() {
+			if data, ok := strings.CutPrefix(scanner.Text(), "data: "); ok {
+				var event orchestrator.Event
+				if json.Unmarshal([]byte(data), &event) == nil {
+					received <- event
+				}
+			}
+		}
+	}()
+
+	var types []orchestrator.EventType
+	for len(types) < 2 {
+		select {
+		case event := <-received:
+			if event.TaskID != "reviewed" {
+				t.Errorf("unexpected event for task %q", event.TaskID)
+			}
+			types = append(types, event.Type)
+		case <-time.After(5 * time.Second):
+			t.Fatalf("timed out waiting for events, got %v", types)
+		}
+	}
+	if types[0] != orchestrator.TaskStarted || types[1] != orchestrator.TaskCompleted {
+		t.Errorf("expected started then completed, got %v", types)
+	}
+}
@@ -0,0 +1,67 @@
+package server_test
+
+import (
+	"bufio"
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+
+	"ludwig/internal/orchestrator"
+	"ludwig/internal/types/task"
+)
+
+// scrape fetches /metrics and returns each sample line keyed by name and labels
+func scrape(t *testing.T, handler http.Handler) map[string]float64 {
+	t.Helper()
+	rec := doRequest(t, handler, http.MethodGet, "/metrics", "")
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body)
+	}
+	samples := map[string]float64{}
+	scanner := bufio.NewScanner(rec.Body)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		idx := strings.LastIndex(line, " ")
+		value, err := strconv.ParseFloat(line[idx+1:], 64)
+		if err != nil {
+			t.Fatalf("bad sample line %q: %v", line, err)
+		}
+		samples[line[:idx]] = value
+	}
+	return samples
+}
+
+func TestMetricsCountCompletedTasks(t *testing.T) {
+	store, handler := newTestServer(t)
+	reviewed := &task.Task{
+		ID:             "measured",
+		Name:           "Measured task",
+		Status:         task.NeedsReview,
+		Review:         &task.ReviewRequest{Question: "Proceed?", Options: []task.ReviewOption{{ID: "1", Label: "Yes"}}},
+		ReviewResponse: &task.ReviewResponse{ChosenOptionID: "1", ChosenLabel: "Yes"},
+	}
+	store.AddTask(reviewed)
+	store.AddTask(&task.Task{ID: "waiting", Name: "Waiting", Status: task.Pending})
+
+	before := scrape(t, handler)
+	if before[`ludwig_tasks{status="in_review"}`] != 1 || before[`ludwig_tasks{status="pending"}`] != 1 {
+		t.Errorf("unexpected task gauges before: %v", before)
+	}
+
+	orchestrator.ProcessTask(store, doneClient{}, nil, reviewed)
+
+	after := scrape(t, handler)
+	if got := after["ludwig_tasks_completed_total"] - before["ludwig_tasks_completed_total"]; got != 1 {
+		t.Errorf("expected completed counter to rise by 1, rose by %v", got)
+	}
+	if got := after["ludwig_ai_request_duration_seconds_count"] - before["ludwig_ai_request_duration_seconds_count"]; got != 1 {
+		t.Errorf("expected one AI request to be observed, got %v", got)
+	}
+	if after[`ludwig_tasks{status="completed"}`] != 1 || after[`ludwig_tasks{status="in_review"}`] != 0 {
+		t.Errorf("unexpected task gauges after: %v", after)
+	}
+}
@@ -0,0 +1,208 @@
+package server_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"ludwig/internal/server"
+	"ludwig/internal/storage"
+	"ludwig/internal/types/task"
+)
+
+const testToken = "secret"
+
+func newTestServer(t *testing.T) (*storage.FileTaskStorage, http.Handler) {
+	t.Helper()
+	t.Chdir(t.TempDir())
+	store, err := storage.NewFileTaskStorage()
+	if err != nil {
+		t.Fatalf("failed to create storage: %v", err)
+	}
+	srv, err := server.New(store, testToken)
+	if err != nil {
+		t.Fatalf("failed to create server: %v", err)
+	}
+	srv.SetPollInterval(10 * time.Millisecond)
+	return store, srv.Handler()
+}
+
+func doRequest(t *testing.T, handler http.Handler, method, path, body string) *httptest.ResponseRecorder {
+	t.Helper()
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	req.Header.Set("Authorization", "Bearer "+testToken)
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestNewRequiresToken(t *testing.T) {
+	if _, err := server.New(nil, ""); err == nil {
+		t.Error("expected an error when no token is given")
+	}
+}
+
+func TestRequestsWithoutTokenAreRejected(t *testing.T) {
+	_, handler := newTestServer(t)
+
+	for _, header := range []string{"", "Bearer wrong", testToken} {
+		req := httptest.NewRequest(http.MethodGet, "/tasks", nil)
+		if header != "" {
+			req.Header.Set("Authorization", header)
+		}
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		if rec.Code != http.StatusUnauthorized {
+			t.Errorf("Authorization %q: expected 401, got %d", header, rec.Code)
+		}
+	}
+}
+
+func TestListTasks(t *testing.T) {
+	store, handler := newTestServer(t)
+	store.AddTask(&task.Task{ID: "b", Name: "Second", CreatedAt: time.Now()})
+	store.AddTask(&task.Task{ID: "a", Name: "First", CreatedAt: time.Now().Add(-time.Hour)})
+	store.AddTask(&task.Task{ID: "c", Name: "Archived", Archived: true})
+
+	rec := doRequest(t, handler, http.MethodGet, "/tasks", "")
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body)
+	}
+	var tasks []task.Task
+	if err := json.Unmarshal(rec.Body.Bytes(), &tasks); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if len(tasks) != 2 || tasks[0].ID != "a" || tasks[1].ID != "b" {
+		t.Errorf("expected board tasks oldest first, got %+v", tasks)
+	}
+}
+
+func TestCreateTask(t *testing.T) {
+	store, handler := newTestServer(t)
+
+	rec := doRequest(t, handler, http.MethodPost, "/tasks", `{"name": "Write docs", "priority": 2, "tags": ["docs"]}`)
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("expected 201, got %d: %s", rec.Code, rec.Body)
+	}
+	var created task.Task
+	json.Unmarshal(rec.Body.Bytes(), &created)
+
+	stored, err := store.GetTask(created.ID)
+	if err != nil {
+		t.Fatalf("expected created task in storage: %v", err)
+	}
+	if stored.Name != "Write docs" || stored.Status != task.Pending || stored.Priority != 2 || !stored.HasTag("docs") {
+		t.Errorf("unexpected stored task: %+v", stored)
+	}
+
+	if rec := doRequest(t, handler, http.MethodPost, "/tasks", `{"name": "  "}`); rec.Code != http.StatusBadRequest {
+		t.Errorf("expected 400 for an empty name, got %d", rec.Code)
+	}
+	if rec := doRequest(t, handler, http.MethodPost, "/tasks", `not json`); rec.Code != http.StatusBadRequest {
+		t.Errorf("expected 400 for invalid JSON, got %d", rec.Code)
+	}
+}
+
+func TestDeleteTask(t *testing.T) {
+	store, handler := newTestServer(t)
+	store.AddTask(&task.Task{ID: "abcdef-1234", Name: "Doomed"})
+
+	// Short IDs are accepted as well as full IDs
+	if rec := doRequest(t, handler, http.MethodDelete, "/tasks/abcdef", ""); rec.Code != http.StatusNoContent {
+		t.Fatalf("expected 204, got %d: %s", rec.Code, rec.Body)
+	}
+	if _, err := store.GetTask("abcdef-1234"); err == nil {
+		t.Error("expected task to be deleted")
+	}
+	if rec := doRequest(t, handler, http.MethodDelete, "/tasks/abcdef-1234", ""); rec.Code != http.StatusNotFound {
+		t.Errorf("expected 404 for a missing task, got %d", rec.Code)
+	}
+}
+
+func TestAnswerReview(t *testing.T) {
+	store, handler := newTestServer(t)
+	store.AddTask(&task.Task{
+		ID:     "review-me",
+		Name:   "Needs a decision",
+		Status: task.NeedsReview,
+		Review: &task.ReviewRequest{
+			Question: "Which database?",
+			Options:  []task.ReviewOption{{ID: "1", Label: "Postgres"}, {ID: "2", Label: "SQLite"}},
+		},
+	})
+	store.AddTask(&task.Task{ID: "not-waiting", Name: "Pending", Status: task.Pending})
+
+	rec := doRequest(t, handler, http.MethodPost, "/tasks/review-me/review", `{"optionId": "2", "notes": "keep it simple"}`)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body)
+	}
+	stored, _ := store.GetTask("review-me")
+	if stored.ReviewResponse == nil || stored.ReviewResponse.ChosenLabel != "SQLite" || stored.ReviewResponse.UserNotes != "keep it simple" {
+		t.Errorf("expected review response to be stored, got %+v", stored.ReviewResponse)
+	}
+
+	if rec := doRequest(t, handler, http.MethodPost, "/tasks/review-me/review", `{"optionId": "9"}`); rec.Code != http.StatusBadRequest {
+		t.Errorf("expected 400 for an unknown option, got %d", rec.Code)
+	}
+	if rec := doRequest(t, handler, http.MethodPost, "/tasks/not-waiting/review", `{"optionId": "1"}`); rec.Code != http.StatusConflict {
+		t.Errorf("expected 409 for a task not in review, got %d", rec.Code)
+	}
+}
+
+func TestStreamResponse(t *testing.T) {
+	store, handler := newTestServer(t)
+
+	writer, responseFile, err := storage.NewResponseWriter("streaming")
+	if err != nil {
+		t.Fatalf("failed to create response writer: %v", err)
+	}
+	writer.SetFlushMode(storage.FlushSyncEveryWrite)
+	writer.WriteChunk("first line\n")
+	running := &task.Task{ID: "streaming", Name: "Streaming", Status: task.InProgress, ResponseFile: responseFile}
+	store.AddTask(running)
+
+	ts := httptest.NewServer(handler)
+	defer ts.Close()
+	req, _ := http.NewRequest(http.MethodGet, ts.URL+"/tasks/streaming/stream", nil)
+	req.Header.Set("Authorization", "Bearer "+testToken)
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("stream request failed: %v", err)
+	}
+	defer resp.Body.Close()
+	if resp.Header.Get("Content-Type") != "text/event-stream" {
+		t.Errorf("expected an event stream, got %q", resp.Header.Get("Content-Type"))
+	}
+
+	// Finish the task while the client is connected; the stream should pick up
+	// the last chunk and then end
+	time.Sleep(30 * time.Millisecond)
+	writer.WriteChunk("second line")
+	writer.Close()
+	running.Status = task.Completed
+	store.UpdateTask(running)
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatalf("failed to read stream: %v", err)
+	}
+	for _, want := range []string{"data: first line\n", "data: second line\n", "event: done\n"} {
+		if !bytes.Contains(body, []byte(want)) {
+			t.Errorf("expected stream to contain %q, got:\n%s", want, body)
+		}
+	}
+}
+
+func TestStreamWithoutOutput(t *testing.T) {
+	store, handler := newTestServer(t)
+	store.AddTask(&task.Task{ID: "quiet", Name: "Quiet", Status: task.Pending})
+
+	if rec := doRequest(t, handler, http.MethodGet, "/tasks/quiet/stream", ""); rec.Code != http.StatusNotFound {
+		t.Errorf("expected 404 for a task without output, got %d", rec.Code)
+	}
+}
@@ -0,0 +1,174 @@
+package storage_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"ludwig/internal/config"
+	"ludwig/internal/storage"
+	"ludwig/internal/types/task"
+)
+
+func TestMigrateMovesDataAndKeepsItReadable(t *testing.T) {
+	t.Chdir(t.TempDir())
+	if err := config.SaveConfig(&config.Config{KanbanColumnLimit: 7}); err != nil {
+		t.Fatalf("failed to save config: %v", err)
+	}
+	s, err := storage.NewFileTaskStorage()
+	if err != nil {
+		t.Fatalf("failed to create storage: %v", err)
+	}
+	s.AddTask(&task.Task{ID: "first", Name: "First", Status: task.Pending})
+	s.AddTask(&task.Task{ID: "moved", Name: "Moved", Status: task.Completed})
+	rw, response, err := storage.NewResponseWriter("moved")
+	if err != nil {
+		t.Fatalf("failed to create response writer: %v", err)
+	}
+	rw.WriteChunk("the task's output\n")
+	rw.Close()
+
+	moved, err := s.Migrate("data")
+	if err != nil || !moved {
+		t.Fatalf("expected the data to move, got moved=%v err=%v", moved, err)
+	}
+
+	for _, name := range []string{"tasks.json", "tasks.json" + storage.BACKUP_SUFFIX, response} {
+		if _, err := os.Stat(filepath.Join("data", name)); err != nil {
+			t.Errorf("expected %s in the new directory: %v", name, err)
+		}
+		if _, err := os.Stat(filepath.Join(".ludwig", name)); !errors.Is(err, os.ErrNotExist) {
+			t.Errorf("expected %s to be removed from .ludwig, got %v", name, err)
+		}
+	}
+	cfg, err := config.LoadConfig()
+	if err != nil || cfg.StorageDir != "data" || cfg.KanbanColumnLimit != 7 {
+		t.Fatalf("expected the config to point at the new directory and keep its options, got %+v (%v)", cfg, err)
+	}
+
+	// The store in use and a fresh one both read from the new location
+	s.AddTask(&task.Task{ID: "after", Name: "After", Status: task.Pending})
+	reopened, err := storage.NewFileTaskStorage()
+	if err != nil {
+		t.Fatalf("failed to reopen storage: %v", err)
+	}
+	for _, id := range []string{"first", "moved", "after"} {
+		if got, _ := reopened.GetTask(id); got == nil {
+			t.Errorf("expected task %s after migrating", id)
+		}
+	}
+	if content, err := storage.ReadResponse(response); err != nil || !strings.Contains(content, "the task's output") {
+		t.Errorf("expected the response to be readable, got %q (%v)", content, err)
+	}
+	if responses, _ := storage.ListResponses("moved"); len(responses) != 1 {
+		t.Errorf("expected the task's run to be listed, got %v", responses)
+	}
+
+	// Running it again does nothing
+	if moved, err := reopened.Migrate("data"); err != nil || moved {
+		t.Errorf("expected a second migration to be a no-op, got moved=%v err=%v", moved, err)
+	}
+}
+
+func TestMigrateRefusesToOverwriteOtherData(t *testing.T) {
+	t.Chdir(t.TempDir())
+	s, err := storage.NewFileTaskStorage()
+	if err != nil {
+		t.Fatalf("failed to create storage: %v", err)
+	}
+	s.AddTask(&task.Task{ID: "mine", Name: "Mine", Status: task.Pending})
+	if err := os.MkdirAll("other", 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join("other", "tasks.json"), []byte("{}"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := s.Migrate("other"); !errors.Is(err, storage.ErrStorageConflict) {
+		t.Fatalf("expected ErrStorageConflict, got %v", err)
+	}
+	if got, _ := s.GetTask("mine"); got == nil {
+		t.Error("expected the task to stay where it was")
+	}
+	if _, err := os.Stat(filepath.Join(".ludwig", "tasks.json")); err != nil {
+		t.Errorf("expected the original tasks.json to be kept: %v", err)
+	}
+}
+
+// writeResponse records a run for taskID and returns its stored path
+func writeResponse(t *testing.T, taskID string) string {
+	t.Helper()
+	rw, response, err := storage.NewResponseWriter(taskID)
+	if err != nil {
+		t.Fatalf("failed to create response writer: %v", err)
+	}
+	rw.WriteChunk("output of " + taskID + "\n")
+	rw.Close()
+	return response
+}
+
+func TestResolveResponseFileAfterMove(t *testing.T) {
+	t.Chdir(t.TempDir())
+	s, err := storage.NewFileTaskStorage()
+	if err != nil {
+		t.Fatalf("failed to create storage: %v", err)
+	}
+	response := writeResponse(t, "moved-task")
+	oldAbsolute, _ := filepath.Abs(filepath.Join(".ludwig", response))
+	if _, err := s.Migrate(filepath.Join("elsewhere", "data")); err != nil {
+		t.Fatalf("failed to migrate: %v", err)
+	}
+
+	// The stored relative path, the old "./.ludwig/" form and an absolute path into
+	// the old directory all find the file where it is now
+	for _, stored := range []string{response, "./.ludwig/" + response, oldAbsolute} {
+		got, err := storage.ResolveResponseFile("moved-task", stored)
+		if err != nil || got != response {
+			t.Errorf("stored %q: expected %q, got %q (%v)", stored, response, got, err)
+			continue
+		}
+		if content, err := storage.ReadResponse(got); err != nil || !strings.Contains(content, "output of moved-task") {
+			t.Errorf("stored %q: expected the output to be readable, got %q (%v)", stored, content, err)
+		}
+	}
+}
+
+func TestResolveResponseFileFallsBackToTaskID(t *testing.T) {
+	t.Chdir(t.TempDir())
+	response := writeResponse(t, "found-task")
+
+	got, err := storage.ResolveResponseFile("found-task", "responses/renamed-20200101-000000.md")
+	if err != nil || got != response {
+		t.Errorf("expected the missing path to fall back to %q, got %q (%v)", response, got, err)
+	}
+
+	if _, err := storage.ResolveResponseFile("silent-task", "responses/silent-task-20200101-000000.md"); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("expected os.ErrNotExist for a task without output, got %v", err)
+	}
+}
+
+func TestMalformedConfigFallsBackToLudwigDir(t *testing.T) {
+	t.Chdir(t.TempDir())
+	if err := os.MkdirAll(".ludwig", 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(".ludwig", "config.json"), []byte(`{"storageDir": `), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	s, err := storage.NewFileTaskStorage()
+	if err != nil {
+		t.Fatalf("expected a malformed config not to stop the storage opening, got %v", err)
+	}
+	if err := s.AddTask(&task.Task{ID: "kept", Name: "Kept", Status: task.Pending}); err != nil {
+		t.Fatalf("failed to add task: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(".ludwig", "tasks.json")); err != nil {
+		t.Errorf("expected tasks to be kept in .ludwig: %v", err)
+	}
+	if dir, err := storage.DataDir(); err != nil || filepath.Base(dir) != ".ludwig" {
+		t.Errorf("expected the data directory to be .ludwig, got %q (%v)", dir, err)
+	}
+}
@@ -0,0 +1,68 @@
+package storage_test
+
+import (
+	"errors"
+	"os"
+	"strings"
+	"testing"
+
+	"ludwig/internal/storage"
+)
+
+func TestResponseWriterSizeLimitTruncates(t *testing.T) {
+	defer cleanupResponseStorage(t)
+
+	rw, _, err := storage.NewResponseWriter("limit-task")
+	if err != nil {
+		t.Fatalf("failed to create response writer: %v", err)
+	}
+	defer rw.Close()
+	exceeded := 0
+	rw.SetSizeLimit(10, func() { exceeded++ })
+
+	if err := rw.WriteChunk("12345"); err != nil {
+		t.Fatalf("expected a write under the limit to succeed, got %v", err)
+	}
+	if err := rw.WriteChunk("67890abcdef"); !errors.Is(err, storage.ErrResponseTooLarge) {
+		t.Fatalf("expected ErrResponseTooLarge past the limit, got %v", err)
+	}
+	if _, err := rw.Write([]byte("more")); !errors.Is(err, storage.ErrResponseTooLarge) {
+		t.Errorf("expected later writes to keep failing, got %v", err)
+	}
+	if exceeded != 1 {
+		t.Errorf("expected the limit callback to run once, ran %d times", exceeded)
+	}
+
+	filePath := rw.GetFilePath()
+	rw.Close()
+	content, err := os.ReadFile(filePath)
+	if err != nil {
+		t.Fatalf("failed to read file: %v", err)
+	}
+	if !strings.Contains(string(content), "1234567890\n\n[truncated: exceeded 10 bytes]\n") {
+		t.Errorf("expected the response cut at 10 bytes with a marker, got %q", content)
+	}
+	if strings.Contains(string(content), "abc") || strings.Contains(string(content), "more") {
+		t.Errorf("expected nothing past the limit to be written, got %q", content)
+	}
+}
+
+func TestResponseWriterSizeLimitKeepsCharactersWhole(t *testing.T) {
+	defer cleanupResponseStorage(t)
+
+	rw, _, err := storage.NewResponseWriter("limit-task")
+	if err != nil {
+		t.Fatalf("failed to create response writer: %v", err)
+	}
+	rw.SetSizeLimit(4, nil)
+	if err := rw.WriteChunk("ab✓✓"); !errors.Is(err, storage.ErrResponseTooLarge) {
+		t.Fatalf("expected ErrResponseTooLarge, got %v", err)
+	}
+	filePath := rw.GetFilePath()
+	rw.Close()
+
+	content, _ := os.ReadFile(filePath)
+	if !strings.Contains(string(content), "---\n\nab\n\n[truncated") {
+		t.Errorf("expected the cut to fall before the split character, got %q", content)
+	}
+}
@@ -1,6 +1,7 @@
 package storage_test
 
 import (
+	"fmt"
 	"os"
 	"path/filepath"
 	"strings"
@@ -329,3 +330,111 @@ func TestReadResponseValidFile(t *testing.T) {
 		t.Errorf("ReadResponse should contain written content")
 	}
 }
+
+func TestResponseWriterBufferedWritesPresentAfterClose(t *testing.T) {
+	defer cleanupResponseStorage(t)
+
+	rw, _, err := storage.NewResponseWriter("buffered-task")
+	if err != nil {
+		t.Fatalf("failed to create response writer: %v", err)
+	}
+
+	// Chunks without newlines stay buffered until Close
+	var expected strings.Builder
+	for i := 0; i < 100; i++ {
+		chunk := fmt.Sprintf("token%d ", i)
+		expected.WriteString(chunk)
+		if err := rw.WriteChunk(chunk); err != nil {
+			t.Fatalf("failed to write chunk: %v", err)
+		}
+	}
+	if err := rw.Close(); err != nil {
+		t.Fatalf("failed to close: %v", err)
+	}
+
+	content, err := os.ReadFile(rw.GetFilePath())
+	if err != nil {
+		t.Fatalf("failed to read file: %v", err)
+	}
+	if !strings.Contains(string(content), expected.String()) {
+		t.Errorf("expected all buffered chunks in file after close")
+	}
+	if !strings.Contains(string(content), "Completed:") {
+		t.Errorf("expected footer after buffered content")
+	}
+}
+
+func TestResponseWriterBufferedFlushesOnNewline(t *testing.T) {
+	defer cleanupResponseStorage(t)
+
+	rw, _, err := storage.NewResponseWriter("newline-task")
+	if err != nil {
+		t.Fatalf("failed to create response writer: %v", err)
+	}
+	defer rw.Close()
+
+	rw.WriteChunk("complete line\n")
+	content, _ := os.ReadFile(rw.GetFilePath())
+	if !strings.Contains(string(content), "complete line") {
+		t.Errorf("expected newline-terminated chunk to be flushed to file")
+	}
+}
+
+func TestResponseWriterExplicitFlush(t *testing.T) {
+	defer cleanupResponseStorage(t)
+
+	rw, _, err := storage.NewResponseWriter("flush-task")
+	if err != nil {
+		t.Fatalf("failed to create response writer: %v", err)
+	}
+	defer rw.Close()
+
+	rw.WriteChunk("partial")
+	if err := rw.Flush(); err != nil {
+		t.Fatalf("failed to flush: %v", err)
+	}
+	content, _ := os.ReadFile(rw.GetFilePath())
+	if !strings.Contains(string(content), "partial") {
+		t.Errorf("expected partial chunk in file after Flush")
+	}
+}
+
+func TestResponseWriterSyncEveryWriteMode(t *testing.T) {
+	defer cleanupResponseStorage(t)
+
+	rw, _, err := storage.NewResponseWriter("sync-task")
+	if err != nil {
+		t.Fatalf("failed to create response writer: %v", err)
+	}
+	defer rw.Close()
+	rw.SetFlushMode(storage.FlushSyncEveryWrite)
+
+	rw.WriteChunk("durable")
+	content, _ := os.ReadFile(rw.GetFilePath())
+	if !strings.Contains(string(content), "durable") {
+		t.Errorf("expected chunk on disk immediately in sync mode")
+	}
+}
+
+func benchmarkResponseWriter(b *testing.B, mode storage.FlushMode) {
+	rw, _, err := storage.NewResponseWriter("bench-task")
+	if err != nil {
+		b.Fatalf("failed to create response writer: %v", err)
+	}
+	defer os.Remove(rw.GetFilePath())
+	defer rw.Close()
+	rw.SetFlushMode(mode)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		rw.WriteChunk("token ")
+	}
+}
+
+func BenchmarkResponseWriterBuffered(b *testing.B) {
+	benchmarkResponseWriter(b, storage.FlushBuffered)
+}
+
+func BenchmarkResponseWriterSyncEveryWrite(b *testing.B) {
+	benchmarkResponseWriter(b, storage.FlushSyncEveryWrite)
+}
@@ -206,3 +206,243 @@ func TestResponseWriterWriteInterface(t *testing.T) {
 		t.Errorf("expected written data in file")
 	}
 }
+
+// writeResponseFile creates a response file directly in .ludwig/responses
+func writeResponseFile(t *testing.T, name string) {
+	cwd, _ := os.Getwd()
+	responseDir := filepath.Join(cwd, ".ludwig", "responses")
+	if err := os.MkdirAll(responseDir, 0755); err != nil {
+		t.Fatalf("failed to create responses dir: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(responseDir, name), []byte(name), 0644); err != nil {
+		t.Fatalf("failed to write response file: %v", err)
+	}
+}
+
+func cleanupLudwigResponses(t *testing.T) {
+	cwd, _ := os.Getwd()
+	os.RemoveAll(filepath.Join(cwd, ".ludwig", "responses"))
+}
+
+func TestListResponsesMultipleRuns(t *testing.T) {
+	cleanupLudwigResponses(t)
+	defer cleanupLudwigResponses(t)
+
+	// Written out of order to verify chronological sorting
+	writeResponseFile(t, "task-1-20250102-090000.md")
+	writeResponseFile(t, "task-1-20250101-120000.md")
+	writeResponseFile(t, "task-1-20250103-080000.md")
+
+	responses, err := storage.ListResponses("task-1")
+	if err != nil {
+		t.Fatalf("failed to list responses: %v", err)
+	}
+
+	expected := []string{
+		filepath.Join("responses", "task-1-20250101-120000.md"),
+		filepath.Join("responses", "task-1-20250102-090000.md"),
+		filepath.Join("responses", "task-1-20250103-080000.md"),
+	}
+	if len(responses) != len(expected) {
+		t.Fatalf("expected %d responses, got %d: %v", len(expected), len(responses), responses)
+	}
+	for i := range expected {
+		if responses[i] != expected[i] {
+			t.Errorf("response %d: expected %q, got %q", i, expected[i], responses[i])
+		}
+	}
+
+	// The most recent run is last and readable through ReadResponse
+	content, err := storage.ReadResponse(responses[len(responses)-1])
+	if err != nil {
+		t.Fatalf("failed to read latest response: %v", err)
+	}
+	if content != "task-1-20250103-080000.md" {
+		t.Errorf("expected latest response content, got %q", content)
+	}
+}
+
+func TestListResponsesIgnoresOtherTasks(t *testing.T) {
+	cleanupLudwigResponses(t)
+	defer cleanupLudwigResponses(t)
+
+	writeResponseFile(t, "task-1-20250101-120000.md")
+	writeResponseFile(t, "task-10-20250101-120000.md")
+	writeResponseFile(t, "task-1-notes.txt")
+
+	responses, err := storage.ListResponses("task-1")
+	if err != nil {
+		t.Fatalf("failed to list responses: %v", err)
+	}
+	if len(responses) != 1 {
+		t.Fatalf("expected 1 response for task-1, got %d: %v", len(responses), responses)
+	}
+}
+
+func TestListResponsesNoResponses(t *testing.T) {
+	cleanupLudwigResponses(t)
+
+	responses, err := storage.ListResponses("missing-task")
+	if err != nil {
+		t.Fatalf("expected no error for missing responses dir, got %v", err)
+	}
+	if len(responses) != 0 {
+		t.Errorf("expected no responses, got %v", responses)
+	}
+}
+
+func TestOpenResponseWriterAppendsResumedSection(t *testing.T) {
+	cleanupLudwigResponses(t)
+	defer cleanupLudwigResponses(t)
+
+	rw, relativePath, err := storage.NewResponseWriter("resume-task")
+	if err != nil {
+		t.Fatalf("failed to create response writer: %v", err)
+	}
+	rw.WriteChunk("first run output")
+	if err := rw.Suspend(); err != nil {
+		t.Fatalf("failed to suspend response writer: %v", err)
+	}
+
+	resumed, err := storage.OpenResponseWriter(relativePath)
+	if err != nil {
+		t.Fatalf("failed to reopen response writer: %v", err)
+	}
+	resumed.WriteChunk("second run output")
+	if err := resumed.Close(); err != nil {
+		t.Fatalf("failed to close resumed writer: %v", err)
+	}
+
+	content, err := storage.ReadResponse(relativePath)
+	if err != nil {
+		t.Fatalf("failed to read response: %v", err)
+	}
+
+	first := strings.Index(content, "first run output")
+	marker := strings.Index(content, "## Resumed")
+	second := strings.Index(content, "second run output")
+	if first == -1 || marker == -1 || second == -1 {
+		t.Fatalf("expected both runs and resumed marker in content, got %s", content)
+	}
+	if !(first < marker && marker < second) {
+		t.Errorf("expected first run, resumed marker, second run in order, got %s", content)
+	}
+	if strings.Count(content, "Completed:") != 1 {
+		t.Errorf("expected exactly one Completed footer, got %d", strings.Count(content, "Completed:"))
+	}
+	if strings.LastIndex(content, "Completed:") < second {
+		t.Errorf("expected Completed footer after the resumed run")
+	}
+}
+
+func TestOpenResponseWriterMissingFile(t *testing.T) {
+	cleanupLudwigResponses(t)
+
+	if _, err := storage.OpenResponseWriter(filepath.Join("responses", "missing-20250101-120000.md")); err == nil {
+		t.Errorf("expected error reopening a missing response file")
+	}
+}
+
+func TestResponseWriterSuspendIsIdempotent(t *testing.T) {
+	cleanupLudwigResponses(t)
+	defer cleanupLudwigResponses(t)
+
+	rw, _, err := storage.NewResponseWriter("suspend-task")
+	if err != nil {
+		t.Fatalf("failed to create response writer: %v", err)
+	}
+	if err := rw.Suspend(); err != nil {
+		t.Fatalf("failed to suspend: %v", err)
+	}
+	if err := rw.Suspend(); err != nil {
+		t.Errorf("expected no error on second suspend, got %v", err)
+	}
+	if err := rw.Close(); err != nil {
+		t.Errorf("expected no error closing a suspended writer, got %v", err)
+	}
+
+	content, _ := os.ReadFile(rw.GetFilePath())
+	if strings.Contains(string(content), "Completed:") {
+		t.Errorf("expected no footer after suspend, got %s", content)
+	}
+}
+
+func TestReadResponseTail(t *testing.T) {
+	cleanupLudwigResponses(t)
+	defer cleanupLudwigResponses(t)
+
+	content := "line one\nline two\nline three\n"
+	writeResponseFile(t, "tail-task-20250101-120000.md")
+	cwd, _ := os.Getwd()
+	path := filepath.Join(cwd, ".ludwig", "responses", "tail-task-20250101-120000.md")
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write response file: %v", err)
+	}
+	relativePath := filepath.Join("responses", "tail-task-20250101-120000.md")
+
+	tests := []struct {
+		name     string
+		maxBytes int64
+		expected string
+	}{
+		{"file smaller than window", int64(len(content)) + 10, content},
+		{"file equal to window", int64(len(content)), content},
+		{"file larger than window aligns to line", 15, "line three\n"},
+		{"window inside last line", 5, "hree\n"},
+		{"no limit", 0, content},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tail, err := storage.ReadResponseTail(relativePath, tt.maxBytes)
+			if err != nil {
+				t.Fatalf("failed to read tail: %v", err)
+			}
+			if tail != tt.expected {
+				t.Errorf("expected %q, got %q", tt.expected, tail)
+			}
+		})
+	}
+}
+
+func TestReadResponseTailMissingFile(t *testing.T) {
+	if _, err := storage.ReadResponseTail(filepath.Join("responses", "missing.md"), 100); err == nil {
+		t.Errorf("expected error reading tail of missing file")
+	}
+}
+
+func TestReadResponseFromIncrementalGrowth(t *testing.T) {
+	cleanupLudwigResponses(t)
+	defer cleanupLudwigResponses(t)
+
+	rw, relativePath, err := storage.NewResponseWriter("growth-task")
+	if err != nil {
+		t.Fatalf("failed to create response writer: %v", err)
+	}
+	defer rw.Close()
+
+	initial, offset, err := storage.ReadResponseFrom(relativePath, 0)
+	if err != nil {
+		t.Fatalf("failed initial read: %v", err)
+	}
+	if !strings.Contains(initial, "# AI Response for Task: growth-task") {
+		t.Errorf("expected header in initial read, got %q", initial)
+	}
+
+	rw.WriteChunk("first line\n")
+	chunk, offset, err := storage.ReadResponseFrom(relativePath, offset)
+	if err != nil {
+		t.Fatalf("failed second read: %v", err)
+	}
+	if chunk != "first line\n" {
+		t.Errorf("expected only newly written bytes, got %q", chunk)
+	}
+
+	chunk, _, err = storage.ReadResponseFrom(relativePath, offset)
+	if err != nil {
+		t.Fatalf("failed third read: %v", err)
+	}
+	if chunk != "" {
+		t.Errorf("expected nothing new, got %q", chunk)
+	}
+}
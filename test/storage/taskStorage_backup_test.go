@@ -0,0 +1,69 @@
+package storage_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"ludwig/internal/storage"
+	"ludwig/internal/types/task"
+)
+
+func TestSaveBacksUpPreviousState(t *testing.T) {
+	setupTestStorage(t)
+	defer cleanupTestStorage(t)
+
+	s, _ := storage.NewFileTaskStorage()
+	s.AddTask(&task.Task{ID: "first", Name: "First", Status: task.Pending})
+
+	path := filepath.Join(".", ".ludwig", "tasks.json")
+	beforeSecondSave, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read tasks.json: %v", err)
+	}
+
+	s.AddTask(&task.Task{ID: "second", Name: "Second", Status: task.Pending})
+
+	backup, err := os.ReadFile(path + storage.BACKUP_SUFFIX)
+	if err != nil {
+		t.Fatalf("expected a backup after saving, got %v", err)
+	}
+	if string(backup) != string(beforeSecondSave) {
+		t.Errorf("expected backup to hold the pre-save state\ngot:  %s\nwant: %s", backup, beforeSecondSave)
+	}
+}
+
+func TestRestoreBackupSwapsFiles(t *testing.T) {
+	setupTestStorage(t)
+	defer cleanupTestStorage(t)
+
+	s, _ := storage.NewFileTaskStorage()
+	s.AddTask(&task.Task{ID: "keep", Name: "Keep", Status: task.Pending})
+	s.DeleteTask("keep")
+
+	if err := s.RestoreBackup(); err != nil {
+		t.Fatalf("failed to restore backup: %v", err)
+	}
+	if _, err := s.GetTask("keep"); err != nil {
+		t.Errorf("expected deleted task to come back after restore, got %v", err)
+	}
+
+	// Restoring again swaps back to the state with the task deleted
+	if err := s.RestoreBackup(); err != nil {
+		t.Fatalf("failed to undo restore: %v", err)
+	}
+	if _, err := s.GetTask("keep"); err == nil {
+		t.Errorf("expected second restore to undo the first")
+	}
+}
+
+func TestRestoreBackupWithoutBackup(t *testing.T) {
+	setupTestStorage(t)
+	defer cleanupTestStorage(t)
+
+	s, _ := storage.NewFileTaskStorage()
+	if err := s.RestoreBackup(); !errors.Is(err, storage.ErrNoBackup) {
+		t.Errorf("expected ErrNoBackup, got %v", err)
+	}
+}
@@ -0,0 +1,59 @@
+package storage_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"ludwig/internal/storage"
+	"ludwig/internal/types/task"
+)
+
+func TestCancelledContextAbortsWrites(t *testing.T) {
+	setupTestStorage(t)
+	defer cleanupTestStorage(t)
+
+	s, _ := storage.NewFileTaskStorage()
+	s.AddTask(&task.Task{ID: "existing", Name: "Existing", Status: task.Pending})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if err := s.AddTaskCtx(ctx, &task.Task{ID: "new", Name: "New"}); !errors.Is(err, context.Canceled) {
+		t.Errorf("expected AddTaskCtx to return context.Canceled, got %v", err)
+	}
+	if err := s.DeleteTaskCtx(ctx, "existing"); !errors.Is(err, context.Canceled) {
+		t.Errorf("expected DeleteTaskCtx to return context.Canceled, got %v", err)
+	}
+	if err := s.UpdateTaskCtx(ctx, &task.Task{ID: "existing", Name: "Changed"}); !errors.Is(err, context.Canceled) {
+		t.Errorf("expected UpdateTaskCtx to return context.Canceled, got %v", err)
+	}
+
+	tasks, err := s.ListTasks()
+	if err != nil {
+		t.Fatalf("failed to list tasks: %v", err)
+	}
+	if len(tasks) != 1 || tasks[0].ID != "existing" || tasks[0].Name != "Existing" {
+		t.Errorf("expected cancelled calls to leave storage untouched, got %+v", tasks)
+	}
+}
+
+func TestExpiredDeadlineAbortsReads(t *testing.T) {
+	setupTestStorage(t)
+	defer cleanupTestStorage(t)
+
+	s, _ := storage.NewFileTaskStorage()
+	s.AddTask(&task.Task{ID: "existing", Name: "Existing", Status: task.Pending})
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Nanosecond)
+	defer cancel()
+	<-ctx.Done()
+
+	if _, err := s.GetTaskCtx(ctx, "existing"); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected GetTaskCtx to return context.DeadlineExceeded, got %v", err)
+	}
+	if _, err := s.ListTasksFilteredCtx(ctx, storage.ListOptions{}); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected ListTasksFilteredCtx to return context.DeadlineExceeded, got %v", err)
+	}
+}
@@ -0,0 +1,60 @@
+package storage_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"ludwig/internal/logger"
+	"ludwig/internal/storage"
+	"ludwig/internal/types/task"
+)
+
+func TestCorruptTasksFileIsQuarantined(t *testing.T) {
+	setupTestStorage(t)
+	defer cleanupTestStorage(t)
+	logger.Reset()
+	defer logger.Reset()
+
+	ludwigDir := filepath.Join(".", ".ludwig")
+	if err := os.MkdirAll(ludwigDir, 0755); err != nil {
+		t.Fatalf("failed to create .ludwig: %v", err)
+	}
+	corrupt := []byte(`{"task-1": {"ID": "task-1", "Name": "Trunc`)
+	if err := os.WriteFile(filepath.Join(ludwigDir, "tasks.json"), corrupt, 0644); err != nil {
+		t.Fatalf("failed to write corrupt file: %v", err)
+	}
+
+	s, err := storage.NewFileTaskStorage()
+	if err != nil {
+		t.Fatalf("expected storage to recover from a corrupt file, got %v", err)
+	}
+
+	tasks, err := s.ListTasks()
+	if err != nil {
+		t.Fatalf("failed to list tasks: %v", err)
+	}
+	if len(tasks) != 0 {
+		t.Errorf("expected to start with no tasks, got %d", len(tasks))
+	}
+
+	backups, _ := filepath.Glob(filepath.Join(ludwigDir, "tasks.json.corrupt-*"))
+	if len(backups) != 1 {
+		t.Fatalf("expected one quarantined backup, got %v", backups)
+	}
+	saved, err := os.ReadFile(backups[0])
+	if err != nil || string(saved) != string(corrupt) {
+		t.Errorf("expected backup to keep the corrupt contents, got %q (err %v)", saved, err)
+	}
+
+	recent := logger.Recent(1)
+	if len(recent) != 1 || recent[0].Level != logger.LevelWarn || !strings.Contains(recent[0].Message, "corrupt") {
+		t.Errorf("expected a warning about the corrupt file, got %v", recent)
+	}
+
+	// The store is usable afterwards
+	if err := s.AddTask(&task.Task{ID: "fresh", Name: "Fresh start"}); err != nil {
+		t.Errorf("failed to add a task after recovery: %v", err)
+	}
+}
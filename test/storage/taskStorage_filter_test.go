@@ -0,0 +1,190 @@
+package storage_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"ludwig/internal/storage"
+	"ludwig/internal/types/task"
+)
+
+func seedFilterTasks(t *testing.T) *storage.FileTaskStorage {
+	t.Helper()
+	s, err := storage.NewFileTaskStorage()
+	if err != nil {
+		t.Fatalf("failed to create storage: %v", err)
+	}
+
+	base := time.Date(2025, 1, 1, 9, 0, 0, 0, time.UTC)
+	tasks := []*task.Task{
+		{ID: "a", Name: "Write docs", Status: task.Pending, CreatedAt: base.Add(2 * time.Hour), Priority: 1, Tags: []string{"docs"}},
+		{ID: "b", Name: "Fix login bug", Status: task.InProgress, CreatedAt: base, Priority: 3, Tags: []string{"bug", "Backend"}},
+		{ID: "c", Name: "Add caching", Status: task.Pending, CreatedAt: base.Add(1 * time.Hour), Priority: 2, Tags: []string{"backend"}},
+		{ID: "d", Name: "Bump deps", Status: task.Completed, CreatedAt: base.Add(3 * time.Hour), Priority: 2},
+	}
+	for _, tk := range tasks {
+		if err := s.AddTask(tk); err != nil {
+			t.Fatalf("failed to add task %s: %v", tk.ID, err)
+		}
+	}
+	return s
+}
+
+func taskIDs(tasks []*task.Task) []string {
+	ids := make([]string, len(tasks))
+	for i, tk := range tasks {
+		ids[i] = tk.ID
+	}
+	return ids
+}
+
+func TestListTasksFiltered(t *testing.T) {
+	setupTestStorage(t)
+	defer cleanupTestStorage(t)
+	s := seedFilterTasks(t)
+
+	tests := []struct {
+		name     string
+		opts     storage.ListOptions
+		expected []string
+	}{
+		{
+			name:     "sort by created",
+			opts:     storage.ListOptions{SortBy: storage.SortByCreated},
+			expected: []string{"b", "c", "a", "d"},
+		},
+		{
+			name:     "sort by created descending",
+			opts:     storage.ListOptions{SortBy: storage.SortByCreated, Descending: true},
+			expected: []string{"d", "a", "c", "b"},
+		},
+		{
+			name:     "sort by priority breaks ties by ID",
+			opts:     storage.ListOptions{SortBy: storage.SortByPriority},
+			expected: []string{"a", "c", "d", "b"},
+		},
+		{
+			name:     "sort by priority descending",
+			opts:     storage.ListOptions{SortBy: storage.SortByPriority, Descending: true},
+			expected: []string{"b", "d", "c", "a"},
+		},
+		{
+			name:     "sort by name",
+			opts:     storage.ListOptions{SortBy: storage.SortByName},
+			expected: []string{"c", "d", "b", "a"},
+		},
+		{
+			name:     "filter by status",
+			opts:     storage.ListOptions{Statuses: []task.Status{task.Pending}, SortBy: storage.SortByName},
+			expected: []string{"c", "a"},
+		},
+		{
+			name:     "filter by multiple statuses",
+			opts:     storage.ListOptions{Statuses: []task.Status{task.InProgress, task.Completed}, SortBy: storage.SortByCreated},
+			expected: []string{"b", "d"},
+		},
+		{
+			name:     "filter by tag is case-insensitive",
+			opts:     storage.ListOptions{Tag: "BACKEND", SortBy: storage.SortByPriority},
+			expected: []string{"c", "b"},
+		},
+		{
+			name:     "filter by status and tag",
+			opts:     storage.ListOptions{Statuses: []task.Status{task.Pending}, Tag: "backend", SortBy: storage.SortByName},
+			expected: []string{"c"},
+		},
+		{
+			name:     "no matches",
+			opts:     storage.ListOptions{Tag: "frontend"},
+			expected: []string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tasks, err := s.ListTasksFiltered(tt.opts)
+			if err != nil {
+				t.Fatalf("ListTasksFiltered returned error: %v", err)
+			}
+			got := taskIDs(tasks)
+			if len(got) != len(tt.expected) {
+				t.Fatalf("expected %v, got %v", tt.expected, got)
+			}
+			for i := range got {
+				if got[i] != tt.expected[i] {
+					t.Fatalf("expected %v, got %v", tt.expected, got)
+				}
+			}
+		})
+	}
+}
+
+func TestListTasksFilteredWithoutOptionsReturnsEverything(t *testing.T) {
+	setupTestStorage(t)
+	defer cleanupTestStorage(t)
+	s := seedFilterTasks(t)
+
+	all, err := s.ListTasks()
+	if err != nil {
+		t.Fatalf("ListTasks returned error: %v", err)
+	}
+	filtered, err := s.ListTasksFiltered(storage.ListOptions{})
+	if err != nil {
+		t.Fatalf("ListTasksFiltered returned error: %v", err)
+	}
+	if len(filtered) != len(all) {
+		t.Errorf("expected %d tasks, got %d", len(all), len(filtered))
+	}
+}
+
+func TestArchivedTasksPersistButAreExcludedByDefault(t *testing.T) {
+	setupTestStorage(t)
+	defer cleanupTestStorage(t)
+	s := seedFilterTasks(t)
+
+	archived, err := s.GetTask("d")
+	if err != nil {
+		t.Fatalf("failed to get task: %v", err)
+	}
+	archived.Archived = true
+	if err := s.UpdateTask(archived); err != nil {
+		t.Fatalf("failed to archive task: %v", err)
+	}
+
+	// Reopen storage to make sure the flag was written to disk
+	reopened, err := storage.NewFileTaskStorage()
+	if err != nil {
+		t.Fatalf("failed to reopen storage: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		opts     storage.ListOptions
+		expected []string
+	}{
+		{"default excludes archived", storage.ListOptions{SortBy: storage.SortByName}, []string{"c", "b", "a"}},
+		{"include archived", storage.ListOptions{Archived: storage.IncludeArchived, SortBy: storage.SortByName}, []string{"c", "d", "b", "a"}},
+		{"only archived", storage.ListOptions{Archived: storage.OnlyArchived}, []string{"d"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tasks, err := reopened.ListTasksFiltered(tt.opts)
+			if err != nil {
+				t.Fatalf("ListTasksFiltered returned error: %v", err)
+			}
+			got := taskIDs(tasks)
+			if strings.Join(got, ",") != strings.Join(tt.expected, ",") {
+				t.Errorf("expected %v, got %v", tt.expected, got)
+			}
+		})
+	}
+
+	all, err := reopened.ListTasks()
+	if err != nil {
+		t.Fatalf("ListTasks returned error: %v", err)
+	}
+	if len(all) != 4 {
+		t.Errorf("expected ListTasks to still return archived tasks, got %d tasks", len(all))
+	}
+}
@@ -0,0 +1,153 @@
+package storage_test
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"ludwig/internal/storage"
+	"ludwig/internal/types/task"
+)
+
+func writeTasksFile(t *testing.T, contents string) string {
+	t.Helper()
+	ludwigDir := filepath.Join(".", ".ludwig")
+	if err := os.MkdirAll(ludwigDir, 0755); err != nil {
+		t.Fatalf("failed to create .ludwig: %v", err)
+	}
+	path := filepath.Join(ludwigDir, "tasks.json")
+	if err := os.WriteFile(path, []byte(contents), 0644); err != nil {
+		t.Fatalf("failed to write tasks.json: %v", err)
+	}
+	return path
+}
+
+func readSchemaVersion(t *testing.T, path string) int {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read tasks.json: %v", err)
+	}
+	var file struct {
+		SchemaVersion int `json:"schemaVersion"`
+	}
+	if err := json.Unmarshal(data, &file); err != nil {
+		t.Fatalf("failed to unmarshal tasks.json: %v", err)
+	}
+	return file.SchemaVersion
+}
+
+func TestVersionZeroFileUpgrades(t *testing.T) {
+	setupTestStorage(t)
+	defer cleanupTestStorage(t)
+
+	path := writeTasksFile(t, `{"legacy-1": {"ID": "legacy-1", "Name": "Old task", "Status": 1}}`)
+
+	s, err := storage.NewFileTaskStorage()
+	if err != nil {
+		t.Fatalf("failed to load v0 file: %v", err)
+	}
+	got, err := s.GetTask("legacy-1")
+	if err != nil {
+		t.Fatalf("expected legacy task to load, got %v", err)
+	}
+	if got.Name != "Old task" || got.Status != task.InProgress {
+		t.Errorf("legacy task not decoded correctly: %+v", got)
+	}
+
+	// The file is rewritten in the current format on the next save
+	got.Name = "Renamed"
+	if err := s.UpdateTask(got); err != nil {
+		t.Fatalf("failed to update task: %v", err)
+	}
+	if v := readSchemaVersion(t, path); v != storage.CURRENT_SCHEMA_VERSION {
+		t.Errorf("expected schema version %d after save, got %d", storage.CURRENT_SCHEMA_VERSION, v)
+	}
+}
+
+func TestSchemaVersionSurvivesRoundTrip(t *testing.T) {
+	setupTestStorage(t)
+	defer cleanupTestStorage(t)
+
+	s, _ := storage.NewFileTaskStorage()
+	s.AddTask(&task.Task{ID: "round-trip", Name: "Round trip", Status: task.Pending})
+
+	path := filepath.Join(".", ".ludwig", "tasks.json")
+	reloaded, err := storage.NewFileTaskStorage()
+	if err != nil {
+		t.Fatalf("failed to reload storage: %v", err)
+	}
+	got, err := reloaded.GetTask("round-trip")
+	if err != nil || got.Name != "Round trip" {
+		t.Fatalf("expected task to survive reload, got %+v (err %v)", got, err)
+	}
+	if err := reloaded.UpdateTask(got); err != nil {
+		t.Fatalf("failed to update task: %v", err)
+	}
+	if v := readSchemaVersion(t, path); v != storage.CURRENT_SCHEMA_VERSION {
+		t.Errorf("expected schema version %d, got %d", storage.CURRENT_SCHEMA_VERSION, v)
+	}
+}
+
+func TestNewerSchemaVersionIsRefused(t *testing.T) {
+	setupTestStorage(t)
+	defer cleanupTestStorage(t)
+
+	path := writeTasksFile(t, `{"schemaVersion": 999, "tasks": {}}`)
+
+	_, err := storage.NewFileTaskStorage()
+	if err == nil || !strings.Contains(err.Error(), "schema version 999") {
+		t.Fatalf("expected a schema version error, got %v", err)
+	}
+	// A newer file is not corrupt, so it must be left where it is
+	if _, statErr := os.Stat(path); statErr != nil {
+		t.Errorf("expected tasks.json to be left in place, got %v", statErr)
+	}
+}
+
+func TestVersionOneStatusesKeepTheirMeaning(t *testing.T) {
+	setupTestStorage(t)
+	defer cleanupTestStorage(t)
+
+	writeTasksFile(t, `{"schemaVersion": 1, "tasks": {"done-1": {"ID": "done-1", "Name": "Shipped", "Status": 3}}}`)
+	s, err := storage.NewFileTaskStorage()
+	if err != nil {
+		t.Fatalf("failed to load v1 file: %v", err)
+	}
+	if got, _ := s.GetTask("done-1"); got == nil || got.Status != task.Completed {
+		t.Errorf("expected the numeric status to stay Completed, got %+v", got)
+	}
+}
+
+func TestCustomStatusIsStoredByName(t *testing.T) {
+	setupTestStorage(t)
+	defer cleanupTestStorage(t)
+
+	blocked := task.CustomStatus("Blocked")
+	s, _ := storage.NewFileTaskStorage()
+	tk := &task.Task{ID: "stuck", Name: "Waiting on infra", Status: task.Pending}
+	s.AddTask(tk)
+	tk.MoveTo(blocked, "Moved manually")
+	if err := s.UpdateTask(tk); err != nil {
+		t.Fatalf("failed to update task: %v", err)
+	}
+
+	data, err := os.ReadFile(filepath.Join(".ludwig", "tasks.json"))
+	if err != nil {
+		t.Fatalf("failed to read tasks.json: %v", err)
+	}
+	if !strings.Contains(string(data), `"Status": "Blocked"`) {
+		t.Errorf("expected the custom status to be stored by name, got:\n%s", data)
+	}
+
+	reloaded, _ := storage.NewFileTaskStorage()
+	got, _ := reloaded.GetTask("stuck")
+	if got == nil || got.Status != blocked || task.StatusString(*got) != "Blocked" {
+		t.Fatalf("expected the task to come back Blocked, got %+v", got)
+	}
+	if got.History[0].From != task.Pending || got.History[0].To != blocked {
+		t.Errorf("expected the history to keep both statuses, got %+v", got.History[0])
+	}
+}
@@ -240,12 +240,18 @@ func TestTaskJSONFormat(t *testing.T) {
 		t.Fatalf("failed to read task file: %v", err)
 	}
 
-	var tasks map[string]*task.Task
-	if err := json.Unmarshal(data, &tasks); err != nil {
+	var file struct {
+		SchemaVersion int                   `json:"schemaVersion"`
+		Tasks         map[string]*task.Task `json:"tasks"`
+	}
+	if err := json.Unmarshal(data, &file); err != nil {
 		t.Fatalf("failed to unmarshal JSON: %v", err)
 	}
 
-	if _, ok := tasks["format-test"]; !ok {
+	if file.SchemaVersion != storage.CURRENT_SCHEMA_VERSION {
+		t.Errorf("expected schema version %d, got %d", storage.CURRENT_SCHEMA_VERSION, file.SchemaVersion)
+	}
+	if _, ok := file.Tasks["format-test"]; !ok {
 		t.Errorf("task not found in JSON file")
 	}
 }
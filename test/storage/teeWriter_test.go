@@ -0,0 +1,125 @@
+package storage_test
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"ludwig/internal/storage"
+)
+
+// syncBuffer is a bytes.Buffer that can be written by a mirror and read by the test
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *syncBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
+
+// stuckWriter blocks every write until release is closed
+type stuckWriter struct{ release chan struct{} }
+
+func (w stuckWriter) Write(p []byte) (int, error) {
+	<-w.release
+	return len(p), nil
+}
+
+func TestTeeWriterSendsFullStreamToEveryWriter(t *testing.T) {
+	var file bytes.Buffer
+	live := &syncBuffer{}
+	tee := storage.NewTeeWriter(&file, live)
+
+	var want strings.Builder
+	for i := 0; i < 100; i++ {
+		chunk := fmt.Sprintf("chunk %d\n", i)
+		want.WriteString(chunk)
+		if n, err := tee.Write([]byte(chunk)); err != nil || n != len(chunk) {
+			t.Fatalf("write %d: got n=%d err=%v", i, n, err)
+		}
+	}
+	tee.Close()
+
+	if file.String() != want.String() {
+		t.Errorf("expected the file to get the full stream, got %q", file.String())
+	}
+	if live.String() != want.String() {
+		t.Errorf("expected the mirror to get the full stream, got %q", live.String())
+	}
+	if tee.Dropped() != 0 {
+		t.Errorf("expected nothing dropped, got %d", tee.Dropped())
+	}
+}
+
+func TestTeeWriterSlowMirrorDoesNotBlockFile(t *testing.T) {
+	var file bytes.Buffer
+	stuck := stuckWriter{release: make(chan struct{})}
+	tee := storage.NewTeeWriter(&file, stuck)
+
+	writes := storage.TEE_BUFFER_SIZE * 2
+	done := make(chan struct{})
+	go func() {
+		for i := 0; i < writes; i++ {
+			tee.Write([]byte("x"))
+		}
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("expected writes to carry on while the mirror is stuck")
+	}
+
+	if file.Len() != writes {
+		t.Errorf("expected every write to reach the file, got %d of %d", file.Len(), writes)
+	}
+	if tee.Dropped() == 0 {
+		t.Error("expected writes to be dropped for the stuck mirror")
+	}
+	close(stuck.release)
+	tee.Close()
+}
+
+func TestResponseWriterMirrorsWhatReachesFile(t *testing.T) {
+	t.Chdir(t.TempDir())
+	rw, _, err := storage.NewResponseWriter("mirror-task")
+	if err != nil {
+		t.Fatalf("failed to create response writer: %v", err)
+	}
+	r, _ := storage.NewRedactor([]string{`FAKE-SECRET-[0-9]+`})
+	rw.SetRedactor(r)
+	live := &syncBuffer{}
+	rw.SetMirror(live)
+
+	for _, chunk := range []string{"first line\n", "key FAKE-SEC", "RET-4242 here\n"} {
+		if err := rw.WriteChunk(chunk); err != nil {
+			t.Fatalf("failed to write chunk: %v", err)
+		}
+	}
+	filePath := rw.GetFilePath()
+	rw.Close()
+
+	content, err := os.ReadFile(filePath)
+	if err != nil {
+		t.Fatalf("failed to read file: %v", err)
+	}
+	if !strings.HasSuffix(string(content), live.String()) || !strings.Contains(live.String(), "first line\nkey [REDACTED] here\n") {
+		t.Errorf("expected the mirror to get the redacted output as written to the file, got %q", live.String())
+	}
+	if strings.Contains(live.String(), "FAKE-SECRET") {
+		t.Errorf("expected the secret to be masked for the mirror too, got %q", live.String())
+	}
+}
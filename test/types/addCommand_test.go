@@ -0,0 +1,74 @@
+package types_test
+
+import (
+	"strings"
+	"testing"
+
+	"ludwig/internal/types/model"
+	"ludwig/internal/types/task"
+)
+
+func runCommand(t *testing.T, commands []model.Command, name, input string) string {
+	t.Helper()
+	for _, cmd := range commands {
+		if cmd.Text == name {
+			return cmd.Action(input, nil)
+		}
+	}
+	t.Fatalf("command %q not found", name)
+	return ""
+}
+
+func TestAddKeepsMultilineDescription(t *testing.T) {
+	store := newRefStore(t)
+	description := "Refactor the parser\n\n- keep  the public API\n- add tests"
+
+	runCommand(t, model.PalleteCommands(store), "add", "add "+description)
+
+	tasks, err := store.ListTasks()
+	if err != nil || len(tasks) != 1 {
+		t.Fatalf("expected one task, got %d (err %v)", len(tasks), err)
+	}
+	if tasks[0].Name != description {
+		t.Errorf("expected name %q, got %q", description, tasks[0].Name)
+	}
+}
+
+func TestAddStillRequiresDescription(t *testing.T) {
+	store := newRefStore(t)
+
+	out := runCommand(t, model.PalleteCommands(store), "add", "add")
+
+	tasks, _ := store.ListTasks()
+	if len(tasks) != 0 {
+		t.Errorf("expected no task to be added, got %d", len(tasks))
+	}
+	if out == "" {
+		t.Error("expected a usage message")
+	}
+}
+
+func TestAddWithModel(t *testing.T) {
+	store := newRefStore(t)
+	commands := model.PalleteCommands(store)
+
+	runCommand(t, commands, "add", "add --model gemini-2.5-pro Rework auth\n- keep sessions")
+	if out := runCommand(t, commands, "add", "add --model gemini-2.5-pro"); !strings.Contains(out, "Usage") {
+		t.Errorf("expected a usage message without a description, got %q", out)
+	}
+
+	tasks, err := store.ListTasks()
+	if err != nil || len(tasks) != 1 {
+		t.Fatalf("expected one task, got %d (err %v)", len(tasks), err)
+	}
+	if tasks[0].Model != "gemini-2.5-pro" || tasks[0].Name != "Rework auth\n- keep sessions" {
+		t.Errorf("expected the model to be split from the description, got model %q name %q", tasks[0].Model, tasks[0].Name)
+	}
+}
+
+func TestTitleIsFirstLine(t *testing.T) {
+	tk := task.Task{Name: "Refactor the parser  \n- keep the public API"}
+	if got := tk.Title(); got != "Refactor the parser" {
+		t.Errorf("expected first line as title, got %q", got)
+	}
+}
@@ -0,0 +1,65 @@
+package types_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"ludwig/internal/config"
+	"ludwig/internal/orchestrator"
+	"ludwig/internal/storage"
+	"ludwig/internal/types/model"
+)
+
+// launchWithAutoStart creates a Model in a fresh project with autoStart on and
+// only the named stub executables on PATH, and runs its auto-start command
+func launchWithAutoStart(t *testing.T, executables ...string) *model.Model {
+	t.Helper()
+	t.Chdir(t.TempDir())
+	bin := t.TempDir()
+	for _, name := range executables {
+		if err := os.WriteFile(filepath.Join(bin, name), []byte("#!/bin/sh\nexit 0\n"), 0755); err != nil {
+			t.Fatalf("failed to write stub %s: %v", name, err)
+		}
+	}
+	t.Setenv("PATH", bin)
+	if err := config.SaveConfig(&config.Config{AutoStart: true}); err != nil {
+		t.Fatalf("failed to save config: %v", err)
+	}
+	store, err := storage.NewFileTaskStorage()
+	if err != nil {
+		t.Fatalf("failed to create storage: %v", err)
+	}
+	t.Cleanup(orchestrator.Stop)
+
+	m := model.NewModel(store, "test")
+	cmd := m.AutoStart()
+	if cmd == nil {
+		t.Fatal("expected an auto-start command with autoStart on")
+	}
+	m.Update(cmd())
+	return m
+}
+
+func TestAutoStartStartsOrchestrator(t *testing.T) {
+	m := launchWithAutoStart(t, "git", "gemini")
+
+	if !orchestrator.IsRunning() {
+		t.Fatal("expected the orchestrator to be running after launch")
+	}
+	if !strings.Contains(m.Message(), "started automatically") {
+		t.Errorf("expected a message saying it was started, got %q", m.Message())
+	}
+}
+
+func TestAutoStartWaitsForProvider(t *testing.T) {
+	m := launchWithAutoStart(t, "git")
+
+	if orchestrator.IsRunning() {
+		t.Fatal("expected the orchestrator not to start without the AI provider")
+	}
+	if !strings.Contains(m.Message(), "gemini CLI is not available") {
+		t.Errorf("expected a message naming the missing provider, got %q", m.Message())
+	}
+}
@@ -0,0 +1,160 @@
+package types_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"ludwig/internal/components/commandFinder"
+	"ludwig/internal/orchestrator"
+	"ludwig/internal/types/model"
+)
+
+// slowCommand returns a background command that blocks until release is closed
+func slowCommand(release chan struct{}) model.Command {
+	return model.Command{
+		Text:       "slow",
+		Background: true,
+		Action: func(text string, m *model.Model) string {
+			<-release
+			return "slow finished"
+		},
+	}
+}
+
+// runBatch runs the commands a background command started, delivering their
+// messages in the order they finish
+func runBatch(cmd tea.Cmd) chan tea.Msg {
+	msgs := make(chan tea.Msg, 8)
+	batch, ok := cmd().(tea.BatchMsg)
+	if !ok {
+		batch = tea.BatchMsg{cmd}
+	}
+	for _, c := range batch {
+		go func() { msgs <- c() }()
+	}
+	return msgs
+}
+
+// awaitResult feeds messages to m until its background command is done
+func awaitResult(t *testing.T, m *model.Model, msgs chan tea.Msg) {
+	t.Helper()
+	deadline := time.After(5 * time.Second)
+	for m.Background() != "" {
+		select {
+		case msg := <-msgs:
+			if _, next := m.Update(msg); next != nil {
+				go func() { msgs <- next() }()
+			}
+		case <-deadline:
+			t.Fatal("timed out waiting for the background command")
+		}
+	}
+}
+
+func TestBackgroundCommandDoesNotBlockKeys(t *testing.T) {
+	release := make(chan struct{})
+	m := &model.Model{}
+
+	started := time.Now()
+	cmd := m.RunInBackground(slowCommand(release), "slow")
+	msgs := runBatch(cmd)
+	if elapsed := time.Since(started); elapsed > time.Second {
+		t.Fatalf("expected the command to start without waiting, took %v", elapsed)
+	}
+	if m.Background() != "slow" {
+		t.Fatalf("expected slow to be running, got %q", m.Background())
+	}
+
+	// Keys are handled while the action is still blocked
+	m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if !strings.Contains(m.Message(), "Cancelling slow") {
+		t.Fatalf("expected Esc to cancel the running command, got %q", m.Message())
+	}
+	// The action is still going, so other commands wait for it
+	m.Update(commandFinder.SelectMsg{Entry: commandFinder.Entry{Name: "start"}})
+	if m.Background() != "slow" || !strings.Contains(m.Message(), "start was not run") {
+		t.Fatalf("expected commands to be refused until the cancelled one returns, got %q", m.Message())
+	}
+
+	close(release)
+	for range 2 {
+		select {
+		case msg := <-msgs:
+			m.Update(msg)
+		case <-time.After(5 * time.Second):
+			t.Fatal("timed out waiting for the command's messages")
+		}
+	}
+	if m.Background() != "" || m.Message() != "Cancelled slow" {
+		t.Errorf("expected the cancelled command's result to be dropped, got %q", m.Message())
+	}
+}
+
+func TestBackgroundCommandShowsResult(t *testing.T) {
+	release := make(chan struct{})
+	close(release)
+	m := &model.Model{}
+
+	awaitResult(t, m, runBatch(m.RunInBackground(slowCommand(release), "slow")))
+	if m.Message() != "slow finished" {
+		t.Errorf("expected the command's output to be shown, got %q", m.Message())
+	}
+}
+
+func TestStopRunsInBackground(t *testing.T) {
+	store := newRefStore(t)
+	if err := orchestrator.Start(); err != nil {
+		t.Skipf("orchestrator unavailable: %v", err)
+	}
+	defer orchestrator.Stop()
+
+	var stop model.Command
+	for _, cmd := range model.PalleteCommands(store) {
+		if cmd.Text == "stop" {
+			stop = cmd
+		}
+	}
+	if !stop.Background {
+		t.Fatal("expected stop to run in the background")
+	}
+
+	m := &model.Model{}
+	started := time.Now()
+	msgs := runBatch(m.RunInBackground(stop, "stop"))
+	if elapsed := time.Since(started); elapsed > time.Second {
+		t.Fatalf("expected stop to return without waiting on the orchestrator, took %v", elapsed)
+	}
+	awaitResult(t, m, msgs)
+	if m.Message() != "AI Orchestrator stopped." || orchestrator.IsRunning() {
+		t.Errorf("expected the orchestrator to be stopped, got %q", m.Message())
+	}
+}
+
+func TestCommandsRefusedWhileBackgroundCommandRuns(t *testing.T) {
+	release := make(chan struct{})
+	m := &model.Model{}
+	msgs := runBatch(m.RunInBackground(slowCommand(release), "slow"))
+
+	m.Update(commandFinder.SelectMsg{Entry: commandFinder.Entry{Name: "delete"}})
+	if m.Background() != "slow" {
+		t.Fatalf("expected slow to keep running, got %q", m.Background())
+	}
+	if !strings.Contains(m.Message(), "Running slow") || !strings.Contains(m.Message(), "delete was not run") {
+		t.Errorf("expected a working indicator naming the refused command, got %q", m.Message())
+	}
+
+	close(release)
+	awaitResult(t, m, msgs)
+	if m.Message() != "slow finished" {
+		t.Errorf("expected the command's output once done, got %q", m.Message())
+	}
+
+	// Commands are accepted again afterwards
+	m.Update(commandFinder.SelectMsg{Entry: commandFinder.Entry{Name: "delete"}})
+	if m.Message() != "Command not found: delete" {
+		t.Errorf("expected the next command to run, got %q", m.Message())
+	}
+}
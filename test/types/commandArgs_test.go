@@ -0,0 +1,92 @@
+package types_test
+
+import (
+	"io"
+	"os"
+	"strings"
+	"testing"
+
+	"ludwig/internal/types/model"
+)
+
+// argsInput builds an input for cmd with count placeholder arguments
+func argsInput(cmd model.Command, count int) string {
+	return strings.TrimSpace(cmd.Text + strings.Repeat(" x", count))
+}
+
+func TestCommandsRejectWrongArgumentCounts(t *testing.T) {
+	store := newRefStore(t)
+	for _, cmd := range model.PalleteCommands(store) {
+		want := "Usage: " + cmd.Synopsis() + " - " + cmd.Description
+		if cmd.MinArgs > 0 {
+			if got := cmd.Action(argsInput(cmd, cmd.MinArgs-1), nil); got != want {
+				t.Errorf("%s with too few arguments: expected %q, got %q", cmd.Text, want, got)
+			}
+		}
+		if cmd.MaxArgs != model.ANY_ARGS {
+			if got := cmd.Action(argsInput(cmd, cmd.MaxArgs+1), nil); got != want {
+				t.Errorf("%s with too many arguments: expected %q, got %q", cmd.Text, want, got)
+			}
+		}
+	}
+}
+
+func TestCommandUsageMatchesArguments(t *testing.T) {
+	for _, cmd := range model.PalleteCommands(newRefStore(t)) {
+		required := strings.Count(cmd.Usage, "<")
+		if required != cmd.MinArgs && cmd.MaxArgs != model.ANY_ARGS {
+			t.Errorf("%s: usage %q lists %d required arguments but MinArgs is %d", cmd.Text, cmd.Usage, required, cmd.MinArgs)
+		}
+		if cmd.TakesTaskRef && !strings.HasPrefix(cmd.Usage, "<task ref>") {
+			t.Errorf("%s takes a task ref but its usage is %q", cmd.Text, cmd.Usage)
+		}
+		if strings.HasPrefix(cmd.Description, cmd.Text+" ") {
+			t.Errorf("%s: description should not repeat the usage, got %q", cmd.Text, cmd.Description)
+		}
+	}
+}
+
+func TestValidateArgs(t *testing.T) {
+	view := model.Command{Text: "view", Usage: "<task ref> [run]", MinArgs: 1, MaxArgs: 2, Description: "View a task."}
+	for input, ok := range map[string]bool{"view": false, "view 1": true, "view 1 2": true, "view 1 2 3": false} {
+		if got := model.ValidateArgs(view, input); (got == "") != ok {
+			t.Errorf("%q: expected accepted=%v, got %q", input, ok, got)
+		}
+	}
+	if got := model.ValidateArgs(view, "view"); got != "Usage: view <task ref> [run] - View a task." {
+		t.Errorf("unexpected usage message %q", got)
+	}
+}
+
+func TestBadArgumentsDoNotWriteToStdout(t *testing.T) {
+	store := newRefStore(t)
+	reader, writer, err := os.Pipe()
+	if err != nil {
+		t.Fatalf("failed to create pipe: %v", err)
+	}
+	stdout := os.Stdout
+	os.Stdout = writer
+
+	// Under the TUI anything written to stdout corrupts the screen
+	var messages []string
+	for _, cmd := range model.PalleteCommands(store) {
+		if cmd.MaxArgs != model.ANY_ARGS {
+			messages = append(messages, cmd.Action(argsInput(cmd, cmd.MaxArgs+1), nil))
+		}
+		if cmd.MinArgs > 0 {
+			messages = append(messages, cmd.Action(argsInput(cmd, cmd.MinArgs-1), nil))
+		}
+	}
+	os.Stdout = stdout
+	writer.Close()
+
+	written, _ := io.ReadAll(reader)
+	if len(written) != 0 {
+		t.Errorf("expected nothing written to stdout, got %q", written)
+	}
+	for _, message := range messages {
+		if !strings.HasPrefix(message, "Usage: ") {
+			t.Errorf("expected the usage to be returned as the message, got %q", message)
+		}
+	}
+}
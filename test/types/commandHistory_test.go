@@ -0,0 +1,92 @@
+package types_test
+
+import (
+	"slices"
+	"testing"
+
+	"ludwig/internal/types/model"
+)
+
+func TestHistoryRecallOrder(t *testing.T) {
+	h := model.NewCommandHistory(10)
+	h.Add("add first")
+	h.Add("add second")
+	h.Add("start")
+
+	for _, want := range []string{"start", "add second", "add first"} {
+		got, ok := h.Previous("")
+		if !ok || got != want {
+			t.Fatalf("Previous() = %q, %v; want %q", got, ok, want)
+		}
+	}
+	if _, ok := h.Previous(""); ok {
+		t.Error("expected Previous to stop at the oldest command")
+	}
+
+	for _, want := range []string{"add second", "start"} {
+		got, ok := h.Next()
+		if !ok || got != want {
+			t.Fatalf("Next() = %q, %v; want %q", got, ok, want)
+		}
+	}
+}
+
+func TestHistoryRestoresDraft(t *testing.T) {
+	h := model.NewCommandHistory(10)
+	h.Add("add first")
+
+	if got, _ := h.Previous("half typed"); got != "add first" {
+		t.Fatalf("expected to recall 'add first', got %q", got)
+	}
+	got, ok := h.Next()
+	if !ok || got != "half typed" {
+		t.Errorf("expected Next past the newest command to restore the draft, got %q, %v", got, ok)
+	}
+	if _, ok := h.Next(); ok {
+		t.Error("expected Next to do nothing once back at the draft")
+	}
+}
+
+func TestEditingRecalledCommandLeavesHistory(t *testing.T) {
+	h := model.NewCommandHistory(10)
+	h.Add("add write docs")
+
+	recalled, _ := h.Previous("")
+	edited := recalled + " and tests"
+	h.Add(edited)
+
+	want := []string{"add write docs", "add write docs and tests"}
+	if got := h.Entries(); !slices.Equal(got, want) {
+		t.Errorf("expected history %v, got %v", want, got)
+	}
+}
+
+func TestHistoryDropsOldestAndRepeats(t *testing.T) {
+	h := model.NewCommandHistory(2)
+	h.Add("one")
+	h.Add("two")
+	h.Add("two")
+	h.Add("  ")
+	h.Add("three")
+
+	want := []string{"two", "three"}
+	if got := h.Entries(); !slices.Equal(got, want) {
+		t.Errorf("expected history %v, got %v", want, got)
+	}
+}
+
+func TestHistoryPersists(t *testing.T) {
+	t.Chdir(t.TempDir())
+
+	h := model.NewCommandHistory(10)
+	h.Add("add first")
+	h.Add("list")
+	if err := h.Save(); err != nil {
+		t.Fatalf("failed to save history: %v", err)
+	}
+
+	loaded := model.LoadCommandHistory(10)
+	if got := loaded.Entries(); !slices.Equal(got, []string{"add first", "list"}) {
+		t.Errorf("expected saved history to load, got %v", got)
+	}
+}
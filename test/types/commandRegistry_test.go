@@ -0,0 +1,69 @@
+package types_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"ludwig/internal/types/model"
+)
+
+func TestRegisteredCommandRunsLikeBuiltIn(t *testing.T) {
+	err := model.RegisterCommand(model.Command{
+		Text:        "greet",
+		Description: "Say hello to someone",
+		Usage:       "<name>",
+		MinArgs:     1,
+		MaxArgs:     1,
+		Action: func(text string, m *model.Model) string {
+			return "Hello, " + strings.Fields(text)[1] + "!"
+		},
+	})
+	if err != nil {
+		t.Fatalf("failed to register the command: %v", err)
+	}
+	store := newRefStore(t)
+	commands := model.PalleteCommands(store)
+
+	greet := findCommand(t, commands, "greet")
+	if got := greet.Action("greet Ada", nil); got != "Hello, Ada!" {
+		t.Errorf("expected the custom action to run, got %q", got)
+	}
+	if got := greet.Action("greet", nil); !strings.HasPrefix(got, "Usage: greet <name>") {
+		t.Errorf("expected the argument count to be checked like a built-in, got %q", got)
+	}
+	if help := findCommand(t, commands, "help").Action("help", nil); !strings.Contains(help, "greet <name>") {
+		t.Errorf("expected help to list the custom command, got:\n%s", help)
+	}
+}
+
+func TestRegisteredCommandCannotReplaceBuiltIn(t *testing.T) {
+	if err := model.RegisterCommand(model.Command{
+		Text:   "stats",
+		Action: func(text string, m *model.Model) string { return "hijacked" },
+	}); err != nil {
+		t.Fatalf("failed to register the command: %v", err)
+	}
+	store := newRefStore(t)
+
+	count := 0
+	for _, cmd := range model.PalleteCommands(store) {
+		if cmd.Text == "stats" {
+			count++
+			if cmd.Action("stats", nil) == "hijacked" {
+				t.Error("expected the built-in stats command to win")
+			}
+		}
+	}
+	if count != 1 {
+		t.Errorf("expected one stats command, got %d", count)
+	}
+}
+
+func TestRegisterCommandRejectsBadNames(t *testing.T) {
+	for _, name := range []string{"", "two words"} {
+		if err := model.RegisterCommand(model.Command{Text: name}); !errors.Is(err, model.ErrInvalidCommandName) {
+			t.Errorf("name %q: expected ErrInvalidCommandName, got %v", name, err)
+		}
+	}
+}
@@ -0,0 +1,51 @@
+package types_test
+
+import (
+	"strings"
+	"testing"
+
+	"ludwig/internal/types/model"
+	"ludwig/internal/types/task"
+)
+
+func TestCommentsAppendInOrder(t *testing.T) {
+	store := newRefStore(t, &task.Task{ID: "abc123def", Name: "Refactor the parser", Status: task.Pending})
+	commands := model.PalleteCommands(store)
+
+	runCommand(t, commands, "comment", "comment abc123 Check the  edge cases first")
+	out := runCommand(t, commands, "comment", "comment abc123 Then the docs\n- README too")
+	if !strings.Contains(out, "comment 2") {
+		t.Errorf("expected the second comment to be reported, got %q", out)
+	}
+
+	got, err := store.GetTask("abc123def")
+	if err != nil {
+		t.Fatalf("failed to reload task: %v", err)
+	}
+	want := []string{"Check the  edge cases first", "Then the docs\n- README too"}
+	if len(got.Comments) != len(want) {
+		t.Fatalf("expected %d comments, got %d", len(want), len(got.Comments))
+	}
+	for i, text := range want {
+		if got.Comments[i].Text != text {
+			t.Errorf("comment %d: expected %q, got %q", i, text, got.Comments[i].Text)
+		}
+	}
+	if got.Comments[1].At.Before(got.Comments[0].At) {
+		t.Error("expected comments to be kept oldest first")
+	}
+	if got.ReviewResponse != nil {
+		t.Error("expected comments to stay separate from review notes")
+	}
+}
+
+func TestCommentRequiresText(t *testing.T) {
+	store := newRefStore(t, &task.Task{ID: "abc123def", Name: "Refactor the parser"})
+
+	out := runCommand(t, model.PalleteCommands(store), "comment", "comment abc123")
+
+	got, _ := store.GetTask("abc123def")
+	if len(got.Comments) != 0 || !strings.HasPrefix(out, "Usage") {
+		t.Errorf("expected a usage message and no comment, got %q with %d comments", out, len(got.Comments))
+	}
+}
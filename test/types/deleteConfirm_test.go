@@ -0,0 +1,81 @@
+package types_test
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"ludwig/internal/types/model"
+	"ludwig/internal/types/task"
+)
+
+func findCommand(t *testing.T, commands []model.Command, name string) model.Command {
+	t.Helper()
+	for _, cmd := range commands {
+		if cmd.Text == name {
+			return cmd
+		}
+	}
+	t.Fatalf("command %q not found", name)
+	return model.Command{}
+}
+
+func keyPress(key string) tea.KeyMsg {
+	return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(key)}
+}
+
+func TestDeleteAsksBeforeDeleting(t *testing.T) {
+	store := newRefStore(t, &task.Task{ID: "doomed-task", Name: "Remove me", Status: task.Pending})
+	deleteCmd := findCommand(t, model.PalleteCommands(store), "delete")
+	m := &model.Model{}
+
+	out := deleteCmd.Action("delete doomed-task", m)
+	if !strings.Contains(out, "Remove me") || !strings.HasSuffix(out, "(y/n)") {
+		t.Errorf("expected a y/n question naming the task, got %q", out)
+	}
+	if got, _ := store.GetTask("doomed-task"); got == nil {
+		t.Fatal("expected nothing deleted before the answer")
+	}
+
+	m.Update(keyPress("n"))
+	if got, _ := store.GetTask("doomed-task"); got == nil {
+		t.Fatal("expected n to keep the task")
+	}
+	if m.Confirmation() != "" {
+		t.Errorf("expected the question to be answered, still asking %q", m.Confirmation())
+	}
+
+	deleteCmd.Action("delete doomed-task", m)
+	_, cmd := m.Update(keyPress("y"))
+	if got, _ := store.GetTask("doomed-task"); got != nil {
+		t.Error("expected y to delete the task")
+	}
+	if cmd == nil {
+		t.Error("expected the board to be refreshed after deleting")
+	}
+}
+
+func TestDeleteConfirmationCancelledByOtherKeys(t *testing.T) {
+	store := newRefStore(t, &task.Task{ID: "kept-task", Name: "Keep me", Status: task.Pending})
+	m := &model.Model{}
+
+	findCommand(t, model.PalleteCommands(store), "delete").Action("delete kept-task", m)
+	m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if got, _ := store.GetTask("kept-task"); got == nil || m.Confirmation() != "" {
+		t.Error("expected Esc to cancel the delete")
+	}
+}
+
+func TestDeleteYesSkipsConfirmation(t *testing.T) {
+	store := newRefStore(t, &task.Task{ID: "scripted-task", Name: "Delete me", Status: task.Pending})
+	m := &model.Model{}
+
+	out := findCommand(t, model.PalleteCommands(store), "delete").Action("delete scripted-task --yes", m)
+	if !strings.HasPrefix(out, "Deleted task") || m.Confirmation() != "" {
+		t.Errorf("expected --yes to delete straight away, got %q", out)
+	}
+	if got, _ := store.GetTask("scripted-task"); got != nil {
+		t.Error("expected the task to be deleted")
+	}
+}
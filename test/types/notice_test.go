@@ -0,0 +1,37 @@
+package types_test
+
+import (
+	"testing"
+
+	"ludwig/internal/notify"
+	"ludwig/internal/storage"
+	"ludwig/internal/types/model"
+)
+
+func TestBackgroundErrorShowsInMessageArea(t *testing.T) {
+	t.Chdir(t.TempDir())
+	store, err := storage.NewFileTaskStorage()
+	if err != nil {
+		t.Fatalf("failed to create storage: %v", err)
+	}
+	m := model.NewModel(store, "test")
+
+	notify.Errorf("Task %s failed: %s", "abc123", "model overloaded")
+	m.Update(m.WaitForNotice()())
+	if got, want := m.Message(), "Error: Task abc123 failed: model overloaded"; got != want {
+		t.Fatalf("expected %q, got %q", want, got)
+	}
+
+	// A warning straight after doesn't hide the more severe error
+	notify.Warnf("Could not check for updates: %s", "offline")
+	m.Update(m.WaitForNotice()())
+	if got, want := m.Message(), "Error: Task abc123 failed: model overloaded"; got != want {
+		t.Errorf("expected the error to stay, got %q", got)
+	}
+
+	notify.Errorf("Orchestrator could not list tasks: %s", "disk full")
+	m.Update(m.WaitForNotice()())
+	if got, want := m.Message(), "Error: Orchestrator could not list tasks: disk full"; got != want {
+		t.Errorf("expected a new error to replace the old one, got %q", got)
+	}
+}
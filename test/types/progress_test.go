@@ -0,0 +1,86 @@
+package types_test
+
+import (
+	"testing"
+
+	"ludwig/internal/types/task"
+)
+
+func TestParseProgressSystemPromptExample(t *testing.T) {
+	text := `✓ Read README.md for project structure
+✓ Created auth middleware in internal/middleware/auth.go
+✓ Added 5 unit tests in test/middleware/auth_test.go (all passing)
+✓ Verified project builds: go build ./cmd/main.go
+✓ All 156 tests passing
+✓ Committed: Add authentication middleware with comprehensive tests
+• Pending: Integration test with database`
+
+	items := task.ParseProgress(text)
+	if len(items) != 7 {
+		t.Fatalf("expected 7 items, got %d: %+v", len(items), items)
+	}
+	if !items[0].Done || items[0].Text != "Read README.md for project structure" {
+		t.Errorf("unexpected first item: %+v", items[0])
+	}
+	if last := items[6]; last.Done || last.Note || last.Text != "Integration test with database" {
+		t.Errorf("expected a pending item without its marker, got %+v", last)
+	}
+	if done, total := task.CountProgress(items); done != 6 || total != 7 {
+		t.Errorf("expected 6/7 done, got %d/%d", done, total)
+	}
+}
+
+func TestParseProgressMixedOutput(t *testing.T) {
+	text := `I looked into the failing build.
+
+✓ Completed: Fixed the import cycle
+Done: Updated go.mod
+- [x] Ran go vet
+- [ ] Add a regression test
+• Waiting for: which database driver to use
+•
+Some closing remarks.`
+
+	want := []task.ProgressItem{
+		{Note: true, Text: "I looked into the failing build."},
+		{Done: true, Text: "Fixed the import cycle"},
+		{Done: true, Text: "Updated go.mod"},
+		{Done: true, Text: "Ran go vet"},
+		{Text: "Add a regression test"},
+		{Text: "Waiting for: which database driver to use"},
+		{Note: true, Text: "•"},
+		{Note: true, Text: "Some closing remarks."},
+	}
+	got := task.ParseProgress(text)
+	if len(got) != len(want) {
+		t.Fatalf("expected %d items, got %d: %+v", len(want), len(got), got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("item %d: expected %+v, got %+v", i, want[i], got[i])
+		}
+	}
+}
+
+func TestParseProgressEmpty(t *testing.T) {
+	if items := task.ParseProgress("\n  \n"); len(items) != 0 {
+		t.Errorf("expected no items from blank text, got %+v", items)
+	}
+}
+
+func TestFinalSummaryUsesLastReport(t *testing.T) {
+	text := `✓ Read the parser
+• Pending: Tests
+
+Now adding the tests.
+✓ Added the lexer
+• Pending: Docs
+✓ Added 3 lexer tests
+All done.`
+	if got := task.FinalSummary(text); got != "Added the lexer\nAdded 3 lexer tests" {
+		t.Errorf("expected the done items of the last report, got %q", got)
+	}
+	if got := task.FinalSummary("Just some prose."); got != "" {
+		t.Errorf("expected no summary without markers, got %q", got)
+	}
+}
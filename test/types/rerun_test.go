@@ -0,0 +1,75 @@
+package types_test
+
+import (
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"ludwig/internal/types/model"
+	"ludwig/internal/types/task"
+)
+
+func TestRerunAddsDistinctPendingTask(t *testing.T) {
+	original := &task.Task{
+		ID:             "abc123def",
+		Name:           "Add a parser\n\nKeep the public API",
+		Status:         task.Completed,
+		CreatedAt:      time.Now().Add(-time.Hour),
+		Priority:       2,
+		Tags:           []string{"backend"},
+		SubPath:        "services/api",
+		BranchName:     "ludwig/abc123def",
+		WorktreePath:   "/tmp/worktree",
+		ResponseFile:   "responses/abc123def.md",
+		WorkInProgress: "✓ Done: parser",
+		Failures:       1,
+	}
+	store := newRefStore(t, original)
+
+	out := runCommand(t, model.PalleteCommands(store), "rerun", "rerun abc123 Also handle comments")
+
+	tasks, err := store.ListTasks()
+	if err != nil || len(tasks) != 2 {
+		t.Fatalf("expected two tasks, got %d (err %v)", len(tasks), err)
+	}
+	var rerun *task.Task
+	for _, tk := range tasks {
+		if tk.ID != original.ID {
+			rerun = tk
+		}
+	}
+	if rerun == nil || !strings.Contains(out, rerun.ShortID()) {
+		t.Fatalf("expected the new task to be reported, got %q", out)
+	}
+
+	if rerun.Status != task.Pending || rerun.ClonedFrom != original.ID {
+		t.Errorf("expected a Pending task linked to the original, got %+v", rerun)
+	}
+	if rerun.Name != original.Name+"\n\nAlso handle comments" {
+		t.Errorf("expected the description plus the extra instructions, got %q", rerun.Name)
+	}
+	if rerun.Priority != 2 || !slices.Equal(rerun.Tags, original.Tags) || rerun.SubPath != original.SubPath {
+		t.Errorf("expected priority, tags and scope to be copied, got %+v", rerun)
+	}
+	if rerun.BranchName != "" || rerun.WorktreePath != "" || rerun.ResponseFile != "" || rerun.WorkInProgress != "" || rerun.Failures != 0 {
+		t.Errorf("expected no run state to be copied, got %+v", rerun)
+	}
+	if !rerun.CreatedAt.After(original.CreatedAt) {
+		t.Errorf("expected a new creation time")
+	}
+
+	kept, _ := store.GetTask(original.ID)
+	if kept.Status != task.Completed || kept.Name != original.Name || kept.BranchName != original.BranchName {
+		t.Errorf("expected the original to be left alone, got %+v", kept)
+	}
+}
+
+func TestRerunCopiesTagsIndependently(t *testing.T) {
+	original := task.Task{ID: "orig", Name: "Tagged", Tags: []string{"a"}}
+	rerun := original.Rerun("copy")
+	rerun.Tags[0] = "b"
+	if original.Tags[0] != "a" {
+		t.Error("expected the re-run's tags not to share the original's slice")
+	}
+}
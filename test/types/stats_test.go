@@ -0,0 +1,81 @@
+package types_test
+
+import (
+	"testing"
+	"time"
+
+	"ludwig/internal/types/task"
+)
+
+func TestComputeStatsNoTasks(t *testing.T) {
+	stats := task.ComputeStats(nil, time.Now())
+
+	if stats.Total != 0 {
+		t.Errorf("Expected 0 total tasks, got %d", stats.Total)
+	}
+	if stats.AverageCompletion != 0 {
+		t.Errorf("Expected zero average completion, got %v", stats.AverageCompletion)
+	}
+	if stats.SuccessRate != 0 {
+		t.Errorf("Expected zero success rate, got %v", stats.SuccessRate)
+	}
+	for _, status := range []task.Status{task.Pending, task.InProgress, task.NeedsReview, task.Completed} {
+		count, ok := stats.ByStatus[status]
+		if !ok || count != 0 {
+			t.Errorf("Expected status %v to be present with 0 tasks, got %d (present: %v)", status, count, ok)
+		}
+	}
+}
+
+func TestComputeStatsAggregates(t *testing.T) {
+	now := time.Date(2025, 6, 10, 15, 0, 0, 0, time.UTC)
+	tasks := []task.Task{
+		{ID: "1", Status: task.Pending},
+		{ID: "2", Status: task.InProgress, Failures: 1},
+		{ID: "3", Status: task.NeedsReview},
+		{
+			ID:          "4",
+			Status:      task.Completed,
+			StartedAt:   now.Add(-3 * time.Hour),
+			CompletedAt: now.Add(-1 * time.Hour),
+		},
+		{
+			ID:          "5",
+			Status:      task.Completed,
+			StartedAt:   now.Add(-50 * time.Hour),
+			CompletedAt: now.Add(-46 * time.Hour),
+			Failures:    1,
+		},
+		// Completed before stats tracking existed, so it has no timestamps
+		{ID: "6", Status: task.Completed},
+	}
+
+	stats := task.ComputeStats(tasks, now)
+
+	if stats.Total != 6 {
+		t.Errorf("Expected 6 total tasks, got %d", stats.Total)
+	}
+	expectedByStatus := map[task.Status]int{
+		task.Pending:     1,
+		task.InProgress:  1,
+		task.NeedsReview: 1,
+		task.Completed:   3,
+	}
+	for status, expected := range expectedByStatus {
+		if stats.ByStatus[status] != expected {
+			t.Errorf("Expected %d tasks with status %v, got %d", expected, status, stats.ByStatus[status])
+		}
+	}
+	if stats.AverageCompletion != 3*time.Hour {
+		t.Errorf("Expected average completion of 3h, got %v", stats.AverageCompletion)
+	}
+	if stats.CompletedToday != 1 {
+		t.Errorf("Expected 1 task completed today, got %d", stats.CompletedToday)
+	}
+	if stats.FailedRuns != 2 {
+		t.Errorf("Expected 2 failed runs, got %d", stats.FailedRuns)
+	}
+	if stats.SuccessRate != 0.6 {
+		t.Errorf("Expected success rate of 0.6, got %v", stats.SuccessRate)
+	}
+}
@@ -0,0 +1,68 @@
+package types_test
+
+import (
+	"testing"
+
+	"ludwig/internal/types/task"
+)
+
+func TestParseStatusValidNames(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected task.Status
+	}{
+		{"Pending", task.Pending},
+		{"pending", task.Pending},
+		{"To Do", task.Pending},
+		{"InProgress", task.InProgress},
+		{"in-progress", task.InProgress},
+		{"In Progress", task.InProgress},
+		{"NeedsReview", task.NeedsReview},
+		{"needs_review", task.NeedsReview},
+		{"In Review", task.NeedsReview},
+		{"Completed", task.Completed},
+		{"done", task.Completed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			status, err := task.ParseStatus(tt.input)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if status != tt.expected {
+				t.Errorf("expected %v, got %v", tt.expected, status)
+			}
+		})
+	}
+}
+
+func TestParseStatusInvalidNames(t *testing.T) {
+	for _, input := range []string{"", "started", "complete!", "42"} {
+		t.Run(input, func(t *testing.T) {
+			if _, err := task.ParseStatus(input); err == nil {
+				t.Errorf("expected error for %q", input)
+			}
+		})
+	}
+}
+
+func TestMoveToRecordsHistory(t *testing.T) {
+	tk := &task.Task{ID: "move-1", Status: task.InProgress}
+
+	tk.MoveTo(task.Completed, "Moved manually")
+
+	if tk.Status != task.Completed {
+		t.Errorf("expected status Completed, got %v", tk.Status)
+	}
+	if tk.CompletedAt.IsZero() {
+		t.Errorf("expected CompletedAt to be set when moved to Completed")
+	}
+	if len(tk.History) != 1 {
+		t.Fatalf("expected 1 history entry, got %d", len(tk.History))
+	}
+	entry := tk.History[0]
+	if entry.From != task.InProgress || entry.To != task.Completed || entry.Note != "Moved manually" {
+		t.Errorf("unexpected history entry: %+v", entry)
+	}
+}
@@ -0,0 +1,38 @@
+package types_test
+
+import (
+	"testing"
+
+	"ludwig/internal/types/task"
+)
+
+func TestCleanSubPath(t *testing.T) {
+	valid := map[string]string{
+		"":                  "",
+		".":                 "",
+		"services/api/":     "services/api",
+		"./services//api":   "services/api",
+		"services/../tools": "tools",
+	}
+	for in, want := range valid {
+		if got, err := task.CleanSubPath(in); err != nil || got != want {
+			t.Errorf("CleanSubPath(%q) = %q, %v; want %q", in, got, err, want)
+		}
+	}
+
+	for _, in := range []string{"/etc", "..", "../other-repo", "services/../../x"} {
+		if _, err := task.CleanSubPath(in); err == nil {
+			t.Errorf("expected CleanSubPath(%q) to be rejected", in)
+		}
+	}
+}
+
+func TestWorkDirJoinsSubPath(t *testing.T) {
+	tk := task.Task{WorktreePath: "/repo/.worktrees/t1", SubPath: "services/api"}
+	if got := tk.WorkDir(); got != "/repo/.worktrees/t1/services/api" {
+		t.Errorf("expected the sub path inside the worktree, got %s", got)
+	}
+	if got := (task.Task{SubPath: "services/api"}).WorkDir(); got != "" {
+		t.Errorf("expected no work dir before a worktree exists, got %s", got)
+	}
+}
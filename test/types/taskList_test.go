@@ -0,0 +1,72 @@
+package types_test
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"ludwig/internal/types/task"
+)
+
+func TestParseTaskListLines(t *testing.T) {
+	input := `# Tasks for the release
+Write the changelog
+
+// JSON lines can carry more than a name
+{"name": "Fix the login bug", "tags": ["backend"], "priority": 2, "instructions": "Keep the session API"}
+{"name": "Broken", "tags": [
+   Bump the version
+{"tags": ["docs"]}
+`
+	specs, errs := task.ParseTaskList(strings.NewReader(input))
+
+	if len(specs) != 3 {
+		t.Fatalf("expected 3 tasks, got %d: %+v", len(specs), specs)
+	}
+	if specs[0].Name != "Write the changelog" || specs[2].Name != "Bump the version" {
+		t.Errorf("expected plain lines as names, got %q and %q", specs[0].Name, specs[2].Name)
+	}
+	if specs[1].Name != "Fix the login bug" || specs[1].Priority != 2 || !slices.Equal(specs[1].Tags, []string{"backend"}) {
+		t.Errorf("expected the JSON line's fields, got %+v", specs[1])
+	}
+
+	if len(errs) != 2 {
+		t.Fatalf("expected the malformed and nameless lines to be reported, got %v", errs)
+	}
+	if !strings.Contains(errs[0].Error(), "line 6") || !strings.Contains(errs[1].Error(), "line 8: name is required") {
+		t.Errorf("expected errors to name their lines, got %v", errs)
+	}
+}
+
+func TestParseTaskListJSON(t *testing.T) {
+	input := `[
+		{"name": "Add dark mode", "tags": ["ui"], "model": "gemini-2.5-pro"},
+		{"name": "  "},
+		{"name": "Update the docs"}
+	]`
+	specs, errs := task.ParseTaskList(strings.NewReader(input))
+
+	if len(specs) != 2 || specs[0].Model != "gemini-2.5-pro" || specs[1].Name != "Update the docs" {
+		t.Errorf("expected the two named tasks, got %+v", specs)
+	}
+	if len(errs) != 1 || !strings.Contains(errs[0].Error(), "item 2") {
+		t.Errorf("expected the nameless item to be reported, got %v", errs)
+	}
+
+	if specs, errs := task.ParseTaskList(strings.NewReader(`[{"name": "Cut off"`)); len(specs) != 0 || len(errs) != 1 {
+		t.Errorf("expected a malformed list to be rejected whole, got %+v and %v", specs, errs)
+	}
+}
+
+func TestSpecNewTask(t *testing.T) {
+	spec := task.Spec{Name: "Fix the login bug", Instructions: "Keep the session API", Tags: []string{"backend"}, Priority: 2}
+
+	got := spec.NewTask("new-id")
+
+	if got.ID != "new-id" || got.Status != task.Pending || got.Priority != 2 || got.CreatedAt.IsZero() {
+		t.Errorf("expected a Pending task with the spec's fields, got %+v", got)
+	}
+	if got.Name != "Fix the login bug\n\nKeep the session API" || got.Title() != "Fix the login bug" {
+		t.Errorf("expected the instructions below the name, got %q", got.Name)
+	}
+}
@@ -0,0 +1,189 @@
+package types_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"ludwig/internal/storage"
+	"ludwig/internal/types/model"
+	"ludwig/internal/types/task"
+)
+
+func newRefStore(t *testing.T, tasks ...*task.Task) *storage.FileTaskStorage {
+	t.Helper()
+	t.Chdir(t.TempDir())
+
+	store, err := storage.NewFileTaskStorage()
+	if err != nil {
+		t.Fatalf("failed to create storage: %v", err)
+	}
+	for _, tk := range tasks {
+		if err := store.AddTask(tk); err != nil {
+			t.Fatalf("failed to add task: %v", err)
+		}
+	}
+	return store
+}
+
+func TestShortID(t *testing.T) {
+	tk := task.Task{ID: "3f2a9c1e-8b7d-4c6e-9a1b-2d3c4e5f6a7b"}
+	if got := tk.ShortID(); got != "3f2a9c" {
+		t.Errorf("expected short ID 3f2a9c, got %q", got)
+	}
+
+	short := task.Task{ID: "abc"}
+	if got := short.ShortID(); got != "abc" {
+		t.Errorf("expected IDs shorter than the short ID length to be returned whole, got %q", got)
+	}
+}
+
+func TestShortIDStaysValidAfterDeletes(t *testing.T) {
+	store := newRefStore(t,
+		&task.Task{ID: "aaaaaa-1", Name: "First"},
+		&task.Task{ID: "bbbbbb-2", Name: "Second"},
+		&task.Task{ID: "cccccc-3", Name: "Third"},
+	)
+
+	before, err := model.ResolveTaskRef(store, "cccccc", storage.ListOptions{})
+	if err != nil {
+		t.Fatalf("failed to resolve short ID: %v", err)
+	}
+
+	for _, id := range []string{"aaaaaa-1", "bbbbbb-2"} {
+		if err := store.DeleteTask(id); err != nil {
+			t.Fatalf("failed to delete task: %v", err)
+		}
+	}
+
+	after, err := model.ResolveTaskRef(store, "cccccc", storage.ListOptions{})
+	if err != nil {
+		t.Fatalf("expected short ID to resolve after deletes: %v", err)
+	}
+	if after.ID != before.ID {
+		t.Errorf("expected short ID to keep pointing at %s, got %s", before.ID, after.ID)
+	}
+}
+
+func TestResolveTaskRefByIndexAndErrors(t *testing.T) {
+	store := newRefStore(t, &task.Task{ID: "only-task", Name: "Only"})
+
+	resolved, err := model.ResolveTaskRef(store, "0", storage.ListOptions{})
+	if err != nil || resolved.ID != "only-task" {
+		t.Errorf("expected index 0 to resolve to only-task, got %v (err %v)", resolved, err)
+	}
+
+	if _, err := model.ResolveTaskRef(store, "5", storage.ListOptions{}); err == nil || !strings.Contains(err.Error(), "out of range") {
+		t.Errorf("expected an out of range error for index 5, got %v", err)
+	}
+	if _, err := model.ResolveTaskRef(store, "zzzzzz", storage.ListOptions{}); err == nil {
+		t.Errorf("expected an error for an unknown short ID")
+	}
+}
+
+func TestResolveTaskRefIndexFollowsListOrder(t *testing.T) {
+	now := time.Now()
+	store := newRefStore(t,
+		&task.Task{ID: "bbbbbb-2", Name: "Newer", CreatedAt: now},
+		&task.Task{ID: "aaaaaa-1", Name: "Older", CreatedAt: now.Add(-time.Hour)},
+	)
+
+	resolved, err := model.ResolveTaskRef(store, "1", storage.ListOptions{})
+	if err != nil || resolved.ID != "bbbbbb-2" {
+		t.Errorf("expected index 1 to be the second task listed, got %v (err %v)", resolved, err)
+	}
+}
+
+func TestResolveTaskRefShortIDBeatsIndex(t *testing.T) {
+	now := time.Now()
+	store := newRefStore(t,
+		&task.Task{ID: "first-task", Name: "First", CreatedAt: now.Add(-time.Hour)},
+		&task.Task{ID: "000000-numeric", Name: "Numeric", CreatedAt: now},
+	)
+
+	// "000000" parses as index 0, but it's also the second task's short ID
+	resolved, err := model.ResolveTaskRef(store, "000000", storage.ListOptions{})
+	if err != nil || resolved.ID != "000000-numeric" {
+		t.Errorf("expected the short ID to win over the index, got %v (err %v)", resolved, err)
+	}
+}
+
+func TestResolveTaskRefArchived(t *testing.T) {
+	store := newRefStore(t, &task.Task{ID: "archived-1", Name: "Old", Archived: true})
+
+	if _, err := model.ResolveTaskRef(store, "archiv", storage.ListOptions{}); err == nil {
+		t.Errorf("expected archived task not to resolve from the board")
+	}
+	resolved, err := model.ResolveTaskRef(store, "archiv", storage.ListOptions{Archived: storage.OnlyArchived})
+	if err != nil || resolved.ID != "archived-1" {
+		t.Errorf("expected archived task to resolve from the archive, got %v (err %v)", resolved, err)
+	}
+}
+
+func TestResolveTaskRefByPrefix(t *testing.T) {
+	store := newRefStore(t,
+		&task.Task{ID: "1a2b3c4d", Name: "Refactor parser"},
+		&task.Task{ID: "9f8e7d6c", Name: "Rename config keys"},
+		&task.Task{ID: "5e5e5e5e", Name: "Write docs"},
+	)
+
+	tests := []struct {
+		name     string
+		ref      string
+		expected string
+	}{
+		{"full ID", "9f8e7d6c", "9f8e7d6c"},
+		{"short ID", "1a2b3c", "1a2b3c4d"},
+		{"ID prefix", "5e5e", "5e5e5e5e"},
+		{"name prefix", "refac", "1a2b3c4d"},
+		{"name prefix is case-insensitive", "WRITE", "5e5e5e5e"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resolved, err := model.ResolveTaskRef(store, tt.ref, storage.ListOptions{})
+			if err != nil {
+				t.Fatalf("failed to resolve %q: %v", tt.ref, err)
+			}
+			if resolved.ID != tt.expected {
+				t.Errorf("expected %q to resolve to %s, got %s", tt.ref, tt.expected, resolved.ID)
+			}
+		})
+	}
+}
+
+func TestResolveTaskRefAmbiguousPrefix(t *testing.T) {
+	store := newRefStore(t,
+		&task.Task{ID: "1a2b3c4d", Name: "Refactor parser"},
+		&task.Task{ID: "9f8e7d6c", Name: "Rename config keys"},
+	)
+
+	_, err := model.ResolveTaskRef(store, "re", storage.ListOptions{})
+	if err == nil {
+		t.Fatalf("expected an error for a prefix matching two tasks")
+	}
+	if !strings.Contains(err.Error(), "ambiguous") {
+		t.Errorf("expected an ambiguous reference error, got %v", err)
+	}
+}
+
+func TestListShowsShortIDsOldestFirst(t *testing.T) {
+	now := time.Now()
+	store := newRefStore(t,
+		&task.Task{ID: "bbbbbb-2", Name: "Newer", CreatedAt: now},
+		&task.Task{ID: "aaaaaa-1", Name: "Older", CreatedAt: now.Add(-time.Hour)},
+		&task.Task{ID: "cccccc-3", Name: "Newest", CreatedAt: now.Add(time.Hour)},
+	)
+	list := findCommand(t, model.PalleteCommands(store), "list")
+
+	out := list.Action("list", &model.Model{})
+	older, newer, newest := strings.Index(out, "aaaaaa"), strings.Index(out, "bbbbbb"), strings.Index(out, "cccccc")
+	if older < 0 || !(older < newer && newer < newest) {
+		t.Errorf("expected the tasks by short ID, oldest first, got %q", out)
+	}
+	if !strings.Contains(out, "0") || !strings.Contains(out, "#") {
+		t.Errorf("expected the list to number tasks for index refs, got %q", out)
+	}
+	if again := list.Action("list", &model.Model{}); again != out {
+		t.Errorf("expected the same list each time, got %q then %q", out, again)
+	}
+}
@@ -0,0 +1,100 @@
+package types_test
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"ludwig/internal/config"
+	"ludwig/internal/types/model"
+	"ludwig/internal/types/task"
+)
+
+// addedTask returns the one task in the store that isn't in before
+func addedTask(t *testing.T, tasks []*task.Task, before ...string) *task.Task {
+	t.Helper()
+	var added []*task.Task
+	for _, tk := range tasks {
+		if !slices.Contains(before, tk.ID) {
+			added = append(added, tk)
+		}
+	}
+	if len(added) != 1 {
+		t.Fatalf("expected one new task, got %d", len(added))
+	}
+	return added[0]
+}
+
+func TestTemplateSaveCapturesTask(t *testing.T) {
+	store := newRefStore(t, &task.Task{
+		ID:       "abc123def",
+		Name:     "Fix the login bug\n\nAdd a regression test",
+		Status:   task.Completed,
+		Priority: 3,
+		Tags:     []string{"bug", "auth"},
+	})
+	commands := model.PalleteCommands(store)
+
+	out := runCommand(t, commands, "template", "template save bugfix abc123")
+	if !strings.Contains(out, "Saved template bugfix") {
+		t.Fatalf("expected the template to be saved, got %q", out)
+	}
+	cfg, err := config.LoadConfig()
+	if err != nil || cfg == nil {
+		t.Fatalf("failed to load config: %v", err)
+	}
+	saved, ok := cfg.TaskTemplates["bugfix"]
+	if !ok {
+		t.Fatalf("expected a bugfix template in the config, got %+v", cfg.TaskTemplates)
+	}
+	if saved.Description != "Fix the login bug\n\nAdd a regression test" || saved.Priority != 3 || !slices.Equal(saved.Tags, []string{"bug", "auth"}) {
+		t.Errorf("expected the task's description, tags and priority, got %+v", saved)
+	}
+
+	// Without a placeholder the text goes below the description
+	runCommand(t, commands, "template", "template use bugfix Only on Safari")
+	tasks, _ := store.ListTasks()
+	added := addedTask(t, tasks, "abc123def")
+	if added.Name != "Fix the login bug\n\nAdd a regression test\n\nOnly on Safari" {
+		t.Errorf("expected the extra text below the description, got %q", added.Name)
+	}
+
+	if out := runCommand(t, commands, "template", "template save bugfix nothing"); !strings.Contains(out, "Invalid task ref") {
+		t.Errorf("expected an unknown ref to be refused, got %q", out)
+	}
+}
+
+func TestTemplateUseFillsPlaceholder(t *testing.T) {
+	store := newRefStore(t)
+	err := config.SaveConfig(&config.Config{TaskTemplates: map[string]config.TaskTemplate{
+		"endpoint": {
+			Description: "Add a {{.Input}} endpoint\n\nDocument {{.Input}} in the readme",
+			Tags:        []string{"api"},
+			Priority:    2,
+		},
+	}})
+	if err != nil {
+		t.Fatalf("failed to save config: %v", err)
+	}
+	commands := model.PalleteCommands(store)
+
+	out := runCommand(t, commands, "template", "template use endpoint /users")
+	tasks, _ := store.ListTasks()
+	added := addedTask(t, tasks)
+	if !strings.Contains(out, added.ShortID()) {
+		t.Errorf("expected the new task's ref in %q", out)
+	}
+	if added.Name != "Add a /users endpoint\n\nDocument /users in the readme" {
+		t.Errorf("expected the placeholder to be filled in, got %q", added.Name)
+	}
+	if added.Status != task.Pending || added.Priority != 2 || !slices.Equal(added.Tags, []string{"api"}) {
+		t.Errorf("expected a Pending task with the template's tags and priority, got %+v", added)
+	}
+
+	if out := runCommand(t, commands, "template", "template use missing x"); !strings.Contains(out, "No task template named missing") {
+		t.Errorf("expected an unknown template to be refused, got %q", out)
+	}
+	if out := runCommand(t, commands, "template", "template list"); !strings.Contains(out, "endpoint: Add a {{.Input}} endpoint") {
+		t.Errorf("expected the template to be listed, got %q", out)
+	}
+}
@@ -0,0 +1,54 @@
+package types_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"ludwig/internal/config"
+	"ludwig/internal/storage"
+	"ludwig/internal/types/model"
+)
+
+// Run with -race: the update check must report back through Update rather than
+// writing to the Model while Update and View are running
+func TestUpdateCheckReportsThroughUpdate(t *testing.T) {
+	t.Chdir(t.TempDir())
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"tag_name": "v9.0.0"}`)
+	}))
+	defer server.Close()
+	if err := config.SaveConfig(&config.Config{UpdateAPIURL: server.URL}); err != nil {
+		t.Fatalf("failed to save config: %v", err)
+	}
+	store, err := storage.NewFileTaskStorage()
+	if err != nil {
+		t.Fatalf("failed to create storage: %v", err)
+	}
+	m := model.NewModel(store, "1.0.0")
+
+	// Bubbletea runs commands on their own goroutines while the loop carries on
+	check := m.CheckForUpdate()
+	result := make(chan tea.Msg, 1)
+	go func() { result <- check() }()
+	for i := 0; i < 20; i++ {
+		m.Update(tea.WindowSizeMsg{Width: 80 + i, Height: 24})
+		m.View()
+	}
+	msg := <-result
+	if msg == nil {
+		t.Fatal("expected the check to find the newer release")
+	}
+	if m.Message() != "" {
+		t.Errorf("expected nothing to change before the result is handled, got %q", m.Message())
+	}
+
+	m.Update(msg)
+	if !strings.Contains(m.Message(), "Update available: 1.0.0 → v9.0.0") {
+		t.Errorf("expected the update to be announced, got %q", m.Message())
+	}
+}
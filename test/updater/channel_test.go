@@ -0,0 +1,44 @@
+package updater_test
+
+import (
+	"testing"
+
+	"ludwig/internal/updater"
+)
+
+// releases is newest first, as GitHub lists them
+var releases = []updater.Release{
+	{TagName: "v2.0.0", Draft: true},
+	{TagName: "v1.3.0-beta.2", Prerelease: true},
+	{TagName: "v1.3.0-beta.10", Prerelease: true},
+	{TagName: "v1.2.1"},
+	{TagName: "v1.2.0"},
+}
+
+func TestBetaChannelSelectsPrerelease(t *testing.T) {
+	release, ok := updater.SelectRelease(releases, updater.CHANNEL_BETA)
+	if !ok || release.TagName != "v1.3.0-beta.10" {
+		t.Errorf("expected beta to pick the newest pre-release, got %q", release.TagName)
+	}
+}
+
+func TestStableChannelIgnoresPrerelease(t *testing.T) {
+	release, ok := updater.SelectRelease(releases, updater.CHANNEL_STABLE)
+	if !ok || release.TagName != "v1.2.1" {
+		t.Errorf("expected stable to pick the newest full release, got %q", release.TagName)
+	}
+}
+
+func TestBetaChannelPrefersFinalRelease(t *testing.T) {
+	withFinal := append([]updater.Release{{TagName: "v1.3.0"}}, releases...)
+	release, _ := updater.SelectRelease(withFinal, updater.CHANNEL_BETA)
+	if release.TagName != "v1.3.0" {
+		t.Errorf("expected the final release to beat its pre-releases, got %q", release.TagName)
+	}
+}
+
+func TestSelectReleaseWithNoCandidates(t *testing.T) {
+	if _, ok := updater.SelectRelease([]updater.Release{{TagName: "v1.3.0-rc.1", Prerelease: true}}, updater.CHANNEL_STABLE); ok {
+		t.Error("expected no stable release to be found")
+	}
+}
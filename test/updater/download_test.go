@@ -0,0 +1,76 @@
+package updater_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"ludwig/internal/config"
+	"ludwig/internal/updater"
+)
+
+func TestDownloadFileWithinLimit(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("archive"))
+	}))
+	defer server.Close()
+
+	path, err := updater.DownloadFile(server.URL, 64)
+	if err != nil {
+		t.Fatalf("expected the download to succeed, got %v", err)
+	}
+	defer os.Remove(path)
+	if content, _ := os.ReadFile(path); string(content) != "archive" {
+		t.Errorf("expected the archive to be saved, got %q", content)
+	}
+}
+
+func TestDownloadFileRefusesOversizedBody(t *testing.T) {
+	body := strings.Repeat("x", 1024)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Streamed without a Content-Length, so only the read limit can catch it
+		w.(http.Flusher).Flush()
+		w.Write([]byte(body))
+	}))
+	defer server.Close()
+
+	if path, err := updater.DownloadFile(server.URL, 100); err == nil {
+		os.Remove(path)
+		t.Error("expected a download over the limit to be refused")
+	}
+}
+
+func TestDownloadFileRefusesLargeContentLength(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Length", "1000")
+		w.Write([]byte(strings.Repeat("x", 1000)))
+	}))
+	defer server.Close()
+
+	_, err := updater.DownloadFile(server.URL, 100)
+	if err == nil || !strings.Contains(err.Error(), "1000 bytes") {
+		t.Errorf("expected the declared size to be refused, got %v", err)
+	}
+}
+
+func TestDownloadFileChecksStatus(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "not found", http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	if _, err := updater.DownloadFile(server.URL, 0); err == nil || !strings.Contains(err.Error(), "404") {
+		t.Errorf("expected a 404 to fail the download, got %v", err)
+	}
+}
+
+func TestSourceDownloadLimit(t *testing.T) {
+	if source, _ := updater.SourceFor(nil); source.MaxDownloadBytes != updater.DEFAULT_MAX_DOWNLOAD_BYTES {
+		t.Errorf("expected the default limit, got %d", source.MaxDownloadBytes)
+	}
+	if source, _ := updater.SourceFor(&config.Config{MaxUpdateBytes: -1}); source.MaxDownloadBytes != 0 {
+		t.Errorf("expected a negative limit to mean none, got %d", source.MaxDownloadBytes)
+	}
+}
@@ -0,0 +1,114 @@
+package updater_test
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"compress/gzip"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"ludwig/internal/updater"
+)
+
+// member is a file to put in a crafted release archive
+type member struct {
+	Name, Content string
+}
+
+// binaryName is the name the updater looks for on this platform
+func binaryName() string {
+	if runtime.GOOS == "windows" {
+		return "ludwig.exe"
+	}
+	return "ludwig"
+}
+
+func writeTarGz(t *testing.T, members ...member) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "release.tar.gz")
+	file, err := os.Create(path)
+	if err != nil {
+		t.Fatalf("failed to create archive: %v", err)
+	}
+	defer file.Close()
+	gw := gzip.NewWriter(file)
+	tw := tar.NewWriter(gw)
+	for _, m := range members {
+		header := &tar.Header{Name: m.Name, Mode: 0755, Size: int64(len(m.Content)), Typeflag: tar.TypeReg}
+		if err := tw.WriteHeader(header); err != nil {
+			t.Fatalf("failed to write header: %v", err)
+		}
+		tw.Write([]byte(m.Content))
+	}
+	tw.Close()
+	gw.Close()
+	return path
+}
+
+func writeZip(t *testing.T, members ...member) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "release.zip")
+	file, err := os.Create(path)
+	if err != nil {
+		t.Fatalf("failed to create archive: %v", err)
+	}
+	defer file.Close()
+	zw := zip.NewWriter(file)
+	for _, m := range members {
+		w, err := zw.Create(m.Name)
+		if err != nil {
+			t.Fatalf("failed to add %s: %v", m.Name, err)
+		}
+		w.Write([]byte(m.Content))
+	}
+	zw.Close()
+	return path
+}
+
+func readExtracted(t *testing.T, extract func(string) (string, error), archive string) string {
+	t.Helper()
+	path, err := extract(archive)
+	if err != nil {
+		t.Fatalf("expected the binary to be extracted, got %v", err)
+	}
+	defer os.Remove(path)
+	content, _ := os.ReadFile(path)
+	return string(content)
+}
+
+func TestExtractMatchesBinaryNameExactly(t *testing.T) {
+	members := []member{
+		{"README.md", "docs"},
+		{"ludwig-helper", "not the binary"},
+		{"ludwig_v1.4.0/" + binaryName(), "the binary"},
+	}
+	if got := readExtracted(t, updater.ExtractTarGz, writeTarGz(t, members...)); got != "the binary" {
+		t.Errorf("expected the exact binary from the tarball, got %q", got)
+	}
+	if got := readExtracted(t, updater.ExtractZip, writeZip(t, members...)); got != "the binary" {
+		t.Errorf("expected the exact binary from the zip, got %q", got)
+	}
+}
+
+func TestExtractRejectsPathTraversal(t *testing.T) {
+	for _, name := range []string{"../../tmp/" + binaryName(), "/usr/local/bin/" + binaryName(), `..\..\` + binaryName()} {
+		// The unsafe entry comes after a valid binary, so it must not stop at the first match
+		members := []member{{binaryName(), "the binary"}, {name, "evil"}}
+		if path, err := updater.ExtractTarGz(writeTarGz(t, members...)); err == nil {
+			os.Remove(path)
+			t.Errorf("expected tarball entry %q to be rejected", name)
+		}
+		if path, err := updater.ExtractZip(writeZip(t, members...)); err == nil {
+			os.Remove(path)
+			t.Errorf("expected zip entry %q to be rejected", name)
+		}
+	}
+}
+
+func TestExtractWithoutBinary(t *testing.T) {
+	if _, err := updater.ExtractTarGz(writeTarGz(t, member{"ludwig.txt", "notes"})); err == nil {
+		t.Error("expected an archive without the binary to fail")
+	}
+}
@@ -0,0 +1,89 @@
+package updater_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"ludwig/internal/updater"
+)
+
+func TestFetcherTimesOutSlowServer(t *testing.T) {
+	var attempts atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts.Add(1)
+		select {
+		case <-r.Context().Done():
+		case <-time.After(5 * time.Second):
+		}
+	}))
+	defer server.Close()
+
+	fetcher := updater.NewFetcher(50 * time.Millisecond)
+	fetcher.MaxRetries = 1
+	fetcher.Sleep = func(time.Duration) {}
+
+	start := time.Now()
+	resp, err := fetcher.Get(server.URL)
+	if err == nil {
+		resp.Body.Close()
+		t.Fatal("expected the request to time out")
+	}
+	if elapsed := time.Since(start); elapsed > 2*time.Second {
+		t.Errorf("expected the timeout to cut the request short, took %v", elapsed)
+	}
+	if got := attempts.Load(); got != 2 {
+		t.Errorf("expected one retry after the timeout, got %d attempts", got)
+	}
+}
+
+func TestFetcherRetriesServerErrors(t *testing.T) {
+	var attempts atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if attempts.Add(1) < 3 {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		w.Write([]byte("ok"))
+	}))
+	defer server.Close()
+
+	var delays []time.Duration
+	fetcher := updater.NewFetcher(time.Second)
+	fetcher.BaseDelay = 10 * time.Millisecond
+	fetcher.Sleep = func(d time.Duration) { delays = append(delays, d) }
+
+	resp, err := fetcher.Get(server.URL)
+	if err != nil {
+		t.Fatalf("expected the retries to succeed, got %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("expected 200, got %d", resp.StatusCode)
+	}
+	if len(delays) != 2 || delays[1] != 2*delays[0] {
+		t.Errorf("expected two doubling delays, got %v", delays)
+	}
+}
+
+func TestFetcherDoesNotRetryNotFound(t *testing.T) {
+	var attempts atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts.Add(1)
+		http.NotFound(w, r)
+	}))
+	defer server.Close()
+
+	fetcher := updater.NewFetcher(time.Second)
+	fetcher.Sleep = func(time.Duration) {}
+	resp, err := fetcher.Get(server.URL)
+	if err != nil {
+		t.Fatalf("expected the 404 to be returned, got %v", err)
+	}
+	resp.Body.Close()
+	if got := attempts.Load(); got != 1 {
+		t.Errorf("expected a single attempt, got %d", got)
+	}
+}
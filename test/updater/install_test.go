@@ -0,0 +1,133 @@
+package updater_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"ludwig/internal/updater"
+)
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.WriteFile(path, []byte(content), 0755); err != nil {
+		t.Fatalf("failed to write %s: %v", path, err)
+	}
+}
+
+func TestFileHash(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "binary")
+	writeFile(t, path, "hello\n")
+
+	got, err := updater.FileHash(path)
+	if err != nil {
+		t.Fatalf("failed to hash file: %v", err)
+	}
+	if want := "5891b5b522d5df086d0ff0b110fbd9d21bb4fc7163af34d08286a2e846f6be03"; got != want {
+		t.Errorf("expected sha256 %s, got %s", want, got)
+	}
+}
+
+func TestReplaceBinaryInstallsNewVersion(t *testing.T) {
+	dir := t.TempDir()
+	exePath := filepath.Join(dir, "ludwig")
+	newBinary := filepath.Join(t.TempDir(), "ludwig-download")
+	writeFile(t, exePath, "old version")
+	writeFile(t, newBinary, "new version")
+
+	if err := updater.ReplaceBinary(newBinary, exePath); err != nil {
+		t.Fatalf("expected the update to install, got %v", err)
+	}
+	content, _ := os.ReadFile(exePath)
+	if string(content) != "new version" {
+		t.Errorf("expected the binary to be replaced, got %q", content)
+	}
+	info, err := os.Stat(exePath)
+	if err != nil || info.Mode().Perm()&0100 == 0 {
+		t.Errorf("expected the new binary to be executable, got %v (%v)", info.Mode(), err)
+	}
+	if _, err := os.Stat(exePath + ".new"); !os.IsNotExist(err) {
+		t.Errorf("expected no staged binary to be left behind, got %v", err)
+	}
+}
+
+func TestVerifyInstallDetectsOldBinary(t *testing.T) {
+	dir := t.TempDir()
+	exePath := filepath.Join(dir, "ludwig")
+	newBinary := filepath.Join(dir, "ludwig-download")
+	writeFile(t, exePath, "old version")
+	writeFile(t, newBinary, "new version")
+
+	want, err := updater.FileHash(newBinary)
+	if err != nil {
+		t.Fatalf("failed to hash file: %v", err)
+	}
+	err = updater.VerifyInstall(exePath, want)
+	if err == nil || !strings.Contains(err.Error(), "did not replace") {
+		t.Errorf("expected a mismatch to be reported, got %v", err)
+	}
+	if err := updater.VerifyInstall(filepath.Join(dir, "missing"), want); err == nil {
+		t.Error("expected a missing binary to fail verification")
+	}
+}
+
+// fakeBinary writes a shell script standing in for a downloaded ludwig binary
+func fakeBinary(t *testing.T, script string) string {
+	t.Helper()
+	if runtime.GOOS == "windows" {
+		t.Skip("fake binaries are shell scripts")
+	}
+	path := filepath.Join(t.TempDir(), "ludwig-download")
+	writeFile(t, path, "#!/bin/sh\n"+script+"\n")
+	return path
+}
+
+func TestSmokeTestAcceptsWorkingBinary(t *testing.T) {
+	binary := fakeBinary(t, `echo "ludwig version $1"`)
+	if err := os.Chmod(binary, 0600); err != nil {
+		t.Fatalf("failed to chmod: %v", err)
+	}
+	// Downloads aren't executable, so the smoke test has to make them so
+	if err := updater.SmokeTest(binary); err != nil {
+		t.Errorf("expected a working binary to pass, got %v", err)
+	}
+}
+
+func TestSmokeTestRejectsBrokenBinary(t *testing.T) {
+	if err := updater.SmokeTest(fakeBinary(t, "exit 1")); err == nil {
+		t.Error("expected a binary that exits with an error to fail")
+	}
+	if err := updater.SmokeTest(fakeBinary(t, "echo segmentation fault")); err == nil {
+		t.Error("expected a binary that doesn't report a version to fail")
+	}
+}
+
+func TestReplaceBinaryKeepsBackupForRestore(t *testing.T) {
+	dir := t.TempDir()
+	exePath := filepath.Join(dir, "ludwig")
+	newBinary := filepath.Join(dir, "ludwig-download")
+	writeFile(t, exePath, "old version")
+	writeFile(t, newBinary, "broken version")
+
+	if err := updater.ReplaceBinary(newBinary, exePath); err != nil {
+		t.Fatalf("failed to replace binary: %v", err)
+	}
+	backup, _ := os.ReadFile(exePath + ".prev")
+	if string(backup) != "old version" {
+		t.Fatalf("expected the old binary to be backed up, got %q", backup)
+	}
+
+	if err := updater.RestoreBinary(exePath); err != nil {
+		t.Fatalf("failed to restore binary: %v", err)
+	}
+	content, _ := os.ReadFile(exePath)
+	if string(content) != "old version" {
+		t.Errorf("expected the old binary to be restored, got %q", content)
+	}
+	if err := updater.RestoreBinary(exePath); !errors.Is(err, updater.ErrNoBackup) {
+		t.Errorf("expected ErrNoBackup once the backup is used, got %v", err)
+	}
+}
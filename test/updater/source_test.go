@@ -0,0 +1,68 @@
+package updater_test
+
+import (
+	"testing"
+
+	"ludwig/internal/config"
+	"ludwig/internal/updater"
+)
+
+func TestSourceDefaultsToLudwigReleases(t *testing.T) {
+	source, err := updater.SourceFor(nil)
+	if err != nil {
+		t.Fatalf("expected the defaults to be valid, got %v", err)
+	}
+	if want := "https://api.github.com/repos/AlexanderHeffernan/Ludwig-AI/releases"; source.ReleasesURL != want {
+		t.Errorf("expected %s, got %s", want, source.ReleasesURL)
+	}
+	if source.Channel != updater.CHANNEL_STABLE {
+		t.Errorf("expected the stable channel by default, got %q", source.Channel)
+	}
+}
+
+func TestSourceUsesConfiguredRepo(t *testing.T) {
+	source, err := updater.SourceFor(&config.Config{
+		UpdateOwner:  "acme",
+		UpdateRepo:   "ludwig-fork",
+		UpdateAPIURL: "https://github.acme.com/api/v3/",
+	})
+	if err != nil {
+		t.Fatalf("expected a valid source, got %v", err)
+	}
+	if want := "https://github.acme.com/api/v3/repos/acme/ludwig-fork/releases"; source.ReleasesURL != want {
+		t.Errorf("expected %s, got %s", want, source.ReleasesURL)
+	}
+}
+
+func TestSourceRejectsInvalidConfig(t *testing.T) {
+	for _, cfg := range []*config.Config{
+		{UpdateAPIURL: "github.acme.com/api/v3"},
+		{UpdateAPIURL: "ftp://github.acme.com"},
+		{UpdateAPIURL: "https://"},
+		{UpdateOwner: "acme/other"},
+	} {
+		if _, err := updater.SourceFor(cfg); err == nil {
+			t.Errorf("expected %+v to be rejected", cfg)
+		}
+	}
+}
+
+func TestFindAssetInForkRelease(t *testing.T) {
+	assets := []updater.Asset{
+		{Name: "checksums.txt"},
+		{Name: "ludwig_v1.4.0_Darwin_arm64.tar.gz", DownloadURL: "https://github.acme.com/darwin"},
+		{Name: "ludwig_v1.4.0_Linux_x86_64.tar.gz", DownloadURL: "https://github.acme.com/linux"},
+		{Name: "ludwig_v1.4.0_Windows_x86_64.zip", DownloadURL: "https://github.acme.com/windows"},
+	}
+	asset, ok := updater.FindAsset(assets, "linux", "amd64")
+	if !ok || asset.DownloadURL != "https://github.acme.com/linux" {
+		t.Errorf("expected the linux archive, got %+v", asset)
+	}
+	asset, ok = updater.FindAsset(assets, "windows", "amd64")
+	if !ok || asset.DownloadURL != "https://github.acme.com/windows" {
+		t.Errorf("expected the windows archive, got %+v", asset)
+	}
+	if _, ok := updater.FindAsset(assets, "linux", "arm64"); ok {
+		t.Error("expected no archive for linux/arm64")
+	}
+}
@@ -0,0 +1,63 @@
+package utils_test
+
+import (
+	"errors"
+	"slices"
+	"testing"
+
+	"ludwig/internal/utils"
+)
+
+func fakeEnv(vars map[string]string) func(string) string {
+	return func(name string) string { return vars[name] }
+}
+
+func fakeLookPath(installed ...string) func(string) (string, error) {
+	return func(name string) (string, error) {
+		if slices.Contains(installed, name) {
+			return "/usr/bin/" + name, nil
+		}
+		return "", errors.New("not found")
+	}
+}
+
+func TestResolveEditorPrefersVisualThenEditor(t *testing.T) {
+	dir := "/repo/.worktrees/task-1"
+
+	launch, err := utils.ResolveEditor(dir, fakeEnv(map[string]string{"VISUAL": "code --new-window", "EDITOR": "vim"}), fakeLookPath())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := []string{"code", "--new-window", dir}; !slices.Equal(launch.Args, want) {
+		t.Errorf("expected args %v, got %v", want, launch.Args)
+	}
+	if launch.Dir != dir || launch.Terminal {
+		t.Errorf("expected a detached launch in %s, got %+v", dir, launch)
+	}
+
+	launch, _ = utils.ResolveEditor(dir, fakeEnv(map[string]string{"EDITOR": "/usr/local/bin/nvim"}), fakeLookPath())
+	if want := []string{"/usr/local/bin/nvim", dir}; !slices.Equal(launch.Args, want) {
+		t.Errorf("expected args %v, got %v", want, launch.Args)
+	}
+	if !launch.Terminal {
+		t.Error("expected nvim to be treated as a terminal editor")
+	}
+}
+
+func TestResolveEditorFallsBack(t *testing.T) {
+	dir := "/repo/.worktrees/task-1"
+
+	launch, err := utils.ResolveEditor(dir, fakeEnv(nil), fakeLookPath("vim", "code"))
+	if err != nil || launch.Args[0] != "code" {
+		t.Errorf("expected code to be preferred over vim, got %+v (err %v)", launch, err)
+	}
+
+	launch, err = utils.ResolveEditor(dir, fakeEnv(nil), fakeLookPath("vim"))
+	if err != nil || launch.Args[0] != "vim" || !launch.Terminal {
+		t.Errorf("expected vim as a terminal editor, got %+v (err %v)", launch, err)
+	}
+
+	if _, err := utils.ResolveEditor(dir, fakeEnv(nil), fakeLookPath()); !errors.Is(err, utils.ErrNoEditor) {
+		t.Errorf("expected ErrNoEditor, got %v", err)
+	}
+}
@@ -0,0 +1,133 @@
+package utils_test
+
+import (
+	"strings"
+	"testing"
+
+	"ludwig/internal/utils"
+)
+
+// A run mixing messages, tool calls, commits and errors, as gemini streams it
+var mixedRun = strings.Join([]string{
+	initEvent,
+	messageEvent,
+	toolUseEvent,
+	toolResultEvent,
+	`{"type":"message","timestamp":"2025-10-10T12:00:05.000Z","role":"assistant","content":"✓ Created parser.go\n✓ Committed: Add the parser\n• Pending: Wire it up"}`,
+	toolErrorEvent,
+	`{"type":"message","timestamp":"2025-10-10T12:00:07.000Z","role":"assistant","content":"Committed: Fix the lexer"}`,
+	errorEvent,
+	"Plain output from another provider",
+	"- Committed: Update the docs",
+	resultEvent,
+}, "\n") + "\n"
+
+func feedFiltered(filter utils.OutputFilter) string {
+	stream := utils.NewTailOutputStream()
+	stream.Filter = filter
+	return stream.Feed(mixedRun)
+}
+
+func TestFilterCommitsShowsOnlyCommitLines(t *testing.T) {
+	out := strings.TrimRight(feedFiltered(utils.FILTER_COMMITS), "\n")
+
+	lines := strings.Split(out, "\n")
+	if len(lines) != 3 {
+		t.Fatalf("expected 3 commit lines, got %d: %q", len(lines), out)
+	}
+	for _, line := range lines {
+		if !utils.COMMIT_PATTERN.MatchString(line) {
+			t.Errorf("expected only commit lines, got %q", line)
+		}
+	}
+	for _, want := range []string{"Add the parser", "Fix the lexer", "Update the docs"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected commit %q in %q", want, out)
+		}
+	}
+}
+
+func TestFilterErrorsShowsOnlyFailures(t *testing.T) {
+	out := feedFiltered(utils.FILTER_ERRORS)
+
+	for _, want := range []string{"File not found: lexer.go", "Loop detected"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected error %q in %q", want, out)
+		}
+	}
+	for _, unwanted := range []string{"Reading the parser", "Using tool", "Committed", "Plain output"} {
+		if strings.Contains(out, unwanted) {
+			t.Errorf("expected %q to be filtered out, got %q", unwanted, out)
+		}
+	}
+}
+
+func TestFilterAllMatchesUnfiltered(t *testing.T) {
+	if feedFiltered(utils.FILTER_ALL) != utils.NewTailOutputStream().Feed(mixedRun) {
+		t.Error("expected the all filter to render everything")
+	}
+}
+
+func TestClassifyLine(t *testing.T) {
+	tests := map[string]utils.EventKind{
+		messageEvent:                 utils.EVENT_MESSAGE,
+		toolUseEvent:                 utils.EVENT_TOOL_CALL,
+		toolResultEvent:              utils.EVENT_TOOL_CALL,
+		toolErrorEvent:               utils.EVENT_ERROR,
+		errorEvent:                   utils.EVENT_ERROR,
+		failedEvent:                  utils.EVENT_ERROR,
+		initEvent:                    utils.EVENT_OTHER,
+		"✓ Committed: Add the lexer": utils.EVENT_COMMIT,
+		"FAIL ludwig/test/utils":     utils.EVENT_ERROR,
+		"All tests passed":           utils.EVENT_MESSAGE,
+	}
+	for line, want := range tests {
+		if got := utils.ClassifyLine(line); got != want {
+			t.Errorf("ClassifyLine(%q) = %d, want %d", line, got, want)
+		}
+	}
+}
+
+func TestOutputFilterCycles(t *testing.T) {
+	filter := utils.FILTER_ALL
+	var seen []string
+	for i := 0; i < 4; i++ {
+		seen = append(seen, filter.String())
+		filter = filter.Next()
+	}
+	if strings.Join(seen, ",") != "all,commits,errors,all" {
+		t.Errorf("unexpected cycle %v", seen)
+	}
+}
+
+func TestReplyTextKeepsOnlyMessages(t *testing.T) {
+	response := strings.Join([]string{
+		initEvent,
+		messageEvent,
+		`{"type":"message","role":"assistant","content":" Then the lexer.","delta":true}`,
+		toolUseEvent,
+		toolResultEvent,
+		`{"type":"message","role":"assistant","content":"✓ Completed: Fixed the parser","delta":true}`,
+		resultEvent,
+	}, "\n")
+	want := "Reading the parser first. Then the lexer.\n✓ Completed: Fixed the parser"
+	if got := utils.ReplyText(response); got != want {
+		t.Errorf("expected %q, got %q", want, got)
+	}
+	if got := utils.ReplyText("plain\ntext\n"); got != "plain\ntext" {
+		t.Errorf("expected plain text output as it is, got %q", got)
+	}
+}
+
+func TestReplyTextKeepsBraceLinesOfPlainText(t *testing.T) {
+	// Code and pretty-printed JSON in a plain text reply aren't split events
+	reply := "Here is code:\n{\n    return nil\n}\nand json:\n{\n  \"a\": 1\n}\ndone"
+	if got := utils.ReplyText(reply); got != reply {
+		t.Errorf("expected plain text with brace lines as it is, got %q", got)
+	}
+	// The start of an event that never completes is passed on as it was
+	broken := `{"type":"message","content":"cut off` + "\nplain text after"
+	if got := utils.ReplyText(broken); got != broken {
+		t.Errorf("expected the held line to be kept, got %q", got)
+	}
+}
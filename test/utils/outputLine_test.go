@@ -0,0 +1,65 @@
+package utils_test
+
+import (
+	"strings"
+	"testing"
+
+	"ludwig/internal/utils"
+)
+
+// Lines captured from `gemini --output-format stream-json`
+const (
+	initEvent       = `{"type":"init","timestamp":"2025-10-10T12:00:00.000Z","session_id":"abc","model":"gemini-2.5-pro"}`
+	messageEvent    = `{"type":"message","timestamp":"2025-10-10T12:00:01.000Z","role":"assistant","content":"Reading the parser first.","delta":true}`
+	toolUseEvent    = `{"type":"tool_use","timestamp":"2025-10-10T12:00:02.000Z","tool_name":"read_file","tool_id":"read-1","parameters":{"file_path":"parser.go","limit":200}}`
+	toolResultEvent = `{"type":"tool_result","timestamp":"2025-10-10T12:00:03.000Z","tool_id":"read-1","status":"success","output":"package parser"}`
+	toolErrorEvent  = `{"type":"tool_result","timestamp":"2025-10-10T12:00:04.000Z","tool_id":"edit-1","status":"error","error":{"type":"FILE_NOT_FOUND","message":"File not found: lexer.go"}}`
+	errorEvent      = `{"type":"error","timestamp":"2025-10-10T12:00:05.000Z","severity":"warning","message":"Loop detected, stopping"}`
+	resultEvent     = `{"type":"result","timestamp":"2025-10-10T12:00:06.000Z","status":"success","stats":{"total_tokens":250}}`
+	failedEvent     = `{"type":"result","timestamp":"2025-10-10T12:00:06.000Z","status":"error","error":{"type":"API","message":"quota exceeded"}}`
+)
+
+func TestOutputLineRendersEachEventType(t *testing.T) {
+	tests := []struct {
+		name  string
+		line  string
+		wants []string
+	}{
+		{"message", messageEvent, []string{"2025-10-10 12:00:01", "Reading the parser first."}},
+		{"tool use", toolUseEvent, []string{utils.TOOL_ICON + "Using tool: read_file", "  - file_path: parser.go", "  - limit: 200"}},
+		{"tool result", toolResultEvent, []string{utils.RESULT_ICON + "Tool result: success"}},
+		{"tool error", toolErrorEvent, []string{utils.RESULT_ICON + "Tool failed: File not found: lexer.go"}},
+		{"error", errorEvent, []string{utils.ERROR_ICON + "warning: Loop detected, stopping"}},
+		{"failed result", failedEvent, []string{utils.ERROR_ICON + "Run ended: quota exceeded"}},
+		{"unknown type", `{"type":"thought","content":"hmm"}`, []string{"[thought]", `"content":"hmm"`}},
+		{"plain text", "not json at all", []string{"not json at all"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := utils.OutputLine(tt.line)
+			for _, want := range tt.wants {
+				if !strings.Contains(got, want) {
+					t.Errorf("expected %q in %q", want, got)
+				}
+			}
+		})
+	}
+}
+
+func TestOutputLineHidesBookkeepingEvents(t *testing.T) {
+	for _, line := range []string{initEvent, resultEvent} {
+		if got := utils.OutputLine(line); got != "" {
+			t.Errorf("expected %s to render nothing, got %q", line, got)
+		}
+	}
+}
+
+func TestOutputLineToleratesMissingFields(t *testing.T) {
+	// Used to panic on the type assertions
+	for _, line := range []string{`{"type":"tool_use"}`, `{"type":"tool_result"}`, `{"type":"message"}`} {
+		utils.OutputLine(line)
+	}
+	if got := utils.OutputLine(`{"type":"tool_use","tool_name":"shell"}`); !strings.Contains(got, "Using tool: shell") || strings.Contains(got, "With parameters") {
+		t.Errorf("expected a tool call without parameters, got %q", got)
+	}
+}
@@ -0,0 +1,95 @@
+package utils_test
+
+import (
+	"strings"
+	"testing"
+
+	"ludwig/internal/utils"
+)
+
+const streamHeader = "# AI Response for Task: t\n\nGenerated: now\n\n---\n\nskip one\nskip two\n"
+
+func TestOutputStreamMatchesOutputLines(t *testing.T) {
+	file := streamHeader + "hello world\nsecond line\n\n---\n\nCompleted: now\n"
+
+	full := utils.OutputLines(strings.Split(file, "\n"))
+
+	stream := utils.NewOutputStream()
+	var incremental strings.Builder
+	// Feed the file a few bytes at a time, splitting lines across chunks
+	for i := 0; i < len(file); i += 7 {
+		end := min(i+7, len(file))
+		incremental.WriteString(stream.Feed(file[i:end]))
+	}
+
+	if incremental.String() != full {
+		t.Errorf("expected incremental output %q to match full output %q", incremental.String(), full)
+	}
+}
+
+func TestOutputStreamHoldsPartialLine(t *testing.T) {
+	stream := utils.NewOutputStream()
+	stream.Feed(streamHeader)
+
+	if out := stream.Feed("partial"); out != "" {
+		t.Errorf("expected partial line to be held back, got %q", out)
+	}
+	out := stream.Feed(" line\n")
+	if !strings.Contains(out, "partial line") {
+		t.Errorf("expected completed line once newline arrives, got %q", out)
+	}
+}
+
+func TestOutputStreamStopsAtFooter(t *testing.T) {
+	stream := utils.NewOutputStream()
+	stream.Feed(streamHeader + "body\n")
+
+	if out := stream.Feed("---\n\nCompleted: now\nafter footer\n"); strings.Contains(out, "after footer") {
+		t.Errorf("expected nothing rendered after the footer, got %q", out)
+	}
+}
+
+func TestTailOutputStreamRendersImmediately(t *testing.T) {
+	stream := utils.NewTailOutputStream()
+	if out := stream.Feed("mid-file line\n"); !strings.Contains(out, "mid-file line") {
+		t.Errorf("expected tail stream to render without a header, got %q", out)
+	}
+}
+
+func TestOutputStreamReassemblesSplitEvent(t *testing.T) {
+	event := `{"type":"message","role":"assistant","content":"reassembled reply"}`
+	split := len(event) / 2
+
+	// Split across read chunks within one line
+	stream := utils.NewOutputStream()
+	stream.Feed(streamHeader)
+	if out := stream.Feed(event[:split]); out != "" {
+		t.Errorf("expected half an event to be held back, got %q", out)
+	}
+	if out := stream.Feed(event[split:] + "\n"); !strings.Contains(out, "reassembled reply") {
+		t.Errorf("expected the event to render once complete, got %q", out)
+	}
+
+	// Split across lines, as a CLI wrapping its output does
+	stream = utils.NewOutputStream()
+	stream.Feed(streamHeader)
+	if out := stream.Feed(event[:split] + "\n"); out != "" {
+		t.Errorf("expected the start of an event to be held back, got %q", out)
+	}
+	if out := stream.Feed(event[split:] + "\n"); !strings.Contains(out, "reassembled reply") {
+		t.Errorf("expected the event to be reassembled, got %q", out)
+	}
+}
+
+func TestOutputStreamDropsEventThatNeverCompletes(t *testing.T) {
+	stream := utils.NewOutputStream()
+	stream.Feed(streamHeader)
+
+	out := stream.Feed(`{"type":"message","content":"cut off` + "\n" + `{"type":"message","content":"next reply"}` + "\nplain text after\n")
+	if strings.Contains(out, "cut off") {
+		t.Errorf("expected the broken event to be dropped, got %q", out)
+	}
+	if !strings.Contains(out, "next reply") || !strings.Contains(out, "plain text after") {
+		t.Errorf("expected the lines after it to render, got %q", out)
+	}
+}